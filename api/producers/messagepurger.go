@@ -0,0 +1,81 @@
+package producers
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+)
+
+// MessagePurger - background job, that deletes idempotency keys of processed messages older than retention,
+// so processed_messages table doesn't grow forever
+type MessagePurger struct {
+	pms       stores.ProcessedMessageStore
+	retention time.Duration
+	interval  time.Duration
+	done      chan struct{}
+}
+
+// NewMessagePurger - Default method for creation MessagePurger, requires connection to database. Retention of keys and
+// interval of purging are read from PROCESSED_MESSAGE_RETENTION and PROCESSED_MESSAGE_PURGE_INTERVAL environment
+// variables
+func NewMessagePurger(conn *sqlx.DB) *MessagePurger {
+	purger := MessagePurger{}
+	purger.SetPMS(stores.NewPMS(conn))
+	purger.SetRetention(readDurationVariable("PROCESSED_MESSAGE_RETENTION", 7*24*time.Hour))
+	purger.SetInterval(readDurationVariable("PROCESSED_MESSAGE_PURGE_INTERVAL", time.Hour))
+	return &purger
+}
+
+// SetPMS - Dependency injection of stores.ProcessedMessageStore
+func (mp *MessagePurger) SetPMS(pms stores.ProcessedMessageStore) {
+	mp.pms = pms
+}
+
+// SetRetention - sets duration, after which key is purged
+func (mp *MessagePurger) SetRetention(retention time.Duration) {
+	mp.retention = retention
+}
+
+// SetInterval - sets interval between purges
+func (mp *MessagePurger) SetInterval(interval time.Duration) {
+	mp.interval = interval
+}
+
+// Setup - starts purging in background
+func (mp *MessagePurger) Setup() {
+	mp.done = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(mp.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				mp.Purge()
+			case <-mp.done:
+				return
+			}
+		}
+	}()
+}
+
+// Purge - deletes keys reserved earlier than retention ago
+func (mp *MessagePurger) Purge() {
+	purged, err := mp.pms.Purge(time.Now().Add(-mp.retention))
+	if err != nil {
+		slog.Error(fmt.Sprintf("error purging processed messages: %v", err))
+		return
+	}
+	if purged > 0 {
+		slog.Info(fmt.Sprintf("%d keys of processed messages were purged", purged))
+	}
+}
+
+// Stop - Closure for stopping purging
+func (mp *MessagePurger) Stop() {
+	if mp.done != nil {
+		close(mp.done)
+	}
+}
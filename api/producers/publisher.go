@@ -0,0 +1,12 @@
+package producers
+
+import (
+	"context"
+
+	rs "github.com/fridrock/rabbitsimplier"
+)
+
+// publisher - sender of notifications, it is RProducer of background job, which is replaced in tests
+type publisher interface {
+	PublishMessage(ctx context.Context, exchangeName, routingKey, body string, messageConfig ...rs.MessageConfig) error
+}
@@ -0,0 +1,125 @@
+package producers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"time"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// RestOverRoutingKey - routing key of notification about finished rest after set
+	RestOverRoutingKey = "tgbot.training.restover"
+	// restTimerLease - time, for which due timer is claimed by scheduler, timer, that isn't removed by then,
+	// is fired again
+	restTimerLease = 30 * time.Second
+)
+
+// RestOverEvent - notification, that is published when rest interval of exercise elapses after set
+type RestOverEvent struct {
+	Event string           `json:"event"`
+	Timer stores.RestTimer `json:"timer"`
+}
+
+// RestScheduler - background job, that fires rest timers. Timers are kept in rest_timers table,
+// so the ones, that elapsed while service was down, are fired after restart, and are claimed before firing,
+// so every timer is fired by one of running instances
+type RestScheduler struct {
+	rs.RProducer
+	publisher publisher
+	rts       stores.RestTimerStore
+	interval  time.Duration
+	done      chan struct{}
+}
+
+// NewRestScheduler - Default method for creation RestScheduler, requires rs.Configurer to create channel for producer
+// and connection to database shared by its stores. Interval of checking timers is read from REST_TIMER_INTERVAL
+// environment variable
+func NewRestScheduler(configurer rs.Configurer, conn *sqlx.DB) *RestScheduler {
+	scheduler := RestScheduler{}
+	scheduler.CreateProducer(configurer)
+	scheduler.SetRTS(stores.NewRTS(conn))
+	scheduler.SetInterval(readDurationVariable("REST_TIMER_INTERVAL", time.Second))
+	return &scheduler
+}
+
+// CreateProducer - helper method
+func (rsc *RestScheduler) CreateProducer(configurer rs.Configurer) {
+	rsc.RProducer = rs.RProducer{}
+	err := rsc.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for RestScheduler")
+	}
+	rsc.publisher = &rsc.RProducer
+}
+
+// SetRTS - Dependency injection of stores.RestTimerStore
+func (rsc *RestScheduler) SetRTS(rts stores.RestTimerStore) {
+	rsc.rts = rts
+}
+
+// SetInterval - sets interval between checks of timers
+func (rsc *RestScheduler) SetInterval(interval time.Duration) {
+	rsc.interval = interval
+}
+
+// Setup - starts firing timers in background
+func (rsc *RestScheduler) Setup() {
+	rsc.done = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(rsc.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				rsc.Fire(time.Now())
+			case <-rsc.done:
+				return
+			}
+		}
+	}()
+}
+
+// Fire - notifies users of timers elapsed by now. Timer is removed only after notification is published,
+// so it is fired again after lease of claim, if publishing failed
+func (rsc *RestScheduler) Fire(now time.Time) {
+	timers, err := rsc.rts.ClaimDue(now, restTimerLease)
+	if err != nil {
+		slog.Error(fmt.Sprintf("error finding due rest timers: %v", err))
+		return
+	}
+	for _, timer := range timers {
+		slog.Info(fmt.Sprintf("rest after set %d of user %d is over", timer.SetId, timer.UserId))
+		event := RestOverEvent{
+			Event: "training.restover",
+			Timer: timer,
+		}
+		err = rsc.publisher.PublishMessage(
+			context.Background(),
+			EXCHANGE_NAME,
+			RestOverRoutingKey,
+			responses.Success(event).Encode(false))
+		if err != nil {
+			slog.Error(fmt.Sprintf("error publishing restover event of set %d: %v", timer.SetId, err))
+			continue
+		}
+		err = rsc.rts.Remove(timer)
+		if err != nil {
+			slog.Error(fmt.Sprintf("error removing rest timer of set %d: %v", timer.SetId, err))
+		}
+	}
+}
+
+// Stop - Closure for stopping scheduler and closing channel of producer
+func (rsc *RestScheduler) Stop() {
+	if rsc.done != nil {
+		close(rsc.done)
+	}
+	rsc.RProducer.Stop()
+}
@@ -0,0 +1,89 @@
+package producers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+// timerStore - rest timer store, which keeps timers in memory and claims them like RTS does
+type timerStore struct {
+	stores.RestTimerStoreStub
+	timers  []stores.RestTimer
+	claimed map[int64]time.Time
+	removed []int64
+}
+
+func (ts *timerStore) ClaimDue(now time.Time, lease time.Duration) ([]stores.RestTimer, error) {
+	var due []stores.RestTimer
+	for _, timer := range ts.timers {
+		if !timer.FiresAt.After(now) && !ts.claimed[timer.Id].After(now) {
+			ts.claimed[timer.Id] = now.Add(lease)
+			due = append(due, timer)
+		}
+	}
+	return due, nil
+}
+
+func (ts *timerStore) Remove(timer stores.RestTimer) error {
+	for i, pending := range ts.timers {
+		if pending.Id == timer.Id {
+			ts.timers = append(ts.timers[:i], ts.timers[i+1:]...)
+			ts.removed = append(ts.removed, timer.Id)
+			return nil
+		}
+	}
+	return nil
+}
+
+func newTestScheduler(timers []stores.RestTimer) (*RestScheduler, *timerStore, *publisherStub) {
+	p := &publisherStub{}
+	rts := &timerStore{timers: timers, claimed: map[int64]time.Time{}}
+	scheduler := &RestScheduler{publisher: p}
+	scheduler.SetRTS(rts)
+	return scheduler, rts, p
+}
+
+var firesAt = time.Date(2024, 6, 10, 18, 0, 0, 0, time.UTC)
+
+func TestFireNotifiesDueTimers(t *testing.T) {
+	scheduler, rts, p := newTestScheduler([]stores.RestTimer{
+		{Id: 1, UserId: 2, SetId: 3, Rest: 90, FiresAt: firesAt},
+		{Id: 2, UserId: 3, SetId: 4, Rest: 60, FiresAt: firesAt.Add(time.Minute)},
+	})
+	scheduler.Fire(firesAt)
+	if len(p.messages) != 1 || p.messages[0].routingKey != RestOverRoutingKey ||
+		!strings.Contains(p.messages[0].body, `"event":"training.restover"`) ||
+		!strings.Contains(p.messages[0].body, `"set_id":3`) {
+		t.Fatalf("published wrong notifications: %#v", p.messages)
+	}
+	if len(rts.removed) != 1 || rts.removed[0] != 1 {
+		t.Errorf("removed wrong timers: %v", rts.removed)
+	}
+	scheduler.Fire(firesAt.Add(time.Minute))
+	if len(p.messages) != 2 || !strings.Contains(p.messages[1].body, `"set_id":4`) || len(rts.timers) != 0 {
+		t.Errorf("second timer isn't fired once: %#v, timers: %#v", p.messages, rts.timers)
+	}
+}
+
+func TestFireRetriesAfterLease(t *testing.T) {
+	scheduler, rts, p := newTestScheduler([]stores.RestTimer{{Id: 1, UserId: 2, SetId: 3, FiresAt: firesAt}})
+	p.err = errors.New("channel closed")
+	scheduler.Fire(firesAt)
+	if len(rts.removed) != 0 {
+		t.Fatalf("timer removed without notification: %v", rts.removed)
+	}
+	p.err = nil
+	//timer is claimed until lease expires
+	scheduler.Fire(firesAt.Add(time.Second))
+	if len(p.messages) != 0 {
+		t.Fatalf("claimed timer fired before lease expired: %#v", p.messages)
+	}
+	scheduler.Fire(firesAt.Add(restTimerLease))
+	if len(p.messages) != 1 || len(rts.removed) != 1 {
+		t.Errorf("timer isn't fired after lease: %#v, removed: %v", p.messages, rts.removed)
+	}
+}
@@ -0,0 +1,194 @@
+package producers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"log/slog"
+	"time"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/reminder"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// ReminderRoutingKey - routing key of reminder about scheduled training
+	ReminderRoutingKey = "tgbot.reminder.training"
+	// MissedRoutingKey - routing key of notification about missed scheduled training
+	MissedRoutingKey = "tgbot.reminder.missed"
+)
+
+// ReminderEvent - notification about slot of schedule, scheduled at is occurrence of slot in timezone of user
+type ReminderEvent struct {
+	Event       string              `json:"event"`
+	UserId      int64               `json:"user_id"`
+	Slot        stores.ScheduleSlot `json:"slot"`
+	ScheduledAt time.Time           `json:"scheduled_at"`
+}
+
+// TrainingReminder - background job, that reminds users about scheduled trainings, when slot arrives and
+// training isn't started yet, and notifies them about missed ones
+type TrainingReminder struct {
+	rs.RProducer
+	publisher   publisher
+	scs         stores.ScheduleStore
+	ts          stores.TrainingStore
+	missedAfter time.Duration
+	interval    time.Duration
+	done        chan struct{}
+}
+
+// NewTrainingReminder - Default method for creation TrainingReminder, requires rs.Configurer to create channel for
+// producer and connection to database shared by its stores. Time after slot, when training is considered missed, and
+// interval of checking are read from REMINDER_MISSED_AFTER and REMINDER_INTERVAL environment variables
+func NewTrainingReminder(configurer rs.Configurer, conn *sqlx.DB) *TrainingReminder {
+	trainingReminder := TrainingReminder{}
+	trainingReminder.CreateProducer(configurer)
+	trainingReminder.SetSCS(stores.NewSCS(conn))
+	trainingReminder.SetTS(stores.NewTs(conn))
+	trainingReminder.SetMissedAfter(readDurationVariable("REMINDER_MISSED_AFTER", 3*time.Hour))
+	trainingReminder.SetInterval(readDurationVariable("REMINDER_INTERVAL", time.Minute))
+	return &trainingReminder
+}
+
+// CreateProducer - helper method
+func (tr *TrainingReminder) CreateProducer(configurer rs.Configurer) {
+	tr.RProducer = rs.RProducer{}
+	err := tr.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for TrainingReminder")
+	}
+	tr.publisher = &tr.RProducer
+}
+
+// SetSCS - Dependency injection of stores.ScheduleStore
+func (tr *TrainingReminder) SetSCS(scs stores.ScheduleStore) {
+	tr.scs = scs
+}
+
+// SetTS - Dependency injection of stores.TrainingStore
+func (tr *TrainingReminder) SetTS(ts stores.TrainingStore) {
+	tr.ts = ts
+}
+
+// SetMissedAfter - sets duration after slot, when training, which wasn't started, is considered missed
+func (tr *TrainingReminder) SetMissedAfter(missedAfter time.Duration) {
+	tr.missedAfter = missedAfter
+}
+
+// SetInterval - sets interval between checks of schedules
+func (tr *TrainingReminder) SetInterval(interval time.Duration) {
+	tr.interval = interval
+}
+
+// Setup - starts checking schedules in background
+func (tr *TrainingReminder) Setup() {
+	tr.done = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(tr.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				tr.Check(time.Now())
+			case <-tr.done:
+				return
+			}
+		}
+	}()
+}
+
+// Check - reminds about slots, which arrived by now, and notifies about missed ones. Every occurrence of slot
+// is handled once, occurrences before slot was created are skipped
+func (tr *TrainingReminder) Check(now time.Time) {
+	slots, err := tr.scs.FindAll()
+	if err != nil {
+		slog.Error(fmt.Sprintf("error finding schedules: %v", err))
+		return
+	}
+	for _, slot := range slots {
+		loc, err := reminder.LoadLocation(slot.Timezone)
+		if err != nil {
+			slog.Error(fmt.Sprintf("error loading timezone of slot %d: %v", slot.Id, err))
+			continue
+		}
+		occurrence, err := reminder.LastOccurrence(slot.Weekday, slot.At, loc, now)
+		if err != nil {
+			slog.Error(fmt.Sprintf("error finding occurrence of slot %d: %v", slot.Id, err))
+			continue
+		}
+		if occurrence.Before(slot.CreatedAt) {
+			continue
+		}
+		if now.Sub(occurrence) < tr.missedAfter {
+			if !handled(slot.RemindedAt, occurrence) {
+				tr.notify(slot, occurrence, loc, tr.scs.MarkReminded, "reminder.training", ReminderRoutingKey)
+			}
+		} else if !handled(slot.MissedAt, occurrence) {
+			tr.notify(slot, occurrence, loc, tr.scs.MarkMissed, "reminder.missed", MissedRoutingKey)
+		}
+	}
+}
+
+// notify - marks occurrence of slot as handled with mark and publishes event, if user hasn't trained yet
+func (tr *TrainingReminder) notify(slot stores.ScheduleSlot, occurrence time.Time, loc *time.Location,
+	mark func(int64, time.Time) error, eventName string, routingKey string) {
+	trained, err := tr.trained(slot.UserId, occurrence, loc)
+	if err != nil {
+		slog.Error(fmt.Sprintf("error checking trainings of user %d: %v", slot.UserId, err))
+		return
+	}
+	//marking before publishing, so occurrence isn't notified twice
+	err = mark(slot.Id, occurrence)
+	if err == stores.NotUpdated || trained {
+		return
+	}
+	if err != nil {
+		slog.Error(fmt.Sprintf("error marking slot %d: %v", slot.Id, err))
+		return
+	}
+	slog.Info(fmt.Sprintf("publishing %s of slot %d to user %d", eventName, slot.Id, slot.UserId))
+	event := ReminderEvent{
+		Event:       eventName,
+		UserId:      slot.UserId,
+		Slot:        slot,
+		ScheduledAt: occurrence.In(loc),
+	}
+	err = tr.publisher.PublishMessage(
+		context.Background(),
+		EXCHANGE_NAME,
+		routingKey,
+		responses.Success(event).Encode(false))
+	if err != nil {
+		slog.Error(fmt.Sprintf("error publishing %s of slot %d: %v", eventName, slot.Id, err))
+	}
+}
+
+// trained - whether user has started training for occurrence of slot, based on the last training of user
+func (tr *TrainingReminder) trained(userId int64, occurrence time.Time, loc *time.Location) (bool, error) {
+	training, err := tr.ts.GetLastTraining(userId)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return reminder.Trained(training.Begins, occurrence, loc), nil
+}
+
+// handled - whether occurrence was already handled, when the last handled one is at
+func handled(at *time.Time, occurrence time.Time) bool {
+	return at != nil && !at.Before(occurrence)
+}
+
+// Stop - Closure for stopping reminder and closing channel of producer
+func (tr *TrainingReminder) Stop() {
+	if tr.done != nil {
+		close(tr.done)
+	}
+	tr.RProducer.Stop()
+}
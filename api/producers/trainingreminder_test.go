@@ -0,0 +1,147 @@
+package producers
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+// slotStore - schedule store, which keeps slots in memory and marks them like SCS does
+type slotStore struct {
+	stores.ScheduleStoreStub
+	slots []stores.ScheduleSlot
+}
+
+func (ss *slotStore) FindAll() ([]stores.ScheduleSlot, error) {
+	return ss.slots, nil
+}
+
+func (ss *slotStore) MarkReminded(slotId int64, occurrence time.Time) error {
+	return ss.mark(slotId, occurrence, func(slot *stores.ScheduleSlot) **time.Time { return &slot.RemindedAt })
+}
+
+func (ss *slotStore) MarkMissed(slotId int64, occurrence time.Time) error {
+	return ss.mark(slotId, occurrence, func(slot *stores.ScheduleSlot) **time.Time { return &slot.MissedAt })
+}
+
+func (ss *slotStore) mark(slotId int64, occurrence time.Time, field func(*stores.ScheduleSlot) **time.Time) error {
+	for i := range ss.slots {
+		at := field(&ss.slots[i])
+		if ss.slots[i].Id == slotId && (*at == nil || (*at).Before(occurrence)) {
+			*at = &occurrence
+			return nil
+		}
+	}
+	return stores.NotUpdated
+}
+
+// lastTrainingStore - training store, which returns the last training of user from trainings
+type lastTrainingStore struct {
+	stores.TrainingStoreStub
+	trainings map[int64]stores.Training
+}
+
+func (lts lastTrainingStore) GetLastTraining(userId int64) (stores.Training, error) {
+	training, ok := lts.trainings[userId]
+	if !ok {
+		return stores.Training{}, sql.ErrNoRows
+	}
+	return training, nil
+}
+
+var berlin, _ = time.LoadLocation("Europe/Berlin")
+
+func newTestReminder(slots []stores.ScheduleSlot, trainings map[int64]stores.Training) (*TrainingReminder,
+	*slotStore, *publisherStub) {
+	p := &publisherStub{}
+	scs := &slotStore{slots: slots}
+	tr := &TrainingReminder{publisher: p}
+	tr.SetSCS(scs)
+	tr.SetTS(lastTrainingStore{trainings: trainings})
+	tr.SetMissedAfter(3 * time.Hour)
+	return tr, scs, p
+}
+
+// mondaySlot - slot of user 2 on mondays at 18:00 in Berlin, created long before checks
+func mondaySlot() stores.ScheduleSlot {
+	return stores.ScheduleSlot{Id: 1, UserId: 2, Weekday: "mon", At: "18:00", Timezone: "Europe/Berlin",
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestCheckRemindsAndNotifiesMissed(t *testing.T) {
+	tr, scs, p := newTestReminder([]stores.ScheduleSlot{mondaySlot()}, nil)
+	occurrence := time.Date(2024, 6, 10, 18, 0, 0, 0, berlin)
+	tr.Check(occurrence.Add(10 * time.Minute))
+	tr.Check(occurrence.Add(20 * time.Minute))
+	if len(p.messages) != 1 || p.messages[0].routingKey != ReminderRoutingKey ||
+		!strings.Contains(p.messages[0].body, `"scheduled_at":"2024-06-10T18:00:00+02:00"`) {
+		t.Fatalf("published wrong reminders: %#v", p.messages)
+	}
+	if scs.slots[0].RemindedAt == nil || !scs.slots[0].RemindedAt.Equal(occurrence) {
+		t.Errorf("slot isn't marked as reminded: %#v", scs.slots[0])
+	}
+	tr.Check(occurrence.Add(4 * time.Hour))
+	tr.Check(occurrence.Add(5 * time.Hour))
+	if len(p.messages) != 2 || p.messages[1].routingKey != MissedRoutingKey ||
+		!strings.Contains(p.messages[1].body, `"event":"reminder.missed"`) {
+		t.Fatalf("published wrong missed notifications: %#v", p.messages)
+	}
+	if scs.slots[0].MissedAt == nil || !scs.slots[0].MissedAt.Equal(occurrence) {
+		t.Errorf("slot isn't marked as missed: %#v", scs.slots[0])
+	}
+	//next occurrence is reminded again
+	tr.Check(occurrence.AddDate(0, 0, 7).Add(time.Minute))
+	if len(p.messages) != 3 || p.messages[2].routingKey != ReminderRoutingKey {
+		t.Errorf("next occurrence isn't reminded: %#v", p.messages)
+	}
+}
+
+func TestCheckSkipsTrainedOccurrence(t *testing.T) {
+	occurrence := time.Date(2024, 6, 10, 18, 0, 0, 0, berlin)
+	//training began in the morning of the same day
+	trainings := map[int64]stores.Training{2: {Id: 1, UserId: 2, Begins: occurrence.Add(-8 * time.Hour)}}
+	tr, scs, p := newTestReminder([]stores.ScheduleSlot{mondaySlot()}, trainings)
+	tr.Check(occurrence.Add(10 * time.Minute))
+	tr.Check(occurrence.Add(4 * time.Hour))
+	if len(p.messages) != 0 {
+		t.Errorf("published notifications about trained occurrence: %#v", p.messages)
+	}
+	if scs.slots[0].RemindedAt == nil || scs.slots[0].MissedAt == nil {
+		t.Errorf("trained occurrence isn't marked as handled: %#v", scs.slots[0])
+	}
+}
+
+func TestCheckSkipsOccurrenceBeforeCreation(t *testing.T) {
+	occurrence := time.Date(2024, 6, 10, 18, 0, 0, 0, berlin)
+	slot := mondaySlot()
+	slot.CreatedAt = occurrence.Add(5 * time.Minute)
+	tr, scs, p := newTestReminder([]stores.ScheduleSlot{slot}, nil)
+	tr.Check(occurrence.Add(10 * time.Minute))
+	tr.Check(occurrence.Add(4 * time.Hour))
+	if len(p.messages) != 0 || scs.slots[0].RemindedAt != nil || scs.slots[0].MissedAt != nil {
+		t.Errorf("occurrence before creation of slot was handled: %#v, %#v", p.messages, scs.slots[0])
+	}
+}
+
+func TestCheckAcrossDaylightSavingTransition(t *testing.T) {
+	//clocks moved forward at 2024-03-31 02:00 in Berlin, slot at 02:30 is shifted to 03:30
+	slot := stores.ScheduleSlot{Id: 1, UserId: 2, Weekday: "sun", At: "02:30", Timezone: "Europe/Berlin",
+		CreatedAt: time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC)}
+	tr, _, p := newTestReminder([]stores.ScheduleSlot{slot}, nil)
+	tr.Check(time.Date(2024, 3, 31, 3, 20, 0, 0, berlin))
+	if len(p.messages) != 0 {
+		t.Fatalf("reminded before shifted time of slot: %#v", p.messages)
+	}
+	tr.Check(time.Date(2024, 3, 31, 3, 40, 0, 0, berlin))
+	if len(p.messages) != 1 || !strings.Contains(p.messages[0].body, `"scheduled_at":"2024-03-31T03:30:00+02:00"`) {
+		t.Errorf("published wrong reminder at day of transition: %#v", p.messages)
+	}
+	//wall clock of slot is kept after transition
+	tr.Check(time.Date(2024, 4, 7, 2, 35, 0, 0, berlin))
+	if len(p.messages) != 2 || !strings.Contains(p.messages[1].body, `"scheduled_at":"2024-04-07T02:30:00+02:00"`) {
+		t.Errorf("published wrong reminder after transition: %#v", p.messages)
+	}
+}
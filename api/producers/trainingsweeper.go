@@ -0,0 +1,135 @@
+package producers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"time"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	EXCHANGE_NAME = responses.Exchange
+	// AutoClosedRoutingKey - routing key of notification about automatically closed training
+	AutoClosedRoutingKey = "tgbot.training.autoclosed"
+)
+
+// AutoClosedEvent - notification, that is published for every automatically closed training
+type AutoClosedEvent struct {
+	Event    string          `json:"event"`
+	Training stores.Training `json:"training"`
+}
+
+// TrainingSweeper - background job, that closes trainings, which stay in progress for too long
+type TrainingSweeper struct {
+	rs.RProducer
+	publisher   publisher
+	ts          stores.TrainingStore
+	maxDuration time.Duration
+	interval    time.Duration
+	done        chan struct{}
+}
+
+// NewTrainingSweeper - Default method for creation TrainingSweeper, requires rs.Configurer to create channel for
+// producer and connection to database shared by its stores. Maximum duration of training and interval of sweeping are
+// read from TRAINING_MAX_DURATION and TRAINING_SWEEP_INTERVAL environment variables
+func NewTrainingSweeper(configurer rs.Configurer, conn *sqlx.DB) *TrainingSweeper {
+	sweeper := TrainingSweeper{}
+	sweeper.CreateProducer(configurer)
+	sweeper.SetTS(stores.NewTs(conn))
+	sweeper.SetMaxDuration(readDurationVariable("TRAINING_MAX_DURATION", 6*time.Hour))
+	sweeper.SetInterval(readDurationVariable("TRAINING_SWEEP_INTERVAL", 10*time.Minute))
+	return &sweeper
+}
+
+// CreateProducer - helper method
+func (tsw *TrainingSweeper) CreateProducer(configurer rs.Configurer) {
+	tsw.RProducer = rs.RProducer{}
+	err := tsw.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for TrainingSweeper")
+	}
+	tsw.publisher = &tsw.RProducer
+}
+
+// SetTS - Dependency injection of stores.TrainingStore
+func (tsw *TrainingSweeper) SetTS(ts stores.TrainingStore) {
+	tsw.ts = ts
+}
+
+// SetMaxDuration - sets duration, after which training in progress is closed
+func (tsw *TrainingSweeper) SetMaxDuration(maxDuration time.Duration) {
+	tsw.maxDuration = maxDuration
+}
+
+// SetInterval - sets interval between sweeps
+func (tsw *TrainingSweeper) SetInterval(interval time.Duration) {
+	tsw.interval = interval
+}
+
+// Setup - starts sweeping in background
+func (tsw *TrainingSweeper) Setup() {
+	tsw.done = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(tsw.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				tsw.Sweep()
+			case <-tsw.done:
+				return
+			}
+		}
+	}()
+}
+
+// Sweep - closes stale trainings and notifies their users
+func (tsw *TrainingSweeper) Sweep() {
+	trainings, err := tsw.ts.CloseStale(tsw.maxDuration)
+	if err != nil {
+		slog.Error(fmt.Sprintf("error closing stale trainings: %v", err))
+		return
+	}
+	for _, training := range trainings {
+		slog.Info(fmt.Sprintf("training %d of user %d was closed as %s", training.Id, training.UserId, training.Status))
+		event := AutoClosedEvent{
+			Event:    "training.autoclosed",
+			Training: training,
+		}
+		err = tsw.publisher.PublishMessage(
+			context.Background(),
+			EXCHANGE_NAME,
+			AutoClosedRoutingKey,
+			responses.Success(event).Encode(false))
+		if err != nil {
+			slog.Error(fmt.Sprintf("error publishing autoclosed event of training %d: %v", training.Id, err))
+		}
+	}
+}
+
+// Stop - Closure for stopping sweeping and closing channel of producer
+func (tsw *TrainingSweeper) Stop() {
+	if tsw.done != nil {
+		close(tsw.done)
+	}
+	tsw.RProducer.Stop()
+}
+
+func readDurationVariable(variableName string, defaultValue time.Duration) time.Duration {
+	value, exists := os.LookupEnv(variableName)
+	if !exists {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Can't parse env variable: %v", variableName)
+	}
+	return duration
+}
@@ -0,0 +1,91 @@
+package producers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+// message - notification sent by publisherStub
+type message struct {
+	routingKey string
+	body       string
+}
+
+// publisherStub - publisher, which keeps messages instead of sending them, or fails with err
+type publisherStub struct {
+	messages []message
+	err      error
+}
+
+func (ps *publisherStub) PublishMessage(ctx context.Context, exchangeName, routingKey, body string,
+	messageConfig ...rs.MessageConfig) error {
+	if exchangeName != EXCHANGE_NAME {
+		return errors.New("wrong exchange " + exchangeName)
+	}
+	if ps.err != nil {
+		return ps.err
+	}
+	ps.messages = append(ps.messages, message{routingKey, body})
+	return nil
+}
+
+// staleTrainingStore - training store, which closes trainings as stale
+type staleTrainingStore struct {
+	stores.TrainingStoreStub
+	closed      []stores.Training
+	err         error
+	maxDuration time.Duration
+}
+
+func (sts *staleTrainingStore) CloseStale(maxDuration time.Duration) ([]stores.Training, error) {
+	sts.maxDuration = maxDuration
+	return sts.closed, sts.err
+}
+
+func newTestSweeper(ts stores.TrainingStore) (*TrainingSweeper, *publisherStub) {
+	p := &publisherStub{}
+	sweeper := &TrainingSweeper{publisher: p}
+	sweeper.SetTS(ts)
+	sweeper.SetMaxDuration(6 * time.Hour)
+	return sweeper, p
+}
+
+func TestSweepNotifiesClosedTrainings(t *testing.T) {
+	ts := &staleTrainingStore{closed: []stores.Training{
+		{Id: 1, UserId: 2, Status: stores.Abandoned},
+		{Id: 3, UserId: 4, Status: stores.Finished},
+	}}
+	sweeper, p := newTestSweeper(ts)
+	sweeper.Sweep()
+	if ts.maxDuration != 6*time.Hour {
+		t.Errorf("trainings were closed with wrong maximum duration: %s", ts.maxDuration)
+	}
+	if len(p.messages) != 2 {
+		t.Fatalf("published wrong amount of notifications: %#v", p.messages)
+	}
+	for i, m := range p.messages {
+		if m.routingKey != AutoClosedRoutingKey || !strings.Contains(m.body, `"event":"training.autoclosed"`) ||
+			!strings.Contains(m.body, `"status":"`+string(ts.closed[i].Status)+`"`) {
+			t.Errorf("published wrong notification: %#v", m)
+		}
+	}
+}
+
+func TestSweepWithoutStaleTrainings(t *testing.T) {
+	sweeper, p := newTestSweeper(&staleTrainingStore{})
+	sweeper.Sweep()
+	sweeper.SetTS(&staleTrainingStore{
+		closed: []stores.Training{{Id: 1}},
+		err:    errors.New("connection refused"),
+	})
+	sweeper.Sweep()
+	if len(p.messages) != 0 {
+		t.Errorf("published notifications without closed trainings: %#v", p.messages)
+	}
+}
@@ -0,0 +1,79 @@
+package producers
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+)
+
+// TrashPurger - background job, that permanently deletes items, which stay in trash longer than retention
+type TrashPurger struct {
+	trs       stores.TrashStore
+	retention time.Duration
+	interval  time.Duration
+	done      chan struct{}
+}
+
+// NewTrashPurger - Default method for creation TrashPurger, requires connection to database. Retention of deleted items
+// and interval of purging are read from TRASH_RETENTION and TRASH_PURGE_INTERVAL environment variables
+func NewTrashPurger(conn *sqlx.DB) *TrashPurger {
+	purger := TrashPurger{}
+	purger.SetTRS(stores.NewTRS(conn))
+	purger.SetRetention(readDurationVariable("TRASH_RETENTION", 30*24*time.Hour))
+	purger.SetInterval(readDurationVariable("TRASH_PURGE_INTERVAL", time.Hour))
+	return &purger
+}
+
+// SetTRS - Dependency injection of stores.TrashStore
+func (tp *TrashPurger) SetTRS(trs stores.TrashStore) {
+	tp.trs = trs
+}
+
+// SetRetention - sets duration, after which deleted item is purged
+func (tp *TrashPurger) SetRetention(retention time.Duration) {
+	tp.retention = retention
+}
+
+// SetInterval - sets interval between purges
+func (tp *TrashPurger) SetInterval(interval time.Duration) {
+	tp.interval = interval
+}
+
+// Setup - starts purging in background
+func (tp *TrashPurger) Setup() {
+	tp.done = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(tp.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				tp.Purge()
+			case <-tp.done:
+				return
+			}
+		}
+	}()
+}
+
+// Purge - permanently deletes items deleted earlier than retention ago
+func (tp *TrashPurger) Purge() {
+	purged, err := tp.trs.Purge(time.Now().Add(-tp.retention))
+	if err != nil {
+		slog.Error(fmt.Sprintf("error purging trash: %v", err))
+		return
+	}
+	if purged > 0 {
+		slog.Info(fmt.Sprintf("%d deleted items were purged from trash", purged))
+	}
+}
+
+// Stop - Closure for stopping purging
+func (tp *TrashPurger) Stop() {
+	if tp.done != nil {
+		close(tp.done)
+	}
+}
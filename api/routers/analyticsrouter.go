@@ -0,0 +1,194 @@
+package routers
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/analytics"
+	"github.com/fridrock/trainingservice/api/utils/converters"
+	"github.com/fridrock/trainingservice/api/utils/muscles"
+	"github.com/fridrock/trainingservice/api/utils/reminder"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/api/utils/units"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// AnalyticsRouter - structure, that contains both consumer, and producer for messaging inside Analytics domain
+type AnalyticsRouter struct {
+	rs.RConsumer
+	rs.RProducer
+	ans    stores.AnalyticsStore
+	uss    stores.SettingsStore
+	routes map[string]func(amqp091.Delivery) responses.Response
+}
+
+// NewAnalyticsRouter - Default method for creation AnalyticsRouter, requires rs.Configurer to create channels
+// for consumer and producer and connection to database, which is shared by its stores
+func NewAnalyticsRouter(configurer rs.Configurer, conn *sqlx.DB) *AnalyticsRouter {
+	analyticsRouter := AnalyticsRouter{}
+	analyticsRouter.CreateConsumer(configurer)
+	analyticsRouter.CreateProducer(configurer)
+	analyticsRouter.SetANS(stores.NewANS(conn))
+	analyticsRouter.SetUSS(stores.NewUSS(conn))
+	return &analyticsRouter
+}
+
+// CreateConsumer - helper method
+func (ar *AnalyticsRouter) CreateConsumer(configurer rs.Configurer) {
+	ar.RConsumer = rs.RConsumer{}
+	err := ar.RConsumer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RConsumer for AnalyticsRouter")
+	}
+}
+
+// CreateProducer - helper method
+func (ar *AnalyticsRouter) CreateProducer(configurer rs.Configurer) {
+	ar.RProducer = rs.RProducer{}
+	err := ar.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for AnalyticsRouter")
+	}
+}
+
+// SetANS - Dependency injection of stores.AnalyticsStore
+func (ar *AnalyticsRouter) SetANS(ans stores.AnalyticsStore) {
+	ar.ans = ans
+}
+
+// SetUSS - Dependency injection of stores.SettingsStore
+func (ar *AnalyticsRouter) SetUSS(uss stores.SettingsStore) {
+	ar.uss = uss
+}
+
+// Setup - main method, that sets up all routes and handlers for them
+func (ar *AnalyticsRouter) Setup() {
+	ar.routes = make(map[string]func(amqp091.Delivery) responses.Response)
+	ar.routes["onerepmax"] = ar.handleOneRepMax
+	ar.routes["tonnage"] = ar.handleTonnage
+	ar.routes["volume"] = ar.handleVolume
+	ar.routes["muscles"] = ar.handleMuscles
+	ar.routes["balance"] = ar.handleBalance
+	q, err := ar.RConsumer.CreateQueue(durableQueue("trainings.analytics"))
+	if err != nil {
+		log.Fatal("error creating queue for analytics consumer")
+	}
+	err = ar.RConsumer.SetBinding(q, "trainings.analytics.#", EXCHANGE_NAME)
+	if err != nil {
+		log.Fatal("error creating binding for analytics consumer")
+	}
+	//creating dispatcher
+	dispatcher := newRouteDispatcher()
+	for path, f := range ar.routes {
+		dispatcher.RegisterHandler("trainings.analytics."+path, rs.NewHandlerFunc(func(msg amqp091.Delivery) {
+			process(ar.RProducer, msg, f, "tgbot.analytics."+path)
+		}))
+	}
+	ar.RConsumer.RegisterDispatcher(q, dispatcher, manualAck())
+}
+
+func (ar *AnalyticsRouter) handleOneRepMax(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	query, err := converters.ParseOneRepMaxQuery(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to get one-rep max with user: %d, exercise: %d, formula: %s",
+		query.UserId, query.ExerciseId, query.Formula))
+	settings, loc, err := userSettings(ar.uss, query.UserId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting one-rep max: %w", err))
+	}
+	query.StatsRange = query.StatsRange.In(loc)
+	sets, err := ar.ans.FindExerciseSets(query.UserId, query.ExerciseId, query.From, query.To)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting one-rep max: %w", err))
+	}
+	return responses.Success(units.ToUser(analytics.OneRepMaxProgress(query.Formula, sets), settings))
+}
+
+func (ar *AnalyticsRouter) handleTonnage(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	statsRange, err := converters.ParseStatsRange(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to get tonnage with user: %d", statsRange.UserId))
+	settings, loc, err := userSettings(ar.uss, statsRange.UserId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting tonnage: %w", err))
+	}
+	statsRange = statsRange.In(loc)
+	sets, err := ar.ans.FindSets(statsRange.UserId, statsRange.From, statsRange.To)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting tonnage: %w", err))
+	}
+	return responses.Success(units.ToUser(analytics.Tonnage(sets), settings))
+}
+
+func (ar *AnalyticsRouter) handleVolume(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	statsRange, err := converters.ParseStatsRange(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to get weekly volume with user: %d", statsRange.UserId))
+	settings, loc, err := userSettings(ar.uss, statsRange.UserId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting weekly volume: %w", err))
+	}
+	statsRange = statsRange.In(loc)
+	sets, err := ar.ans.FindSets(statsRange.UserId, statsRange.From, statsRange.To)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting weekly volume: %w", err))
+	}
+	weekStart, err := reminder.WeekdayOf(settings.WeekStart)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting weekly volume: %w", err))
+	}
+	return responses.Success(units.ToUser(analytics.WeeklyVolume(sets, weekStart), settings))
+}
+
+// handleMuscles - muscles of taxonomy, which exercise groups and exercises can be mapped to
+func (ar *AnalyticsRouter) handleMuscles(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	userId, err := converters.ParseUserID(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to list muscles with user: %d", userId))
+	return responses.Success(muscles.Taxonomy)
+}
+
+func (ar *AnalyticsRouter) handleBalance(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	statsRange, err := converters.ParseStatsRange(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to get muscle balance with user: %d", statsRange.UserId))
+	settings, loc, err := userSettings(ar.uss, statsRange.UserId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting muscle balance: %w", err))
+	}
+	statsRange = statsRange.In(loc)
+	sets, err := ar.ans.FindMuscleSets(statsRange.UserId, statsRange.From, statsRange.To)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting muscle balance: %w", err))
+	}
+	weekStart, err := reminder.WeekdayOf(settings.WeekStart)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting muscle balance: %w", err))
+	}
+	return responses.Success(analytics.Balance(sets, statsRange.From, statsRange.To, weekStart))
+}
+
+// Stop - Closure for closing channels of consumer and producer
+func (ar AnalyticsRouter) Stop() {
+	ar.RConsumer.Stop()
+	ar.RProducer.Stop()
+}
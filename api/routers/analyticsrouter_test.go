@@ -0,0 +1,126 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/analytics"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/google/go-cmp/cmp"
+)
+
+func init() {
+	registerRouter(setupAnalyticsRouter)
+}
+
+// setupAnalyticsRouter - sets up AnalyticsRouter with stub stores
+func setupAnalyticsRouter(configurer rs.Configurer) stopper {
+	router := &AnalyticsRouter{}
+	router.CreateConsumer(configurer)
+	router.CreateProducer(configurer)
+	router.SetANS(stores.AnalyticsStoreStub{})
+	router.SetUSS(stores.SettingsStoreStub{})
+	router.Setup()
+	return router
+}
+
+// callAnalytics - calls analytics route and decodes data of successful response to result
+func callAnalytics(t *testing.T, route string, request string, result any) responses.Response {
+	d, err := rpcClient.CallRaw(context.Background(), "trainings.analytics."+route, []byte(request))
+	if err != nil {
+		t.Fatalf("error calling %s: %v", route, err)
+	}
+	response, err := responses.Decode(d.Body)
+	if err != nil {
+		t.Fatalf("error decoding response of %s: %v", route, err)
+	}
+	if response.Status == responses.StatusSuccess {
+		err = json.Unmarshal(response.Data.(json.RawMessage), result)
+		if err != nil {
+			t.Fatalf("error decoding data of %s: %v", route, err)
+		}
+	}
+	return response
+}
+
+func TestAnalyticsOneRepMax(t *testing.T) {
+	var points []analytics.OneRepMaxPoint
+	response := callAnalytics(t, "onerepmax", `{"user_id":2,"from":"2024-06-03","to":"2024-06-09"}`, &points)
+	if response.Code != responses.Validation {
+		t.Errorf("got wrong code without exercise: %s", response.Code)
+	}
+	response = callAnalytics(t, "onerepmax",
+		`{"user_id":2,"exercise_id":1,"from":"2024-06-03","to":"2024-06-09","formula":"epley"}`, &points)
+	first := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	expected := []analytics.OneRepMaxPoint{
+		{TrainingId: 1, Date: first, Weight: 60, Reps: 10, OneRepMax: 80},
+		{TrainingId: 2, Date: first.AddDate(0, 0, 2), Weight: 65, Reps: 8, OneRepMax: 65 * (1 + 8.0/30)},
+	}
+	if diff := cmp.Diff(expected, points); diff != "" {
+		t.Errorf("got wrong one-rep max progress: %s", diff)
+	}
+}
+
+func TestAnalyticsTonnage(t *testing.T) {
+	var sessions []analytics.SessionTonnage
+	callAnalytics(t, "tonnage", `{"user_id":2,"from":"2024-06-03","to":"2024-06-09"}`, &sessions)
+	if len(sessions) != 2 || sessions[0].Tonnage != 1100 || sessions[1].Tonnage != 520 {
+		t.Errorf("got wrong tonnage: %#v", sessions)
+	}
+	//user without sets
+	response := callAnalytics(t, "tonnage", `{"user_id":1,"from":"2024-06-03","to":"2024-06-09"}`, &sessions)
+	if response.Code != responses.OK || len(sessions) != 0 {
+		t.Errorf("got wrong tonnage without sets: %#v", response)
+	}
+}
+
+func TestAnalyticsVolume(t *testing.T) {
+	var volumes []analytics.GroupVolume
+	callAnalytics(t, "volume", `{"user_id":2,"from":"2024-06-03","to":"2024-06-09"}`, &volumes)
+	if len(volumes) != 2 || volumes[0].GroupName != "Back" || volumes[0].Sets != 2 || volumes[0].Tonnage != 1120 ||
+		volumes[1].GroupName != "Legs" || volumes[1].Tonnage != 500 {
+		t.Errorf("got wrong weekly volume: %#v", volumes)
+	}
+}
+
+func TestAnalyticsMuscles(t *testing.T) {
+	var taxonomy []struct {
+		Name   string `json:"name"`
+		Region string `json:"region"`
+	}
+	callAnalytics(t, "muscles", `{"user_id":2}`, &taxonomy)
+	if len(taxonomy) != 14 || taxonomy[0].Name != "chest" || taxonomy[0].Region != "upper" {
+		t.Errorf("got wrong taxonomy: %#v", taxonomy)
+	}
+}
+
+func TestAnalyticsBalance(t *testing.T) {
+	var report analytics.BalanceReport
+	response := callAnalytics(t, "balance", `{"user_id":2,"from":"2024-06-09"}`, &report)
+	if response.Code != responses.Validation {
+		t.Errorf("got wrong code without range: %s", response.Code)
+	}
+	callAnalytics(t, "balance", `{"user_id":2,"from":"2024-06-03","to":"2024-06-09"}`, &report)
+	monday := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	expectedWeekly := []analytics.WeeklyMuscleSets{
+		{Week: monday, Muscle: "lats", Sets: 2},
+		{Week: monday, Muscle: "biceps", Sets: 1},
+		{Week: monday, Muscle: "glutes", Sets: 1},
+		{Week: monday, Muscle: "quads", Sets: 1},
+	}
+	if diff := cmp.Diff(expectedWeekly, report.Weekly); diff != "" {
+		t.Errorf("got wrong weekly sets: %s", diff)
+	}
+	if report.Weeks != 1 || report.UnmappedSets != 0 || len(report.Ratios) != 3 || !report.Ratios[0].Imbalanced {
+		t.Errorf("got wrong balance: %#v", report)
+	}
+	//user without mapped exercises
+	callAnalytics(t, "balance", `{"user_id":3,"from":"2024-06-03","to":"2024-06-09"}`, &report)
+	if report.UnmappedSets != 3 || len(report.Weekly) != 0 || len(report.Neglected) != 0 {
+		t.Errorf("got wrong balance without mapping: %#v", report)
+	}
+}
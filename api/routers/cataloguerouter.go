@@ -0,0 +1,146 @@
+package routers
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/catalogue"
+	"github.com/fridrock/trainingservice/api/utils/converters"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// CatalogueRouter - structure, that contains both consumer, and producer for messaging inside Catalogue domain
+type CatalogueRouter struct {
+	rs.RConsumer
+	rs.RProducer
+	cts    stores.CatalogueStore
+	pms    stores.ProcessedMessageStore
+	routes map[string]func(amqp091.Delivery) responses.Response
+}
+
+// NewCatalogueRouter - Default method for creation CatalogueRouter, requires rs.Configurer to create channels for
+// consumer and producer and connection to database, which is shared by its stores. Catalogue is seeded from embedded
+// data file
+func NewCatalogueRouter(configurer rs.Configurer, conn *sqlx.DB) *CatalogueRouter {
+	catalogueRouter := CatalogueRouter{}
+	catalogueRouter.CreateConsumer(configurer)
+	catalogueRouter.CreateProducer(configurer)
+	catalogueRouter.SetCTS(stores.NewCTS(conn))
+	catalogueRouter.SetPMS(stores.NewPMS(conn))
+	exercises, err := catalogue.Load()
+	if err != nil {
+		log.Fatalf("error loading catalogue: %v", err)
+	}
+	err = catalogueRouter.cts.Seed(exercises)
+	if err != nil {
+		log.Fatalf("error seeding catalogue: %v", err)
+	}
+	return &catalogueRouter
+}
+
+// CreateConsumer - helper method
+func (cr *CatalogueRouter) CreateConsumer(configurer rs.Configurer) {
+	cr.RConsumer = rs.RConsumer{}
+	err := cr.RConsumer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RConsumer for CatalogueRouter")
+	}
+}
+
+// CreateProducer - helper method
+func (cr *CatalogueRouter) CreateProducer(configurer rs.Configurer) {
+	cr.RProducer = rs.RProducer{}
+	err := cr.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for CatalogueRouter")
+	}
+}
+
+// SetCTS - Dependency injection of stores.CatalogueStore
+func (cr *CatalogueRouter) SetCTS(cts stores.CatalogueStore) {
+	cr.cts = cts
+}
+
+// SetPMS - Dependency injection of stores.ProcessedMessageStore
+func (cr *CatalogueRouter) SetPMS(pms stores.ProcessedMessageStore) {
+	cr.pms = pms
+}
+
+// Setup - main method, that sets up all routes and handlers for them
+func (cr *CatalogueRouter) Setup() {
+	cr.routes = make(map[string]func(amqp091.Delivery) responses.Response)
+	cr.routes["search"] = cr.handleSearch
+	cr.routes["get"] = cr.handleGet
+	cr.routes["link"] = idempotent(cr.pms, cr.handleLink)
+	q, err := cr.RConsumer.CreateQueue(durableQueue("trainings.catalogue"))
+	if err != nil {
+		log.Fatal("error creating queue for catalogue consumer")
+	}
+	err = cr.RConsumer.SetBinding(q, "trainings.catalogue.#", EXCHANGE_NAME)
+	if err != nil {
+		log.Fatal("error creating binding for catalogue consumer")
+	}
+	//creating dispatcher
+	dispatcher := newRouteDispatcher()
+	for path, f := range cr.routes {
+		dispatcher.RegisterHandler("trainings.catalogue."+path, rs.NewHandlerFunc(func(msg amqp091.Delivery) {
+			process(cr.RProducer, msg, f, "tgbot.catalogue."+path)
+		}))
+	}
+	cr.RConsumer.RegisterDispatcher(q, dispatcher, manualAck())
+}
+
+func (cr *CatalogueRouter) handleSearch(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	search, err := converters.ParseCatalogueSearch(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to search catalogue with user: %d, query: %s", search.UserId, search.Query))
+	exercises, err := cr.cts.FindAll()
+	if err != nil {
+		return responses.Error(fmt.Errorf("error searching catalogue: %w", err))
+	}
+	return responses.Success(catalogue.Search(exercises, search.Query, search.Limit))
+}
+
+func (cr *CatalogueRouter) handleGet(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	query, err := converters.ParseCatalogueQuery(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to get catalogue exercise: %d", query.CatalogueId))
+	exercise, err := cr.cts.FindById(query.CatalogueId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting catalogue exercise: %w", err))
+	}
+	return responses.Success(exercise)
+}
+
+// handleLink - adds personal copy of catalogue exercise to exercise group of user
+func (cr *CatalogueRouter) handleLink(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	query, err := converters.ParseCatalogueLink(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to link catalogue exercise %d to group %d of user %d",
+		query.CatalogueId, query.ExerciseGroupId, query.UserId))
+	exerciseId, err := cr.cts.Link(query.UserId, query.CatalogueId, query.ExerciseGroupId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error linking catalogue exercise: %w", err))
+	}
+	return responses.Created(exerciseId)
+}
+
+// Stop - Closure for closing channels of consumer and producer
+func (cr CatalogueRouter) Stop() {
+	cr.RConsumer.Stop()
+	cr.RProducer.Stop()
+}
@@ -0,0 +1,113 @@
+package routers
+
+import (
+	"strings"
+	"testing"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+func init() {
+	registerRouter(setupCatalogueRouter)
+}
+
+// setupCatalogueRouter - sets up CatalogueRouter with stub stores
+func setupCatalogueRouter(configurer rs.Configurer) stopper {
+	router := &CatalogueRouter{}
+	router.CreateConsumer(configurer)
+	router.CreateProducer(configurer)
+	router.SetCTS(stores.CatalogueStoreStub{})
+	router.SetPMS(stores.NewPMSStub())
+	router.Setup()
+	return router
+}
+
+func TestCatalogueRoutes(t *testing.T) {
+	data := []struct {
+		testName       string
+		routingKey     string
+		message        string
+		expectedResult string
+	}{
+		{
+			"Negative case: search without query",
+			"trainings.catalogue.search",
+			`{"user_id":2}`,
+			wrongInput,
+		},
+		{
+			"Negative case: search with too big limit",
+			"trainings.catalogue.search",
+			`{"user_id":2,"query":"bench","limit":100}`,
+			wrongInput,
+		},
+		{
+			"Positive case: search by alias",
+			"trainings.catalogue.search",
+			`{"user_id":2,"query":"Jogging"}`,
+			"SUCCESS: [\n{\n\"id\": 3,\n\"name\": \"Running\",\n\"aliases\": [\n\"jogging\"\n],\n\"primary_muscles\": [\n\"quads\",\n\"hamstrings\"\n],\n\"secondary_muscles\": [\n\"calves\"\n],\n\"equipment\": \"none\",\n\"type\": \"CARDIO\",\n\"score\": 1\n}\n]",
+		},
+		{
+			"Positive case: search with limit",
+			"trainings.catalogue.search",
+			`{"user_id":2,"query":"bench","limit":1}`,
+			"SUCCESS: [\n{\n\"id\": 1,\n\"name\": \"Bench press\",\n\"aliases\": [\n\"flat bench\"\n],\n\"primary_muscles\": [\n\"chest\"\n],\n\"secondary_muscles\": [\n\"triceps\",\n\"shoulders\"\n],\n\"equipment\": \"barbell\",\n\"type\": \"GYM\",\n\"score\": 0.9\n}\n]",
+		},
+		{
+			"Positive case: nothing found",
+			"trainings.catalogue.search",
+			`{"user_id":2,"query":"squat"}`,
+			"SUCCESS: []",
+		},
+		{
+			"Negative case: get unknown exercise",
+			"trainings.catalogue.get",
+			`{"catalogue_id":404}`,
+			"ERROR: error getting catalogue exercise: sql: no rows in result set",
+		},
+		{
+			"Positive case: get",
+			"trainings.catalogue.get",
+			`{"catalogue_id":1}`,
+			`SUCCESS: {"id":1,"name":"Bench press","aliases":["flat bench"],"primary_muscles":["chest"],"secondary_muscles":["triceps","shoulders"],"equipment":"barbell","type":"GYM"}`,
+		},
+		{
+			"Negative case: link without group",
+			"trainings.catalogue.link",
+			`{"user_id":2,"catalogue_id":1}`,
+			wrongInput,
+		},
+		{
+			"Negative case: link to group of other user",
+			"trainings.catalogue.link",
+			`{"user_id":1,"catalogue_id":1,"exercise_group_id":3}`,
+			"ERROR: error linking catalogue exercise: sql: no rows in result set",
+		},
+		{
+			"Negative case: already linked",
+			"trainings.catalogue.link",
+			`{"user_id":2,"catalogue_id":1,"exercise_group_id":2}`,
+			"ERROR: error linking catalogue exercise: catalogue exercise is already in the group",
+		},
+		{
+			"Positive case: link",
+			"trainings.catalogue.link",
+			`{"user_id":2,"catalogue_id":1,"exercise_group_id":3}`,
+			"SUCCESS: id:7",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy(d.routingKey, d.message)
+			body := <-clientConsumer.LastMessageCh
+			if body.RoutingKey != "tgbot"+strings.TrimPrefix(d.routingKey, "trainings") {
+				t.Errorf("error wrong result routing key: %s", body.RoutingKey)
+			}
+			received := string(body.Body)
+			if received != d.expectedResult {
+				t.Errorf("Error handling catalogue, received: %v", received)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package routers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"log/slog"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/converters"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// ExerciseRouter - structure, that contains both consumer, and producer for messaging inside Exercise domain
+type ExerciseRouter struct {
+	rs.RConsumer
+	rs.RProducer
+	es     stores.ExerciseStore
+	ets    stores.ExerciseTypeStore
+	pms    stores.ProcessedMessageStore
+	routes map[string]func(amqp091.Delivery) responses.Response
+}
+
+// NewExerciseRouter - Default method for creation ExerciseRouter, requires rs.Configurer to create channels
+// for consumer and producer and connection to database, which is shared by its stores
+func NewExerciseRouter(configurer rs.Configurer, conn *sqlx.DB) *ExerciseRouter {
+	exerciseRouter := ExerciseRouter{}
+	exerciseRouter.CreateConsumer(configurer)
+	exerciseRouter.CreateProducer(configurer)
+	exerciseRouter.SetES(stores.NewEX(conn))
+	exerciseRouter.SetETS(stores.NewETS(conn))
+	exerciseRouter.SetPMS(stores.NewPMS(conn))
+	return &exerciseRouter
+}
+
+// CreateConsumer - helper method
+func (er *ExerciseRouter) CreateConsumer(configurer rs.Configurer) {
+	er.RConsumer = rs.RConsumer{}
+	err := er.RConsumer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RConsumer for ExerciseRouter")
+	}
+}
+
+// CreateProducer - helper method
+func (er *ExerciseRouter) CreateProducer(configurer rs.Configurer) {
+	er.RProducer = rs.RProducer{}
+	err := er.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for ExerciseRouter")
+	}
+}
+
+// SetES - Dependency injection of stores.ExerciseStore
+func (er *ExerciseRouter) SetES(es stores.ExerciseStore) {
+	er.es = es
+}
+
+// SetETS - Dependency injection of stores.ExerciseTypeStore
+func (er *ExerciseRouter) SetETS(ets stores.ExerciseTypeStore) {
+	er.ets = ets
+}
+
+// SetPMS - Dependency injection of stores.ProcessedMessageStore
+func (er *ExerciseRouter) SetPMS(pms stores.ProcessedMessageStore) {
+	er.pms = pms
+}
+
+// Setup - main method, that sets up all routes and handlers for them
+func (er *ExerciseRouter) Setup() {
+	er.routes = make(map[string]func(amqp091.Delivery) responses.Response)
+	er.routes["create"] = idempotent(er.pms, er.handleCreate)
+	er.routes["find"] = er.handleFind
+	er.routes["update"] = idempotent(er.pms, er.handleUpdate)
+	er.routes["delete"] = idempotent(er.pms, er.handleDelete)
+	er.routes["findByGroup"] = er.handleFindByGroup
+	er.routes["muscles"] = idempotent(er.pms, er.handleMuscles)
+	q, err := er.RConsumer.CreateQueue(durableQueue("trainings.exercise"))
+	if err != nil {
+		log.Fatal("error creating queue for exercise consumer")
+	}
+	err = er.RConsumer.SetBinding(q, "trainings.exercise.#", EXCHANGE_NAME)
+	if err != nil {
+		log.Fatal("error creating binding for exercise consumer")
+	}
+	//creating dispatcher
+	dispatcher := newRouteDispatcher()
+	for path, f := range er.routes {
+		dispatcher.RegisterHandler("trainings.exercise."+path, rs.NewHandlerFunc(func(msg amqp091.Delivery) {
+			process(er.RProducer, msg, f, "tgbot.exercise."+path)
+		}))
+	}
+	er.RConsumer.RegisterDispatcher(q, dispatcher, manualAck())
+}
+
+func (er *ExerciseRouter) handleCreate(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	exercise, err := converters.FromJsonToExercise(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to create exercise: %#v", exercise))
+	if response, ok := er.checkType(exercise); !ok {
+		return response
+	}
+	gotId, err := er.es.Save(exercise)
+	if err != nil {
+		return responses.Error(fmt.Errorf("internal server error: %w", err))
+	}
+	return responses.Created(gotId)
+}
+
+func (er *ExerciseRouter) handleFind(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	query, err := converters.ParseExerciseQuery(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to find exercise: %#v", query))
+	exercise, err := er.es.FindByName(query.UserId, query.Group, query.Name)
+	if err != nil {
+		return responses.Error(err)
+	}
+	return responses.Success(exercise)
+}
+
+func (er *ExerciseRouter) handleUpdate(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	exercise, err := converters.ParseUpdateExercise(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to update exercise: %#v", exercise))
+	if response, ok := er.checkType(exercise); !ok {
+		return response
+	}
+	err = er.es.Update(exercise)
+	if err != nil {
+		return responses.Error(err)
+	}
+	return responses.Success(nil)
+}
+
+func (er *ExerciseRouter) handleDelete(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	query, err := converters.ParseExerciseQuery(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to delete exercise: %#v", query))
+	err = er.es.DeleteByName(query.UserId, query.Group, query.Name)
+	if err != nil {
+		return responses.Error(err)
+	}
+	return responses.Success(nil)
+}
+
+func (er *ExerciseRouter) handleFindByGroup(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	userId, group, err := converters.ParseExerciseGroupProperties(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to find exercises with user_id: %d, group: %v", userId, group))
+	exercises, err := er.es.FindByGroup(userId, group)
+	if err != nil {
+		return responses.Error(err)
+	}
+	return responses.Success(exercises)
+}
+
+// checkType - returns error response, if type of exercise doesn't exist
+func (er *ExerciseRouter) checkType(exercise stores.Exercise) (responses.Response, bool) {
+	_, err := er.ets.FindById(exercise.ExerciseTypeId)
+	if err == sql.ErrNoRows {
+		return responses.WrongInput(fmt.Errorf("unknown exercise type %d", exercise.ExerciseTypeId)), false
+	}
+	if err != nil {
+		return responses.Error(fmt.Errorf("error finding exercise type: %w", err)), false
+	}
+	return responses.Response{}, true
+}
+
+// handleMuscles - maps exercise to muscles of taxonomy, mapping of exercise overrides mapping of its group
+func (er *ExerciseRouter) handleMuscles(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	mapping, err := converters.ParseMuscleMapping(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to map exercise with user_id: %d, name: %s to muscles: %v",
+		mapping.UserId, mapping.Name, mapping.Muscles))
+	err = er.es.SetMuscles(mapping.UserId, mapping.Group, mapping.Name, mapping.Muscles)
+	if err != nil {
+		return responses.Error(err)
+	}
+	return responses.Success(nil)
+}
+
+// Stop - Closure for closing channels of consumer and producer
+func (er ExerciseRouter) Stop() {
+	er.RConsumer.Stop()
+	er.RProducer.Stop()
+}
@@ -0,0 +1,277 @@
+package routers
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+func init() {
+	registerRouter(setupExerciseRouter)
+}
+
+// setupExerciseRouter - sets up ExerciseRouter with stub stores
+func setupExerciseRouter(configurer rs.Configurer) stopper {
+	router := &ExerciseRouter{}
+	router.CreateConsumer(configurer)
+	router.CreateProducer(configurer)
+	router.SetES(stores.ExerciseStoreStub{})
+	router.SetETS(stores.ExerciseTypeStoreStub{})
+	router.SetPMS(stores.NewPMSStub())
+	router.Setup()
+	return router
+}
+
+func TestCreateExercise(t *testing.T) {
+	data := []struct {
+		testName       string
+		message        string
+		resultExpected string
+		errMessage     string
+	}{
+		{
+			"Negative case wrong input",
+			`{"user_id":2,"name":"Pull up"}`,
+			wrongInput,
+			"error while validation, received: %s",
+		},
+		{
+			"Positive case",
+			`{"user_id":2,"name":"Pull up","rest":90,"exercise_type_id":2,"exercise_group_id":1}`,
+			"SUCCESS: id:1",
+			"wrong result adding exercise, received: %s",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy("trainings.exercise.create", d.message)
+			received := <-clientConsumer.LastMessageCh
+			if received.RoutingKey != "tgbot.exercise.create" {
+				t.Errorf("error wrong result routing key")
+			}
+			if string(received.Body) != d.resultExpected {
+				t.Errorf(d.errMessage, string(received.Body))
+			}
+		})
+	}
+}
+
+func TestFindExercise(t *testing.T) {
+	data := []struct {
+		testName       string
+		message        string
+		resultExpected string
+		errMessage     string
+	}{
+		{
+			"Negative case wrong input",
+			`{"user_id":2}`,
+			wrongInput,
+			"error with wrong input, received: %s",
+		},
+		{
+			"Negative case no such exercise",
+			`{"user_id":2,"name":"Unexisting"}`,
+			"ERROR: " + sql.ErrNoRows.Error(),
+			"error with finding unexisting exercise, received: %s",
+		},
+		{
+			"Negative case name in several groups",
+			`{"user_id":2,"name":"Squat"}`,
+			"ERROR: " + stores.AmbiguousName.Error(),
+			"error with finding exercise with name in several groups, received: %s",
+		},
+		{
+			"Positive case found in group",
+			`{"user_id":2,"name":"Squat","group":"Legs"}`,
+			`SUCCESS: {"id":1,"name":"Pull up","description":"","rest":90,"exercise_type_id":2,"user_id":2,"exercise_group_id":1}`,
+			"error with successful finding of exercise in group, received: %s",
+		},
+		{
+			"Positive case found",
+			`{"user_id":2,"name":"Pull up"}`,
+			`SUCCESS: {"id":1,"name":"Pull up","description":"","rest":90,"exercise_type_id":2,"user_id":2,"exercise_group_id":1}`,
+			"error with successful finding of exercise, received: %s",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy("trainings.exercise.find", d.message)
+			received := string((<-clientConsumer.LastMessageCh).Body)
+			if received != d.resultExpected {
+				t.Errorf(d.errMessage, received)
+			}
+		})
+	}
+}
+
+func TestUpdateExercise(t *testing.T) {
+	data := []struct {
+		testName       string
+		message        string
+		resultExpected string
+		errMessage     string
+	}{
+		{
+			"Negative case wrong input",
+			`{"user_id":2,"name":"Pull up","exercise_type_id":2,"exercise_group_id":1}`,
+			wrongInput,
+			"error with wrong input, received: %s",
+		},
+		{
+			"Negative case unknown exercise type",
+			`{"id":1,"user_id":2,"name":"Chin up","exercise_type_id":7,"exercise_group_id":1}`,
+			wrongInput,
+			"error with unknown exercise type, received: %s",
+		},
+		{
+			"Negative case no such exercise",
+			`{"id":3,"user_id":2,"name":"Unexisting","exercise_type_id":2,"exercise_group_id":1}`,
+			"ERROR: " + stores.NotUpdated.Error(),
+			"error with updating unexisting exercise, received: %s",
+		},
+		{
+			"Positive case updated",
+			`{"id":1,"user_id":2,"name":"Chin up","exercise_type_id":2,"exercise_group_id":1}`,
+			success,
+			"error with successful updating of exercise, received: %s",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy("trainings.exercise.update", d.message)
+			received := string((<-clientConsumer.LastMessageCh).Body)
+			if received != d.resultExpected {
+				t.Errorf(d.errMessage, received)
+			}
+		})
+	}
+}
+
+func TestDeleteExercise(t *testing.T) {
+	data := []struct {
+		testName       string
+		message        string
+		resultExpected string
+		errMessage     string
+	}{
+		{
+			"Negative case wrong input",
+			`{"user_id":2}`,
+			wrongInput,
+			"error with wrong input, received: %s",
+		},
+		{
+			"Negative case no such exercise",
+			`{"user_id":2,"name":"Unexisting"}`,
+			notDeleted,
+			"error with deleting unexisting exercise, received: %s",
+		},
+		{
+			"Negative case name in several groups",
+			`{"user_id":2,"name":"Squat"}`,
+			"ERROR: " + stores.AmbiguousName.Error(),
+			"error with deleting exercise with name in several groups, received: %s",
+		},
+		{
+			"Positive case",
+			`{"user_id":2,"name":"Pull up"}`,
+			success,
+			"error with successful deletion of exercise, received: %s",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy("trainings.exercise.delete", d.message)
+			received := string((<-clientConsumer.LastMessageCh).Body)
+			if received != d.resultExpected {
+				t.Errorf(d.errMessage, received)
+			}
+		})
+	}
+}
+
+func TestFindExercisesByGroup(t *testing.T) {
+	data := []struct {
+		testName       string
+		message        string
+		resultExpected string
+		errMessage     string
+	}{
+		{
+			"Negative case wrong input",
+			`{"user_id":2,"name":"Back"}`,
+			wrongInput,
+			"error with wrong input, received: %s",
+		},
+		{
+			"Negative case no such user",
+			`{"user_id":1,"group":"Back"}`,
+			"ERROR: " + sql.ErrNoRows.Error(),
+			"error with finding exercises of unexisting user, received: %s",
+		},
+		{
+			"Positive case got exercises",
+			`{"user_id":2,"group":"Back"}`,
+			success,
+			"error with successful getting exercises, received: %s",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy("trainings.exercise.findByGroup", d.message)
+			received := string((<-clientConsumer.LastMessageCh).Body)
+			if d.testName == "Positive case got exercises" {
+				parts := strings.Split(received, ":")
+				if parts[0] != success {
+					t.Errorf(d.errMessage, received)
+				}
+			} else if received != d.resultExpected {
+				t.Errorf(d.errMessage, received)
+			}
+		})
+	}
+}
+
+func TestMapExerciseMuscles(t *testing.T) {
+	data := []struct {
+		testName       string
+		message        string
+		resultExpected string
+		errMessage     string
+	}{
+		{
+			"Negative case unknown muscle",
+			`{"user_id":2,"name":"Pull up","muscles":["wings"]}`,
+			wrongInput,
+			"error with unknown muscle, received: %s",
+		},
+		{
+			"Negative case no such exercise",
+			`{"user_id":2,"name":"Unexisting","muscles":["lats"]}`,
+			"ERROR: " + stores.NotUpdated.Error(),
+			"error with mapping unexisting exercise, received: %s",
+		},
+		{
+			"Positive case mapped",
+			`{"user_id":2,"name":"Pull up","muscles":["lats","biceps"]}`,
+			success,
+			"error with successful mapping of exercise, received: %s",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy("trainings.exercise.muscles", d.message)
+			received := <-clientConsumer.LastMessageCh
+			if received.RoutingKey != "tgbot.exercise.muscles" {
+				t.Errorf("error wrong result routing key")
+			}
+			if string(received.Body) != d.resultExpected {
+				t.Errorf(d.errMessage, string(received.Body))
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package routers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"log/slog"
+	"time"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/converters"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/api/utils/units"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// NewRecordRoutingKey - routing key of notification about beaten personal record
+const NewRecordRoutingKey = "tgbot.records.new"
+
+// NewRecordEvent - notification, that is published for every personal record beaten by added set
+type NewRecordEvent struct {
+	Event  string                `json:"event"`
+	Record stores.PersonalRecord `json:"record"`
+}
+
+// ExerciseSetRouter - structure, that contains both consumer, and producer for messaging inside ExerciseSet domain
+type ExerciseSetRouter struct {
+	rs.RConsumer
+	rs.RProducer
+	ess    stores.ExerciseSetStore
+	prs    stores.RecordStore
+	rts    stores.RestTimerStore
+	uss    stores.SettingsStore
+	ets    stores.ExerciseTypeStore
+	pms    stores.ProcessedMessageStore
+	routes map[string]func(amqp091.Delivery) responses.Response
+}
+
+// NewExerciseSetRouter - Default method for creation ExerciseSetRouter, requires rs.Configurer to create channels
+// for consumer and producer and connection to database, which is shared by its stores
+func NewExerciseSetRouter(configurer rs.Configurer, conn *sqlx.DB) *ExerciseSetRouter {
+	exerciseSetRouter := ExerciseSetRouter{}
+	exerciseSetRouter.CreateConsumer(configurer)
+	exerciseSetRouter.CreateProducer(configurer)
+	exerciseSetRouter.SetESS(stores.NewESS(conn))
+	exerciseSetRouter.SetPRS(stores.NewPRS(conn))
+	exerciseSetRouter.SetRTS(stores.NewRTS(conn))
+	exerciseSetRouter.SetUSS(stores.NewUSS(conn))
+	exerciseSetRouter.SetETS(stores.NewETS(conn))
+	exerciseSetRouter.SetPMS(stores.NewPMS(conn))
+	return &exerciseSetRouter
+}
+
+// CreateConsumer - helper method
+func (esr *ExerciseSetRouter) CreateConsumer(configurer rs.Configurer) {
+	esr.RConsumer = rs.RConsumer{}
+	err := esr.RConsumer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RConsumer for ExerciseSetRouter")
+	}
+}
+
+// CreateProducer - helper method
+func (esr *ExerciseSetRouter) CreateProducer(configurer rs.Configurer) {
+	esr.RProducer = rs.RProducer{}
+	err := esr.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for ExerciseSetRouter")
+	}
+}
+
+// SetESS - Dependency injection of stores.ExerciseSetStore
+func (esr *ExerciseSetRouter) SetESS(ess stores.ExerciseSetStore) {
+	esr.ess = ess
+}
+
+// SetPRS - Dependency injection of stores.RecordStore
+func (esr *ExerciseSetRouter) SetPRS(prs stores.RecordStore) {
+	esr.prs = prs
+}
+
+// SetRTS - Dependency injection of stores.RestTimerStore
+func (esr *ExerciseSetRouter) SetRTS(rts stores.RestTimerStore) {
+	esr.rts = rts
+}
+
+// SetPMS - Dependency injection of stores.ProcessedMessageStore
+func (esr *ExerciseSetRouter) SetPMS(pms stores.ProcessedMessageStore) {
+	esr.pms = pms
+}
+
+// SetUSS - Dependency injection of stores.SettingsStore
+func (esr *ExerciseSetRouter) SetUSS(uss stores.SettingsStore) {
+	esr.uss = uss
+}
+
+// SetETS - Dependency injection of stores.ExerciseTypeStore
+func (esr *ExerciseSetRouter) SetETS(ets stores.ExerciseTypeStore) {
+	esr.ets = ets
+}
+
+// Setup - main method, that sets up all routes and handlers for them
+func (esr *ExerciseSetRouter) Setup() {
+	esr.routes = make(map[string]func(amqp091.Delivery) responses.Response)
+	esr.routes["add"] = idempotent(esr.pms, esr.handleAdd)
+	esr.routes["undo"] = idempotent(esr.pms, esr.handleUndo)
+	esr.routes["list"] = esr.handleList
+	esr.routes["planned"] = esr.handlePlanned
+	esr.routes["confirm"] = idempotent(esr.pms, esr.handleConfirm)
+	q, err := esr.RConsumer.CreateQueue(durableQueue("trainings.set"))
+	if err != nil {
+		log.Fatal("error creating queue for set consumer")
+	}
+	err = esr.RConsumer.SetBinding(q, "trainings.set.#", EXCHANGE_NAME)
+	if err != nil {
+		log.Fatal("error creating binding for set consumer")
+	}
+	//creating dispatcher
+	dispatcher := newRouteDispatcher()
+	for path, f := range esr.routes {
+		dispatcher.RegisterHandler("trainings.set."+path, rs.NewHandlerFunc(func(msg amqp091.Delivery) {
+			process(esr.RProducer, msg, f, "tgbot.set."+path)
+		}))
+	}
+	esr.RConsumer.RegisterDispatcher(q, dispatcher, manualAck())
+}
+
+func (esr *ExerciseSetRouter) handleAdd(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	set, err := converters.FromJsonToExerciseSet(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to add set: %#v", set))
+	exerciseType, err := esr.ets.FindByExercise(set.UserId, set.ExerciseId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error adding set: %w", err))
+	}
+	err = converters.ValidateSet(set, exerciseType)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	settings, err := esr.uss.Find(set.UserId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error finding settings: %w", err))
+	}
+	set, err = esr.ess.AddSet(units.FromUser(set, settings))
+	if err != nil {
+		return responses.Error(fmt.Errorf("error adding set: %w", err))
+	}
+	esr.detectRecords(set, settings)
+	esr.scheduleRest(set)
+	return responses.Created(set.Id)
+}
+
+func (esr *ExerciseSetRouter) handleUndo(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	userId, err := converters.ParseUserID(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to undo last set with user: %d", userId))
+	err = esr.ess.UndoSet(userId)
+	if err != nil {
+		return responses.Error(err)
+	}
+	return responses.Success(nil)
+}
+
+func (esr *ExerciseSetRouter) handleList(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	listQuery, err := converters.ParseListSets(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to list sets with user: %d, training: %d", listQuery.UserId, listQuery.TrainingId))
+	var sets []stores.ExerciseSet
+	if listQuery.TrainingId == 0 {
+		sets, err = esr.ess.FindCurrent(listQuery.UserId)
+	} else {
+		sets, err = esr.ess.FindByTraining(listQuery.UserId, listQuery.TrainingId)
+	}
+	if err != nil {
+		return responses.Error(fmt.Errorf("error listing sets: %w", err))
+	}
+	settings, err := esr.uss.Find(listQuery.UserId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error finding settings: %w", err))
+	}
+	return responses.Success(units.ToUser(sets, settings))
+}
+
+func (esr *ExerciseSetRouter) handlePlanned(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	userId, err := converters.ParseUserID(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to list planned sets with user: %d", userId))
+	planned, err := esr.ess.FindPlanned(userId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error listing planned sets: %w", err))
+	}
+	settings, err := esr.uss.Find(userId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error finding settings: %w", err))
+	}
+	return responses.Success(units.ToUser(planned, settings))
+}
+
+func (esr *ExerciseSetRouter) handleConfirm(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	plannedId, set, err := converters.ParseConfirmSet(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to confirm planned set %d: %#v", plannedId, set))
+	planned, err := esr.findPlanned(set.UserId, plannedId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error confirming set: %w", err))
+	}
+	exerciseType, err := esr.ets.FindByExercise(set.UserId, planned.ExerciseId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error confirming set: %w", err))
+	}
+	err = converters.ValidateSet(converters.ApplyPlanned(planned, set), exerciseType)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	settings, err := esr.uss.Find(set.UserId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error finding settings: %w", err))
+	}
+	set, err = esr.ess.ConfirmSet(plannedId, units.FromUser(set, settings))
+	if err != nil {
+		return responses.Error(fmt.Errorf("error confirming set: %w", err))
+	}
+	esr.detectRecords(set, settings)
+	esr.scheduleRest(set)
+	return responses.Created(set.Id)
+}
+
+// findPlanned - unconfirmed planned set of the currently open training of user, NotUpdated if there is no such
+func (esr *ExerciseSetRouter) findPlanned(userId int64, plannedId int64) (stores.PlannedSet, error) {
+	planned, err := esr.ess.FindPlannedById(userId, plannedId)
+	if err == sql.ErrNoRows || (err == nil && planned.SetId != 0) {
+		return stores.PlannedSet{}, stores.NotUpdated
+	}
+	return planned, err
+}
+
+// detectRecords - saves records set by added set and notifies user about beaten ones. Set is already stored,
+// so failures are only logged and don't fail the request. Records in events are in units of user
+func (esr *ExerciseSetRouter) detectRecords(set stores.ExerciseSet, settings stores.Settings) {
+	records, err := esr.prs.Detect(set)
+	if err != nil {
+		slog.Error(fmt.Sprintf("error detecting records of set %d: %v", set.Id, err))
+		return
+	}
+	for _, record := range records {
+		slog.Info(fmt.Sprintf("user %d beat %s record of exercise %d", record.UserId, record.Kind, record.ExerciseId))
+		event := NewRecordEvent{
+			Event:  "records.new",
+			Record: units.ToUser(record, settings),
+		}
+		err = esr.RProducer.PublishMessage(
+			context.Background(),
+			EXCHANGE_NAME,
+			NewRecordRoutingKey,
+			responses.Success(event).Encode(false))
+		if err != nil {
+			slog.Error(fmt.Sprintf("error publishing new record event of set %d: %v", set.Id, err))
+		}
+	}
+}
+
+// scheduleRest - replaces pending rest timer of user with timer of added set, exercises without rest interval
+// only cancel pending timer. Failures are only logged and don't fail the request
+func (esr *ExerciseSetRouter) scheduleRest(set stores.ExerciseSet) {
+	timer, err := esr.rts.Schedule(set)
+	if err == sql.ErrNoRows {
+		return
+	}
+	if err != nil {
+		slog.Error(fmt.Sprintf("error scheduling rest timer of set %d: %v", set.Id, err))
+		return
+	}
+	slog.Info(fmt.Sprintf("rest timer of set %d fires at %s", set.Id, timer.FiresAt.Format(time.DateTime)))
+}
+
+// Stop - Closure for closing channels of consumer and producer
+func (esr ExerciseSetRouter) Stop() {
+	esr.RConsumer.Stop()
+	esr.RProducer.Stop()
+}
@@ -0,0 +1,264 @@
+package routers
+
+import (
+	"strings"
+	"testing"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+func init() {
+	registerRouter(setupExerciseSetRouter)
+}
+
+// setupExerciseSetRouter - sets up ExerciseSetRouter with stub stores
+func setupExerciseSetRouter(configurer rs.Configurer) stopper {
+	router := &ExerciseSetRouter{}
+	router.CreateConsumer(configurer)
+	router.CreateProducer(configurer)
+	router.SetESS(stores.ExerciseSetStoreStub{})
+	router.SetPRS(stores.RecordStoreStub{})
+	router.SetRTS(stores.RestTimerStoreStub{})
+	router.SetUSS(stores.SettingsStoreStub{})
+	router.SetETS(stores.ExerciseTypeStoreStub{})
+	router.SetPMS(stores.NewPMSStub())
+	router.Setup()
+	return router
+}
+
+func TestAddSet(t *testing.T) {
+	data := []struct {
+		testName       string
+		message        string
+		expectedResult string
+		errMessage     string
+	}{
+		{
+			"Negative case: wrong input",
+			`{"user_id":2,"exercise_id":1}`,
+			wrongInput,
+			"Error adding set, received: %v",
+		},
+		{
+			"Negative case: no open training",
+			`{"user_id":1,"exercise_id":1,"reps":10}`,
+			"ERROR: error adding set: Empty non-finished trainings list",
+			"Error adding set, received: %v",
+		},
+		{
+			"Negative case: unknown exercise",
+			`{"user_id":2,"exercise_id":404,"reps":10}`,
+			"ERROR: error adding set: sql: no rows in result set",
+			"Error adding set, received: %v",
+		},
+		{
+			"Negative case: gym set without weight",
+			`{"user_id":2,"exercise_id":2,"reps":10}`,
+			wrongInput,
+			"Error adding set, received: %v",
+		},
+		{
+			"Negative case: cardio set without duration and distance",
+			`{"user_id":2,"exercise_id":3,"avg_heart_rate":140}`,
+			wrongInput,
+			"Error adding set, received: %v",
+		},
+		{
+			"Positive case",
+			`{"user_id":2,"exercise_id":1,"weight":60,"reps":10}`,
+			"SUCCESS: id:1",
+			"Error adding set, received: %v",
+		},
+		{
+			"Positive case: cardio set",
+			`{"user_id":2,"exercise_id":3,"distance":5,"duration":1500,"avg_heart_rate":150}`,
+			"SUCCESS: id:1",
+			"Error adding set, received: %v",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy("trainings.set.add", d.message)
+		})
+		body := <-clientConsumer.LastMessageCh
+		if body.RoutingKey != "tgbot.set.add" {
+			t.Errorf("error wrong result routing key")
+		}
+		received := string(body.Body)
+		if received != d.expectedResult {
+			t.Errorf(d.errMessage, received)
+		}
+	}
+}
+
+func TestUndoSet(t *testing.T) {
+	data := []struct {
+		testName       string
+		message        string
+		expectedResult string
+		errMessage     string
+	}{
+		{
+			"Negative case: wrong input",
+			`{"userid":2}`,
+			wrongInput,
+			"Error undoing set, received: %v",
+		},
+		{
+			"Negative case: nothing to undo",
+			`{"user_id":1}`,
+			notDeleted,
+			"Error undoing set, received: %v",
+		},
+		{
+			"Positive case",
+			`{"user_id":2}`,
+			success,
+			"Error undoing set, received: %v",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy("trainings.set.undo", d.message)
+		})
+		body := <-clientConsumer.LastMessageCh
+		if body.RoutingKey != "tgbot.set.undo" {
+			t.Errorf("error wrong result routing key")
+		}
+		received := string(body.Body)
+		if received != d.expectedResult {
+			t.Errorf(d.errMessage, received)
+		}
+	}
+}
+
+func TestListSets(t *testing.T) {
+	data := []struct {
+		testName       string
+		message        string
+		expectedResult string
+		errMessage     string
+	}{
+		{
+			"Negative case: wrong input",
+			`{"userid":2}`,
+			wrongInput,
+			"Error listing sets, received: %v",
+		},
+		{
+			"Negative case: no open training",
+			`{"user_id":1}`,
+			"ERROR: error listing sets: sql: no rows in result set",
+			"Error listing sets, received: %v",
+		},
+		{
+			"Positive case",
+			`{"user_id":2,"training_id":12}`,
+			success,
+			"Error listing sets, received: %v",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy("trainings.set.list", d.message)
+		})
+		body := <-clientConsumer.LastMessageCh
+		if body.RoutingKey != "tgbot.set.list" {
+			t.Errorf("error wrong result routing key")
+		}
+		received := string(body.Body)
+		if d.testName != "Positive case" && received != d.expectedResult {
+			t.Errorf(d.errMessage, received)
+		}
+		if d.testName == "Positive case" {
+			parts := strings.Split(received, ":")
+			if parts[0] != success {
+				t.Errorf(d.errMessage, received)
+			}
+		}
+	}
+}
+
+func TestPlannedSets(t *testing.T) {
+	data := []struct {
+		testName       string
+		routingKey     string
+		message        string
+		expectedResult string
+	}{
+		{
+			"Negative case: wrong input",
+			"trainings.set.planned",
+			`{"userid":2}`,
+			wrongInput,
+		},
+		{
+			"Positive case: list planned",
+			"trainings.set.planned",
+			`{"user_id":2}`,
+			"SUCCESS: [\n{\n\"id\": 1,\n\"training_id\": 12,\n\"position\": 1,\n\"exercise_id\": 1,\n\"set_number\": 1,\n\"reps\": 10,\n\"weight\": 60,\n\"rest\": 90,\n\"set_id\": 1\n},\n{\n\"id\": 2,\n\"training_id\": 12,\n\"position\": 1,\n\"exercise_id\": 1,\n\"set_number\": 2,\n\"reps\": 10,\n\"weight\": 60,\n\"rest\": 90,\n\"set_id\": 0\n},\n{\n\"id\": 3,\n\"training_id\": 12,\n\"position\": 2,\n\"exercise_id\": 3,\n\"set_number\": 1,\n\"reps\": 0,\n\"weight\": 0,\n\"rest\": 60,\n\"set_id\": 0\n}\n]",
+		},
+		{
+			"Positive case: list planned in pounds",
+			"trainings.set.planned",
+			`{"user_id":5}`,
+			"SUCCESS: [\n{\n\"id\": 1,\n\"training_id\": 12,\n\"position\": 1,\n\"exercise_id\": 1,\n\"set_number\": 1,\n\"reps\": 10,\n\"weight\": 132.28,\n\"rest\": 90,\n\"set_id\": 1\n},\n{\n\"id\": 2,\n\"training_id\": 12,\n\"position\": 1,\n\"exercise_id\": 1,\n\"set_number\": 2,\n\"reps\": 10,\n\"weight\": 132.28,\n\"rest\": 90,\n\"set_id\": 0\n},\n{\n\"id\": 3,\n\"training_id\": 12,\n\"position\": 2,\n\"exercise_id\": 3,\n\"set_number\": 1,\n\"reps\": 0,\n\"weight\": 0,\n\"rest\": 60,\n\"set_id\": 0\n}\n]",
+		},
+		{
+			"Negative case: wrong confirm input",
+			"trainings.set.confirm",
+			`{"user_id":2,"reps":8}`,
+			wrongInput,
+		},
+		{
+			"Negative case: already confirmed",
+			"trainings.set.confirm",
+			`{"user_id":2,"planned_id":1}`,
+			"ERROR: error confirming set: no rows updated",
+		},
+		{
+			"Positive case: confirm with edited reps",
+			"trainings.set.confirm",
+			`{"user_id":2,"planned_id":2,"reps":8}`,
+			"SUCCESS: id:2",
+		},
+		{
+			"Negative case: unexisting planned set",
+			"trainings.set.confirm",
+			`{"user_id":2,"planned_id":404,"reps":8}`,
+			"ERROR: error confirming set: no rows updated",
+		},
+		{
+			"Negative case: confirm cardio set without duration or distance",
+			"trainings.set.confirm",
+			`{"user_id":2,"planned_id":3,"avg_heart_rate":140}`,
+			wrongInput,
+		},
+		{
+			"Negative case: confirm with wrong heart rate",
+			"trainings.set.confirm",
+			`{"user_id":2,"planned_id":3,"distance":5,"avg_heart_rate":150,"max_heart_rate":140}`,
+			wrongInput,
+		},
+		{
+			"Positive case: confirm cardio set",
+			"trainings.set.confirm",
+			`{"user_id":2,"planned_id":3,"duration":1800,"distance":5,"avg_heart_rate":140,"calories":400}`,
+			"SUCCESS: id:2",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy(d.routingKey, d.message)
+			body := <-clientConsumer.LastMessageCh
+			if body.RoutingKey != "tgbot"+strings.TrimPrefix(d.routingKey, "trainings") {
+				t.Errorf("error wrong result routing key: %s", body.RoutingKey)
+			}
+			received := string(body.Body)
+			if received != d.expectedResult {
+				t.Errorf("Error handling planned sets, received: %v", received)
+			}
+		})
+	}
+}
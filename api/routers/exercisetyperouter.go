@@ -0,0 +1,108 @@
+package routers
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/converters"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// ExerciseTypeRouter - structure, that contains both consumer, and producer for messaging inside ExerciseType domain.
+// Exercise types are global and read-only
+type ExerciseTypeRouter struct {
+	rs.RConsumer
+	rs.RProducer
+	ets    stores.ExerciseTypeStore
+	routes map[string]func(amqp091.Delivery) responses.Response
+}
+
+// NewExerciseTypeRouter - Default method for creation ExerciseTypeRouter, requires rs.Configurer to create channels
+// for consumer and producer and connection to database, which is shared by its stores
+func NewExerciseTypeRouter(configurer rs.Configurer, conn *sqlx.DB) *ExerciseTypeRouter {
+	exerciseTypeRouter := ExerciseTypeRouter{}
+	exerciseTypeRouter.CreateConsumer(configurer)
+	exerciseTypeRouter.CreateProducer(configurer)
+	exerciseTypeRouter.SetETS(stores.NewETS(conn))
+	return &exerciseTypeRouter
+}
+
+// CreateConsumer - helper method
+func (etr *ExerciseTypeRouter) CreateConsumer(configurer rs.Configurer) {
+	etr.RConsumer = rs.RConsumer{}
+	err := etr.RConsumer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RConsumer for ExerciseTypeRouter")
+	}
+}
+
+// CreateProducer - helper method
+func (etr *ExerciseTypeRouter) CreateProducer(configurer rs.Configurer) {
+	etr.RProducer = rs.RProducer{}
+	err := etr.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for ExerciseTypeRouter")
+	}
+}
+
+// SetETS - Dependency injection of stores.ExerciseTypeStore
+func (etr *ExerciseTypeRouter) SetETS(ets stores.ExerciseTypeStore) {
+	etr.ets = ets
+}
+
+// Setup - main method, that sets up all routes and handlers for them
+func (etr *ExerciseTypeRouter) Setup() {
+	etr.routes = make(map[string]func(amqp091.Delivery) responses.Response)
+	etr.routes["list"] = etr.handleList
+	etr.routes["get"] = etr.handleGet
+	q, err := etr.RConsumer.CreateQueue(durableQueue("trainings.extype"))
+	if err != nil {
+		log.Fatal("error creating queue for exercise type consumer")
+	}
+	err = etr.RConsumer.SetBinding(q, "trainings.extype.#", EXCHANGE_NAME)
+	if err != nil {
+		log.Fatal("error creating binding for exercise type consumer")
+	}
+	//creating dispatcher
+	dispatcher := newRouteDispatcher()
+	for path, f := range etr.routes {
+		dispatcher.RegisterHandler("trainings.extype."+path, rs.NewHandlerFunc(func(msg amqp091.Delivery) {
+			process(etr.RProducer, msg, f, "tgbot.extype."+path)
+		}))
+	}
+	etr.RConsumer.RegisterDispatcher(q, dispatcher, manualAck())
+}
+
+func (etr *ExerciseTypeRouter) handleList(msg amqp091.Delivery) responses.Response {
+	slog.Info("request to list exercise types")
+	types, err := etr.ets.FindAll()
+	if err != nil {
+		return responses.Error(fmt.Errorf("error listing exercise types: %w", err))
+	}
+	return responses.Success(types)
+}
+
+func (etr *ExerciseTypeRouter) handleGet(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	id, err := converters.ParseExerciseTypeQuery(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to get exercise type: %d", id))
+	exerciseType, err := etr.ets.FindById(id)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting exercise type: %w", err))
+	}
+	return responses.Success(exerciseType)
+}
+
+// Stop - Closure for closing channels of consumer and producer
+func (etr ExerciseTypeRouter) Stop() {
+	etr.RConsumer.Stop()
+	etr.RProducer.Stop()
+}
@@ -0,0 +1,70 @@
+package routers
+
+import (
+	"strings"
+	"testing"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+func init() {
+	registerRouter(setupExerciseTypeRouter)
+}
+
+// setupExerciseTypeRouter - sets up ExerciseTypeRouter with stub stores
+func setupExerciseTypeRouter(configurer rs.Configurer) stopper {
+	router := &ExerciseTypeRouter{}
+	router.CreateConsumer(configurer)
+	router.CreateProducer(configurer)
+	router.SetETS(stores.ExerciseTypeStoreStub{})
+	router.Setup()
+	return router
+}
+
+func TestExerciseTypeRoutes(t *testing.T) {
+	data := []struct {
+		testName       string
+		routingKey     string
+		message        string
+		expectedResult string
+	}{
+		{
+			"Positive case: list",
+			"trainings.extype.list",
+			`{"user_id":2}`,
+			`SUCCESS: [{"id":1,"name":"CARDIO"},{"id":2,"name":"WORKOUT"},{"id":3,"name":"GYM"}]`,
+		},
+		{
+			"Negative case: get without id",
+			"trainings.extype.get",
+			`{"user_id":2}`,
+			wrongInput,
+		},
+		{
+			"Negative case: get unknown type",
+			"trainings.extype.get",
+			`{"id":7}`,
+			"ERROR: error getting exercise type: sql: no rows in result set",
+		},
+		{
+			"Positive case: get",
+			"trainings.extype.get",
+			`{"id":3}`,
+			`SUCCESS: {"id":3,"name":"GYM"}`,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy(d.routingKey, d.message)
+			body := <-clientConsumer.LastMessageCh
+			if body.RoutingKey != "tgbot"+strings.TrimPrefix(d.routingKey, "trainings") {
+				t.Errorf("error wrong result routing key: %s", body.RoutingKey)
+			}
+			received := string(body.Body)
+			if received != d.expectedResult {
+				t.Errorf("Error handling exercise types, received: %v", received)
+			}
+		})
+	}
+}
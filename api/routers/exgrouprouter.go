@@ -1,16 +1,16 @@
 package routers
 
 import (
-	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
 
 	rs "github.com/fridrock/rabbitsimplier"
 	"github.com/fridrock/trainingservice/api/utils/converters"
-	"github.com/fridrock/trainingservice/db/core"
+	"github.com/fridrock/trainingservice/api/utils/responses"
 	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
 	"github.com/rabbitmq/amqp091-go"
 )
 
@@ -19,16 +19,18 @@ type ExGroupRouter struct {
 	rs.RConsumer
 	rs.RProducer
 	egs    stores.ExGroupStore
-	routes map[string]func(amqp091.Delivery) string
+	pms    stores.ProcessedMessageStore
+	routes map[string]func(amqp091.Delivery) responses.Response
 }
 
 // NewExGroupRouter - Default method for creation ExGroupRouter, requires rs.Configurer to create channels
-// for consumer and producer
-func NewExGroupRouter(configurer rs.Configurer) *ExGroupRouter {
+// for consumer and producer and connection to database, which is shared by its stores
+func NewExGroupRouter(configurer rs.Configurer, conn *sqlx.DB) *ExGroupRouter {
 	exGroupRouter := ExGroupRouter{}
 	exGroupRouter.CreateConsumer(configurer)
 	exGroupRouter.CreateProducer(configurer)
-	exGroupRouter.SetEGS(stores.NewEGS(core.CreateConnection()))
+	exGroupRouter.SetEGS(stores.NewEGS(conn))
+	exGroupRouter.SetPMS(stores.NewPMS(conn))
 	return &exGroupRouter
 }
 
@@ -55,15 +57,22 @@ func (egr *ExGroupRouter) SetEGS(egs stores.ExGroupStore) {
 	egr.egs = egs
 }
 
+// SetPMS - Dependency injection of stores.ProcessedMessageStore
+func (egr *ExGroupRouter) SetPMS(pms stores.ProcessedMessageStore) {
+	egr.pms = pms
+}
+
 // Setup - main method, that sets up all routes and handlers for them
 func (egr *ExGroupRouter) Setup() {
-	egr.routes = make(map[string]func(amqp091.Delivery) string)
-	egr.routes["create"] = egr.handleCreate
-	egr.routes["delete"] = egr.handleDelete
+	egr.routes = make(map[string]func(amqp091.Delivery) responses.Response)
+	egr.routes["create"] = idempotent(egr.pms, egr.handleCreate)
+	egr.routes["delete"] = idempotent(egr.pms, egr.handleDelete)
+	egr.routes["restore"] = idempotent(egr.pms, egr.handleRestore)
 	egr.routes["find"] = egr.handleFind
-	egr.routes["update"] = egr.handleUpdate
+	egr.routes["update"] = idempotent(egr.pms, egr.handleUpdate)
 	egr.routes["findByUser"] = egr.handleFindByUser
-	q, err := egr.RConsumer.CreateQueue()
+	egr.routes["muscles"] = idempotent(egr.pms, egr.handleMuscles)
+	q, err := egr.RConsumer.CreateQueue(durableQueue("trainings.exgroup"))
 	if err != nil {
 		log.Fatal("error creating queue for exgroup consumer")
 	}
@@ -72,92 +81,104 @@ func (egr *ExGroupRouter) Setup() {
 		log.Fatal("error creating binding for exgroup consumer")
 	}
 	//creating dispatcher
-	dispatcher := rs.NewRDispacher()
+	dispatcher := newRouteDispatcher()
 	for path, f := range egr.routes {
 		dispatcher.RegisterHandler("trainings.exgroup."+path, rs.NewHandlerFunc(func(msg amqp091.Delivery) {
-			egr.sendResponse(f(msg), path)
+			process(egr.RProducer, msg, f, "tgbot.exgroup."+path)
 		}))
 	}
-	egr.RConsumer.RegisterDispatcher(q, dispatcher)
-}
-
-func (egr *ExGroupRouter) sendResponse(response, path string) {
-	egr.RProducer.PublishMessage(
-		context.Background(),
-		EXCHANGE_NAME, "tgbot.exgroup."+path,
-		response)
+	egr.RConsumer.RegisterDispatcher(q, dispatcher, manualAck())
 }
 
-func (egr *ExGroupRouter) handleCreate(msg amqp091.Delivery) string {
+func (egr *ExGroupRouter) handleCreate(msg amqp091.Delivery) responses.Response {
 	body := msg.Body
 	exg, err := converters.FromJsonToExGroup(body)
 	if err != nil {
-		return "ERROR: wrong input"
+		return responses.WrongInput(err)
 	}
 	slog.Info(fmt.Sprintf("request to create exgroup: %#v", exg))
 	gotId, err := egr.egs.Save(exg)
+	if errors.Is(err, stores.NameTaken) {
+		return responses.Error(err)
+	}
+	if err != nil {
+		return responses.Error(fmt.Errorf("internal server error: %w", err))
+	}
+	return responses.Created(gotId)
+}
+
+// handleDelete - deletes group, exercises of group are handled according to converters.DeleteMode of request
+func (egr *ExGroupRouter) handleDelete(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	deleteQuery, err := converters.ParseDeleteExGroup(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to delete ex group with user_id: %d, name: %v, mode: %s",
+		deleteQuery.UserId, deleteQuery.Name, deleteQuery.Mode))
+	switch deleteQuery.Mode {
+	case converters.Move:
+		err = egr.egs.MoveByName(deleteQuery.UserId, deleteQuery.Name, deleteQuery.Target)
+	case converters.Cascade:
+		err = egr.egs.SoftDeleteByName(deleteQuery.UserId, deleteQuery.Name)
+	default:
+		err = egr.egs.DeleteByName(deleteQuery.UserId, deleteQuery.Name)
+	}
 	if err != nil {
-		return fmt.Sprintf("ERROR: internal server error: %v", err)
+		return responses.Error(err)
 	}
-	return fmt.Sprintf("SUCCESS: id:%d", gotId)
+	return responses.Success(nil)
 }
 
-func (egr *ExGroupRouter) handleDelete(msg amqp091.Delivery) string {
+// handleRestore - restores group deleted in converters.Cascade mode together with its exercises
+func (egr *ExGroupRouter) handleRestore(msg amqp091.Delivery) responses.Response {
 	body := msg.Body
 	userId, name, err := converters.ParseExGroupProperties(body)
 	if err != nil {
-		return "ERROR: wrong input"
+		return responses.WrongInput(err)
 	}
-	slog.Info(fmt.Sprintf("request to delete ex group with user_id: %d, name: %v", userId, name))
-	err = egr.egs.DeleteByName(userId, name)
+	slog.Info(fmt.Sprintf("request to restore ex group with user_id: %d, name: %v", userId, name))
+	err = egr.egs.RestoreByName(userId, name)
 	if err != nil {
-		return fmt.Sprintf("ERROR: %v", err)
+		return responses.Error(err)
 	}
-	return "SUCCESS"
+	return responses.Success(nil)
 }
 
-func (egr *ExGroupRouter) handleFind(msg amqp091.Delivery) string {
+func (egr *ExGroupRouter) handleFind(msg amqp091.Delivery) responses.Response {
 	body := msg.Body
 	userId, name, err := converters.ParseExGroupProperties(body)
 	if err != nil {
-		return "ERROR: wrong input"
+		return responses.WrongInput(err)
 	}
 	slog.Info(fmt.Sprintf("request to find ex group with user_id: %d, name: %v", userId, name))
 	exGroup, err := egr.egs.FindByName(userId, name)
 	if err != nil {
-		return fmt.Sprintf("ERROR: %v", err)
-	}
-	r, err := json.Marshal(&exGroup)
-	if err != nil {
-		return fmt.Sprintf("ERROR: %v", err)
+		return responses.Error(err)
 	}
-	return "SUCCESS: " + string(r)
+	return responses.Success(exGroup)
 }
 
-func (egr *ExGroupRouter) handleFindByUser(msg amqp091.Delivery) string {
+func (egr *ExGroupRouter) handleFindByUser(msg amqp091.Delivery) responses.Response {
 	body := msg.Body
 	userId, err := converters.ParseUserID(body)
 	if err != nil {
-		return "ERROR: wrong input"
+		return responses.WrongInput(err)
 	}
 
 	slog.Info(fmt.Sprintf("request to find by user_id: %d", userId))
 	exGroups, err := egr.egs.FindByUserId(userId)
 	if err != nil {
-		return fmt.Sprintf("ERROR: %v", err)
+		return responses.Error(err)
 	}
-	response, err := json.MarshalIndent(exGroups, "", "")
-	if err != nil {
-		return fmt.Sprintf("ERROR: %v", err)
-	}
-	return fmt.Sprintf("SUCCESS: %v", string(response))
+	return responses.Success(exGroups)
 }
 
-func (egr *ExGroupRouter) handleUpdate(msg amqp091.Delivery) string {
+func (egr *ExGroupRouter) handleUpdate(msg amqp091.Delivery) responses.Response {
 	body := msg.Body
 	updateExGroup, err := converters.ParseUpdateExGroup(body)
 	if err != nil {
-		return "ERROR: wrong input"
+		return responses.WrongInput(err)
 	}
 	slog.Info(fmt.Sprintf(
 		"request to update ex group with user_id: %d, name: %s, new_name: %s",
@@ -166,9 +187,25 @@ func (egr *ExGroupRouter) handleUpdate(msg amqp091.Delivery) string {
 		updateExGroup.NewName))
 	err = egr.egs.UpdateByName(updateExGroup.UserId, updateExGroup.Name, updateExGroup.NewName)
 	if err != nil {
-		return fmt.Sprintf("ERROR: %v", err)
+		return responses.Error(err)
+	}
+	return responses.Success(nil)
+}
+
+// handleMuscles - maps group to muscles of taxonomy, which are used in balance analytics
+func (egr *ExGroupRouter) handleMuscles(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	mapping, err := converters.ParseMuscleMapping(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to map ex group with user_id: %d, name: %s to muscles: %v",
+		mapping.UserId, mapping.Name, mapping.Muscles))
+	err = egr.egs.SetMuscles(mapping.UserId, mapping.Name, mapping.Muscles)
+	if err != nil {
+		return responses.Error(err)
 	}
-	return "SUCCESS"
+	return responses.Success(nil)
 }
 
 // Stop - Closure for closing channels of consumer and producer
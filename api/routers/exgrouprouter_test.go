@@ -3,55 +3,89 @@ package routers
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"strings"
 	"testing"
 
 	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/responses"
 	"github.com/fridrock/trainingservice/db/stores"
-	"github.com/fridrock/trainingservice/test"
-	"github.com/testcontainers/testcontainers-go/modules/rabbitmq"
 )
 
-var (
-	rmqContainer   *rabbitmq.RabbitMQContainer
-	clientConsumer *test.AllMessagesConsumer
-	clientProducer *rs.RProducer
-	exGroupRouter  *ExGroupRouter
-	tRouter        *TrainingRouter
-)
+func init() {
+	registerRouter(setupExGroupRouter)
+}
 
-const (
-	wrongInput     = "ERROR: wrong input"
-	notDeleted     = "ERROR: no rows deleted"
-	success        = "SUCCESS"
-	successFinding = `SUCCESS: {"id":1,"user_id":2,"name":"Back"}`
-)
+// setupExGroupRouter - sets up ExGroupRouter with stub stores
+func setupExGroupRouter(configurer rs.Configurer) stopper {
+	router := &ExGroupRouter{}
+	router.CreateConsumer(configurer)
+	router.CreateProducer(configurer)
+	router.SetEGS(stores.EGSStub{})
+	router.SetPMS(stores.NewPMSStub())
+	router.Setup()
+	return router
+}
 
-func TestMain(m *testing.M) {
-	//setting up
-	rmqContainer = test.GetRmqContainer()
-	clientProducer = test.GetClientProducer()
-	clientConsumer = test.GetClientConsumer()
-	//ExGroup Setup
-	exGroupRouter = &ExGroupRouter{}
-	exGroupRouter.CreateConsumer(test.GetClientConfigurer())
-	exGroupRouter.CreateProducer(test.GetClientConfigurer())
-	egs := stores.EGSStub{}
-	exGroupRouter.SetEGS(egs)
-	exGroupRouter.Setup()
-	//Training Setup
-	tRouter = &TrainingRouter{}
-	tRouter.CreateConsumer(test.GetClientConfigurer())
-	tRouter.CreateProducer(test.GetClientConfigurer())
-	ts := stores.TrainingStoreStub{}
-	tRouter.SetTS(ts)
-	tRouter.Setup()
-	//running tests
-	m.Run()
-	//tearing down
-	exGroupRouter.Stop()
-	tRouter.Stop()
-	test.Stop()
+func TestEnvelopeResponse(t *testing.T) {
+	data := []struct {
+		testName       string
+		routingKey     string
+		message        string
+		expectedStatus responses.Status
+		expectedCode   responses.Code
+	}{
+		{
+			"Negative case wrong input",
+			"trainings.exgroup.create",
+			`{"user_id":1`,
+			responses.StatusError,
+			responses.Validation,
+		},
+		{
+			"Negative case not found",
+			"trainings.exgroup.find",
+			`{"user_id":2, "name":"Unexisting"}`,
+			responses.StatusError,
+			responses.NotFound,
+		},
+		{
+			"Negative case conflict",
+			"trainings.training.finish",
+			`{"user_id":1}`,
+			responses.StatusError,
+			responses.Conflict,
+		},
+		{
+			"Negative case name taken",
+			"trainings.exgroup.create",
+			`{"user_id":1,"name":"Front"}`,
+			responses.StatusError,
+			responses.Conflict,
+		},
+		{
+			"Positive case",
+			"trainings.exgroup.find",
+			`{"user_id":2, "name":"Back"}`,
+			responses.StatusSuccess,
+			responses.OK,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			clientProducer.PublishMessage(context.Background(), "sport_bot", d.routingKey, d.message)
+			var received responses.Response
+			err := json.Unmarshal((<-clientConsumer.LastMessageCh).Body, &received)
+			if err != nil {
+				t.Fatalf("error decoding response envelope: %v", err)
+			}
+			if received.Version != responses.Version ||
+				received.Status != d.expectedStatus ||
+				received.Code != d.expectedCode {
+				t.Errorf("got wrong response envelope: %#v", received)
+			}
+		})
+	}
 }
 
 func TestAddExGroup(t *testing.T) {
@@ -67,6 +101,12 @@ func TestAddExGroup(t *testing.T) {
 			wrongInput,
 			"error while validation, received: %s",
 		},
+		{
+			"Negative case name taken",
+			`{"user_id":1,"name":"front"}`,
+			"ERROR: " + stores.NameTaken.Error(),
+			"wrong result adding exgroup with taken name, received: %s",
+		},
 		{
 			"Positive case",
 			`{"user_id":1,"name":"Back"}`,
@@ -77,11 +117,7 @@ func TestAddExGroup(t *testing.T) {
 
 	for _, d := range data {
 		t.Run(d.testName, func(t *testing.T) {
-			clientProducer.PublishMessage(context.Background(),
-				"sport_bot",
-				"trainings.exgroup.create",
-				d.message,
-			)
+			publishLegacy("trainings.exgroup.create", d.message)
 			received := string((<-clientConsumer.LastMessageCh).Body)
 			if received != d.resultExpected {
 				t.Errorf(d.errMessage, received)
@@ -109,16 +145,31 @@ func TestDeleteByName(t *testing.T) {
 			`{"user_id":2, "name":"Back"}`,
 			success,
 			"error with successful deletion of ex group, received: %s"},
+		{"Negative case unknown mode",
+			`{"user_id":2, "name":"Back", "mode":"drop"}`,
+			wrongInput,
+			"error with unknown delete mode, received: %s"},
+		{"Negative case group has exercises",
+			`{"user_id":2, "name":"Chest"}`,
+			"ERROR: exercise group has exercises: Bench press, Push up",
+			"error with deleting ex group with exercises, received: %s"},
+		{"Negative case no target group",
+			`{"user_id":2, "name":"Chest", "mode":"move", "target":"Unexisting"}`,
+			"ERROR: sql: no rows in result set",
+			"error with moving exercises to unexisting ex group, received: %s"},
+		{"Positive case moved",
+			`{"user_id":2, "name":"Chest", "mode":"move", "target":"Front"}`,
+			success,
+			"error with moving exercises of ex group, received: %s"},
+		{"Positive case cascade",
+			`{"user_id":2, "name":"Chest", "mode":"cascade"}`,
+			success,
+			"error with soft deletion of ex group, received: %s"},
 	}
 
 	for _, d := range data {
 		t.Run(d.testName, func(t *testing.T) {
-			clientProducer.PublishMessage(
-				context.Background(),
-				"sport_bot",
-				"trainings.exgroup.delete",
-				d.message,
-			)
+			publishLegacy("trainings.exgroup.delete", d.message)
 			received := <-clientConsumer.LastMessageCh
 			if string(received.Body) != d.resultExpected {
 				t.Errorf(d.errorMessage, string(received.Body))
@@ -156,12 +207,7 @@ func TestFindByName(t *testing.T) {
 
 	for _, d := range data {
 		t.Run(d.testName, func(t *testing.T) {
-			clientProducer.PublishMessage(
-				context.Background(),
-				"sport_bot",
-				"trainings.exgroup.find",
-				d.message,
-			)
+			publishLegacy("trainings.exgroup.find", d.message)
 			received := string((<-clientConsumer.LastMessageCh).Body)
 			if received != d.resultExpected {
 				t.Errorf(d.errMessage, received)
@@ -189,6 +235,12 @@ func TestUpdateByName(t *testing.T) {
 			"ERROR: " + stores.NotUpdated.Error(),
 			"error with updating unexisting ex group, received: %s",
 		},
+		{
+			"Negative case name taken",
+			`{"user_id":2,"name":"Back","newname":"FRONT"}`,
+			"ERROR: " + stores.NameTaken.Error(),
+			"error with renaming ex group to taken name, received: %s",
+		},
 		{
 			"Positive case updated",
 			`{"user_id":2, "name":"Back", "newname":"NewBack"}`,
@@ -199,12 +251,7 @@ func TestUpdateByName(t *testing.T) {
 
 	for _, d := range data {
 		t.Run(d.testName, func(t *testing.T) {
-			clientProducer.PublishMessage(
-				context.Background(),
-				"sport_bot",
-				"trainings.exgroup.update",
-				d.message,
-			)
+			publishLegacy("trainings.exgroup.update", d.message)
 			received := string((<-clientConsumer.LastMessageCh).Body)
 			if received != d.resultExpected {
 				t.Errorf(d.errMessage, received)
@@ -242,12 +289,7 @@ func TestFindByUser(t *testing.T) {
 
 	for _, d := range data {
 		t.Run(d.testName, func(t *testing.T) {
-			clientProducer.PublishMessage(
-				context.Background(),
-				"sport_bot",
-				"trainings.exgroup.findByUser",
-				d.message,
-			)
+			publishLegacy("trainings.exgroup.findByUser", d.message)
 			received := string((<-clientConsumer.LastMessageCh).Body)
 			if d.testName != "Positive case got groups" && received != d.resultExpected {
 				t.Errorf(d.errMessage, received)
@@ -262,3 +304,78 @@ func TestFindByUser(t *testing.T) {
 		})
 	}
 }
+
+func TestMapExGroupMuscles(t *testing.T) {
+	data := []struct {
+		testName       string
+		message        string
+		resultExpected string
+		errMessage     string
+	}{
+		{
+			"Negative case unknown muscle",
+			`{"user_id":2,"name":"Back","muscles":["wings"]}`,
+			wrongInput,
+			"error with unknown muscle, received: %s",
+		},
+		{
+			"Negative case no such ex group",
+			`{"user_id":2,"name":"Unexisting","muscles":["lats"]}`,
+			"ERROR: " + stores.NotUpdated.Error(),
+			"error with mapping unexisting ex group, received: %s",
+		},
+		{
+			"Positive case mapped",
+			`{"user_id":2,"name":"Back","muscles":["lats","biceps"]}`,
+			success,
+			"error with successful mapping of ex group, received: %s",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy("trainings.exgroup.muscles", d.message)
+			received := <-clientConsumer.LastMessageCh
+			if received.RoutingKey != "tgbot.exgroup.muscles" {
+				t.Errorf("error wrong result routing key")
+			}
+			if string(received.Body) != d.resultExpected {
+				t.Errorf(d.errMessage, string(received.Body))
+			}
+		})
+	}
+}
+
+func TestRestoreExGroup(t *testing.T) {
+	data := []struct {
+		testName       string
+		message        string
+		resultExpected string
+		codeExpected   responses.Code
+	}{
+		{"Negative case wrong input", `{"user_id":2}`, wrongInput, responses.Validation},
+		{"Negative case no deleted group", `{"user_id":2,"name":"Unexisting"}`, "ERROR: no rows updated", responses.NotFound},
+		{
+			"Negative case name is taken",
+			`{"user_id":2,"name":"Back"}`,
+			"ERROR: exercise group with this name already exists",
+			responses.Conflict,
+		},
+		{"Positive case", `{"user_id":2,"name":"Chest"}`, success, responses.OK},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy("trainings.exgroup.restore", d.message)
+			received := <-clientConsumer.LastMessageCh
+			if received.RoutingKey != "tgbot.exgroup.restore" {
+				t.Errorf("error wrong result routing key")
+			}
+			if string(received.Body) != d.resultExpected {
+				t.Errorf("error restoring ex group, received: %s", string(received.Body))
+			}
+			response, err := rpcClient.Call(context.Background(), "trainings.exgroup.restore", json.RawMessage(d.message))
+			if err != nil || response.Code != d.codeExpected {
+				t.Errorf("got wrong code: %#v, %v", response, err)
+			}
+		})
+	}
+}
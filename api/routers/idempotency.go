@@ -0,0 +1,68 @@
+package routers
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/fridrock/trainingservice/api/utils/converters"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// idempotencyKey - key of message, idempotency_key of body has priority over MessageId,
+// empty if message has neither of them
+func idempotencyKey(msg amqp091.Delivery) string {
+	key := converters.ParseIdempotencyKey(msg.Body)
+	if key == "" {
+		key = msg.MessageId
+	}
+	if key == "" {
+		return ""
+	}
+	return msg.RoutingKey + ":" + key
+}
+
+// idempotent - wraps handler of command, so duplicate deliveries of message get the original response
+// instead of repeating side effects. Responses with internal error aren't saved, so message can be retried
+func idempotent(pms stores.ProcessedMessageStore, handle func(amqp091.Delivery) responses.Response) func(amqp091.Delivery) responses.Response {
+	return func(msg amqp091.Delivery) (response responses.Response) {
+		key := idempotencyKey(msg)
+		if key == "" {
+			return handle(msg)
+		}
+		reserved, err := pms.Reserve(key)
+		if err != nil {
+			return responses.Error(fmt.Errorf("internal server error: %w", err))
+		}
+		if !reserved {
+			encoded, err := pms.FindResponse(key)
+			if err != nil {
+				return responses.Error(err)
+			}
+			slog.Info(fmt.Sprintf("duplicate message with key: %s", key))
+			response, err = responses.Decode(encoded)
+			if err != nil {
+				return responses.Error(fmt.Errorf("internal server error: %w", err))
+			}
+			return response
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				pms.Release(key)
+				panic(r)
+			}
+		}()
+		response = handle(msg)
+		if response.Code == responses.Internal {
+			pms.Release(key)
+			return response
+		}
+		err = pms.Complete(key, []byte(response.Encode(false)))
+		if err != nil {
+			slog.Error(fmt.Sprintf("error saving response of message with key %s: %v", key, err))
+			return responses.Error(fmt.Errorf("internal server error: error saving response: %w", err))
+		}
+		return response
+	}
+}
@@ -0,0 +1,123 @@
+package routers
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+func publishWithMessageId(routingKey, message, messageId string) {
+	clientProducer.Ch.PublishWithContext(context.Background(),
+		EXCHANGE_NAME,
+		routingKey,
+		false,
+		false,
+		amqp091.Publishing{
+			ContentType: "application/json",
+			MessageId:   messageId,
+			Headers:     amqp091.Table{responses.FormatHeader: responses.LegacyFormat},
+			Body:        []byte(message),
+		})
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	data := []struct {
+		testName    string
+		msg         amqp091.Delivery
+		expectedKey string
+	}{
+		{
+			"no key",
+			amqp091.Delivery{RoutingKey: "trainings.training.start", Body: []byte(`{"user_id":2}`)},
+			"",
+		},
+		{
+			"message id",
+			amqp091.Delivery{RoutingKey: "trainings.training.start", MessageId: "42", Body: []byte(`{"user_id":2}`)},
+			"trainings.training.start:42",
+		},
+		{
+			"body key has priority",
+			amqp091.Delivery{RoutingKey: "trainings.training.start", MessageId: "42", Body: []byte(`{"user_id":2,"idempotency_key":"abc"}`)},
+			"trainings.training.start:abc",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			if key := idempotencyKey(d.msg); key != d.expectedKey {
+				t.Errorf("got wrong key: %s", key)
+			}
+		})
+	}
+}
+
+func TestDuplicateDelivery(t *testing.T) {
+	data := []struct {
+		testName       string
+		routingKey     string
+		message        string
+		messageId      string
+		expectedResult string
+	}{
+		{
+			"Positive case first delivery",
+			"trainings.exgroup.create",
+			`{"user_id":1,"name":"Back"}`,
+			"create-1",
+			"SUCCESS: id:1",
+		},
+		{
+			"Positive case duplicate delivery",
+			"trainings.exgroup.create",
+			`{"user_id":1,"name":"Back"}`,
+			"create-1",
+			"SUCCESS: id:1",
+		},
+		{
+			"Negative case duplicate of failed command",
+			"trainings.training.finish",
+			`{"user_id":1,"idempotency_key":"finish-1"}`,
+			"",
+			"ERROR: error finishing training: Empty non-finished trainings list",
+		},
+		{
+			"Negative case duplicate of failed command repeated",
+			"trainings.training.finish",
+			`{"user_id":1,"idempotency_key":"finish-1"}`,
+			"",
+			"ERROR: error finishing training: Empty non-finished trainings list",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishWithMessageId(d.routingKey, d.message, d.messageId)
+			received := string((<-clientConsumer.LastMessageCh).Body)
+			if received != d.expectedResult {
+				t.Errorf("got wrong response of duplicate: %s", received)
+			}
+		})
+	}
+}
+
+// failingPMS - store, that loses connection while saving response
+type failingPMS struct {
+	*stores.PMSStub
+}
+
+func (fpms failingPMS) Complete(key string, response []byte) error {
+	return driver.ErrBadConn
+}
+
+func TestCompleteFailure(t *testing.T) {
+	handle := idempotent(failingPMS{stores.NewPMSStub()}, func(msg amqp091.Delivery) responses.Response {
+		return responses.Created(1)
+	})
+	response := handle(amqp091.Delivery{RoutingKey: "trainings.exgroup.create", MessageId: "42"})
+	if response.Code != responses.Internal || !response.Transient {
+		t.Errorf("failure of saving response wasn't reported: %#v", response)
+	}
+}
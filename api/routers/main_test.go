@@ -0,0 +1,89 @@
+package routers
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/client"
+	"github.com/fridrock/trainingservice/test"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/testcontainers/testcontainers-go/modules/rabbitmq"
+)
+
+var (
+	rmqContainer   *rabbitmq.RabbitMQContainer
+	clientConsumer *test.AllMessagesConsumer
+	clientProducer *rs.RProducer
+	rpcClient      *client.Client
+	routerSetups   []func(configurer rs.Configurer) stopper
+)
+
+const (
+	wrongInput     = "ERROR: wrong input"
+	notDeleted     = "ERROR: no rows deleted"
+	success        = "SUCCESS"
+	successFinding = `SUCCESS: {"id":1,"user_id":2,"name":"Back"}`
+)
+
+// stopper - router under test, which is stopped after tests
+type stopper interface {
+	Stop()
+}
+
+// registerRouter - registers setup of router under test, test file of each router registers its own setup
+// in init
+func registerRouter(setup func(configurer rs.Configurer) stopper) {
+	routerSetups = append(routerSetups, setup)
+}
+
+func TestMain(m *testing.M) {
+	//setting up
+	rmqContainer = test.GetRmqContainer()
+	clientProducer = test.GetClientProducer()
+	clientConsumer = test.GetClientConsumer()
+	rpc, err := client.NewClient(test.GetClientConfigurer(), 5*time.Second)
+	if err != nil {
+		log.Fatalf("error creating rpc client in test: %s", err)
+	}
+	rpcClient = rpc
+	SetRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: 10 * time.Millisecond})
+	err = SetupRetryTopology(test.GetClientConfigurer())
+	if err != nil {
+		log.Fatalf("error creating retry topology in test: %s", err)
+	}
+	routers := make([]stopper, 0, len(routerSetups))
+	for _, setup := range routerSetups {
+		routers = append(routers, setup(test.GetClientConfigurer()))
+	}
+	//running tests
+	m.Run()
+	//tearing down
+	for _, router := range routers {
+		router.Stop()
+	}
+	rpcClient.Stop()
+	test.Stop()
+}
+
+// publishWithHeaders - publishes request to service with custom headers
+func publishWithHeaders(routingKey, message string, headers amqp091.Table) {
+	clientProducer.Ch.PublishWithContext(context.Background(),
+		EXCHANGE_NAME,
+		routingKey,
+		false,
+		false,
+		amqp091.Publishing{
+			ContentType: "application/json",
+			Headers:     headers,
+			Body:        []byte(message),
+		})
+}
+
+// publishLegacy - publishes request to service, asking for legacy string response
+func publishLegacy(routingKey, message string) {
+	publishWithHeaders(routingKey, message, amqp091.Table{responses.FormatHeader: responses.LegacyFormat})
+}
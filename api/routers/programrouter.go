@@ -0,0 +1,221 @@
+package routers
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/converters"
+	"github.com/fridrock/trainingservice/api/utils/periodization"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/api/utils/units"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// ProgramRouter - structure, that contains both consumer, and producer for messaging inside Program domain
+type ProgramRouter struct {
+	rs.RConsumer
+	rs.RProducer
+	pgs    stores.ProgramStore
+	tps    stores.TemplateStore
+	uss    stores.SettingsStore
+	pms    stores.ProcessedMessageStore
+	routes map[string]func(amqp091.Delivery) responses.Response
+}
+
+// NewProgramRouter - Default method for creation ProgramRouter, requires rs.Configurer to create channels
+// for consumer and producer and connection to database, which is shared by its stores
+func NewProgramRouter(configurer rs.Configurer, conn *sqlx.DB) *ProgramRouter {
+	programRouter := ProgramRouter{}
+	programRouter.CreateConsumer(configurer)
+	programRouter.CreateProducer(configurer)
+	programRouter.SetPGS(stores.NewPGS(conn))
+	programRouter.SetTPS(stores.NewTPS(conn))
+	programRouter.SetUSS(stores.NewUSS(conn))
+	programRouter.SetPMS(stores.NewPMS(conn))
+	return &programRouter
+}
+
+// CreateConsumer - helper method
+func (pr *ProgramRouter) CreateConsumer(configurer rs.Configurer) {
+	pr.RConsumer = rs.RConsumer{}
+	err := pr.RConsumer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RConsumer for ProgramRouter")
+	}
+}
+
+// CreateProducer - helper method
+func (pr *ProgramRouter) CreateProducer(configurer rs.Configurer) {
+	pr.RProducer = rs.RProducer{}
+	err := pr.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for ProgramRouter")
+	}
+}
+
+// SetPGS - Dependency injection of stores.ProgramStore
+func (pr *ProgramRouter) SetPGS(pgs stores.ProgramStore) {
+	pr.pgs = pgs
+}
+
+// SetTPS - Dependency injection of stores.TemplateStore
+func (pr *ProgramRouter) SetTPS(tps stores.TemplateStore) {
+	pr.tps = tps
+}
+
+// SetPMS - Dependency injection of stores.ProcessedMessageStore
+func (pr *ProgramRouter) SetPMS(pms stores.ProcessedMessageStore) {
+	pr.pms = pms
+}
+
+// SetUSS - Dependency injection of stores.SettingsStore
+func (pr *ProgramRouter) SetUSS(uss stores.SettingsStore) {
+	pr.uss = uss
+}
+
+// Setup - main method, that sets up all routes and handlers for them
+func (pr *ProgramRouter) Setup() {
+	pr.routes = make(map[string]func(amqp091.Delivery) responses.Response)
+	pr.routes["create"] = idempotent(pr.pms, pr.handleCreate)
+	pr.routes["find"] = pr.handleFind
+	pr.routes["enroll"] = idempotent(pr.pms, pr.handleEnroll)
+	pr.routes["today"] = pr.handleToday
+	pr.routes["skip"] = idempotent(pr.pms, pr.handleSkip)
+	pr.routes["advance"] = idempotent(pr.pms, pr.handleAdvance)
+	q, err := pr.RConsumer.CreateQueue(durableQueue("trainings.program"))
+	if err != nil {
+		log.Fatal("error creating queue for program consumer")
+	}
+	err = pr.RConsumer.SetBinding(q, "trainings.program.#", EXCHANGE_NAME)
+	if err != nil {
+		log.Fatal("error creating binding for program consumer")
+	}
+	//creating dispatcher
+	dispatcher := newRouteDispatcher()
+	for path, f := range pr.routes {
+		dispatcher.RegisterHandler("trainings.program."+path, rs.NewHandlerFunc(func(msg amqp091.Delivery) {
+			process(pr.RProducer, msg, f, "tgbot.program."+path)
+		}))
+	}
+	pr.RConsumer.RegisterDispatcher(q, dispatcher, manualAck())
+}
+
+func (pr *ProgramRouter) handleCreate(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	program, err := converters.FromJsonToProgram(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to create program: %#v", program))
+	settings, err := pr.uss.Find(program.UserId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error finding settings: %w", err))
+	}
+	gotId, err := pr.pgs.Save(units.FromUser(program, settings))
+	if err != nil {
+		return responses.Error(fmt.Errorf("error creating program: %w", err))
+	}
+	return responses.Created(gotId)
+}
+
+func (pr *ProgramRouter) handleFind(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	query, err := converters.ParseProgramQuery(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to find program with user_id: %d, id: %d", query.UserId, query.ProgramId))
+	program, err := pr.pgs.FindById(query.UserId, query.ProgramId)
+	if err != nil {
+		return responses.Error(err)
+	}
+	settings, err := pr.uss.Find(query.UserId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error finding settings: %w", err))
+	}
+	return responses.Success(units.ToUser(program, settings))
+}
+
+func (pr *ProgramRouter) handleEnroll(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	query, err := converters.ParseProgramQuery(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to enroll user %d to program %d", query.UserId, query.ProgramId))
+	enrollmentId, err := pr.pgs.Enroll(query.UserId, query.ProgramId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error enrolling to program: %w", err))
+	}
+	return responses.Created(enrollmentId)
+}
+
+// handleToday - computes today's workout from enrollment, planned weights follow progression rules of program
+func (pr *ProgramRouter) handleToday(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	userId, err := converters.ParseUserID(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to get today's workout with user: %d", userId))
+	enrollment, err := pr.pgs.FindEnrollment(userId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting today's workout: %w", err))
+	}
+	program, err := pr.pgs.FindById(userId, enrollment.ProgramId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting today's workout: %w", err))
+	}
+	rules := periodization.NewRules(program)
+	position, ok := rules.PositionOf(enrollment.DayIndex)
+	if !ok {
+		return responses.Error(fmt.Errorf("error getting today's workout: %w", stores.ProgramCompleted))
+	}
+	template, err := pr.tps.FindById(userId, program.Days[position.Day-1].TemplateId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting today's workout: %w", err))
+	}
+	settings, err := pr.uss.Find(userId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error finding settings: %w", err))
+	}
+	return responses.Success(units.ToUser(rules.Plan(program.Id, enrollment.DayIndex, position, template), settings))
+}
+
+func (pr *ProgramRouter) handleSkip(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	userId, err := converters.ParseUserID(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to skip workout with user: %d", userId))
+	err = pr.pgs.Skip(userId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error skipping workout: %w", err))
+	}
+	return responses.Success(nil)
+}
+
+func (pr *ProgramRouter) handleAdvance(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	userId, err := converters.ParseUserID(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to advance program with user: %d", userId))
+	err = pr.pgs.Advance(userId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error advancing program: %w", err))
+	}
+	return responses.Success(nil)
+}
+
+// Stop - Closure for closing channels of consumer and producer
+func (pr ProgramRouter) Stop() {
+	pr.RConsumer.Stop()
+	pr.RProducer.Stop()
+}
@@ -0,0 +1,121 @@
+package routers
+
+import (
+	"strings"
+	"testing"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+func init() {
+	registerRouter(setupProgramRouter)
+}
+
+// setupProgramRouter - sets up ProgramRouter with stub stores
+func setupProgramRouter(configurer rs.Configurer) stopper {
+	router := &ProgramRouter{}
+	router.CreateConsumer(configurer)
+	router.CreateProducer(configurer)
+	router.SetPGS(stores.ProgramStoreStub{})
+	router.SetTPS(stores.TemplateStoreStub{})
+	router.SetUSS(stores.SettingsStoreStub{})
+	router.SetPMS(stores.NewPMSStub())
+	router.Setup()
+	return router
+}
+
+func TestProgramRoutes(t *testing.T) {
+	data := []struct {
+		testName       string
+		routingKey     string
+		message        string
+		expectedResult string
+	}{
+		{
+			"Negative case: create without days",
+			"trainings.program.create",
+			`{"user_id":2,"name":"Strength","weeks":8}`,
+			wrongInput,
+		},
+		{
+			"Negative case: create with unexisting template",
+			"trainings.program.create",
+			`{"user_id":2,"name":"Strength","weeks":8,"days":[{"template_id":2}]}`,
+			"ERROR: error creating program: sql: no rows in result set",
+		},
+		{
+			"Positive case: create",
+			"trainings.program.create",
+			`{"user_id":2,"name":"Strength","weeks":8,"deload_every":4,"days":[{"template_id":1}],"progressions":[{"exercise_id":1,"increment":2.5}]}`,
+			"SUCCESS: id:1",
+		},
+		{
+			"Positive case: find",
+			"trainings.program.find",
+			`{"user_id":2,"program_id":1}`,
+			`SUCCESS: {"id":1,"user_id":2,"name":"Strength","weeks":8,"deload_every":4,"deload_factor":0.9,"days":[{"position":1,"template_id":1}],"progressions":[{"exercise_id":1,"increment":2.5}]}`,
+		},
+		{
+			"Negative case: enroll to unexisting program",
+			"trainings.program.enroll",
+			`{"user_id":2,"program_id":2}`,
+			"ERROR: error enrolling to program: sql: no rows in result set",
+		},
+		{
+			"Positive case: enroll",
+			"trainings.program.enroll",
+			`{"user_id":2,"program_id":1}`,
+			"SUCCESS: id:1",
+		},
+		{
+			"Negative case: today without enrollment",
+			"trainings.program.today",
+			`{"user_id":1}`,
+			"ERROR: error getting today's workout: no active program enrollment",
+		},
+		{
+			"Negative case: today of completed program",
+			"trainings.program.today",
+			`{"user_id":4}`,
+			"ERROR: error getting today's workout: program is completed",
+		},
+		{
+			"Positive case: today with progressed weight",
+			"trainings.program.today",
+			`{"user_id":2}`,
+			`SUCCESS: {"program_id":1,"day_index":2,"week":3,"day":1,"deload":false,"template_id":1,"name":"Push day","exercises":[{"id":1,"template_id":1,"position":1,"exercise_id":1,"sets":3,"reps":10,"weight":65,"rest":90}]}`,
+		},
+		{
+			"Negative case: skip without enrollment",
+			"trainings.program.skip",
+			`{"user_id":1}`,
+			"ERROR: error skipping workout: no active program enrollment",
+		},
+		{
+			"Positive case: skip",
+			"trainings.program.skip",
+			`{"user_id":2}`,
+			success,
+		},
+		{
+			"Positive case: advance",
+			"trainings.program.advance",
+			`{"user_id":2}`,
+			success,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy(d.routingKey, d.message)
+			body := <-clientConsumer.LastMessageCh
+			if body.RoutingKey != "tgbot"+strings.TrimPrefix(d.routingKey, "trainings") {
+				t.Errorf("error wrong result routing key: %s", body.RoutingKey)
+			}
+			received := string(body.Body)
+			if received != d.expectedResult {
+				t.Errorf("Error handling program, received: %v", received)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package routers
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/converters"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/api/utils/units"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// RecordRouter - structure, that contains both consumer, and producer for messaging inside Records domain
+type RecordRouter struct {
+	rs.RConsumer
+	rs.RProducer
+	prs    stores.RecordStore
+	uss    stores.SettingsStore
+	routes map[string]func(amqp091.Delivery) responses.Response
+}
+
+// NewRecordRouter - Default method for creation RecordRouter, requires rs.Configurer to create channels
+// for consumer and producer and connection to database, which is shared by its stores
+func NewRecordRouter(configurer rs.Configurer, conn *sqlx.DB) *RecordRouter {
+	recordRouter := RecordRouter{}
+	recordRouter.CreateConsumer(configurer)
+	recordRouter.CreateProducer(configurer)
+	recordRouter.SetPRS(stores.NewPRS(conn))
+	recordRouter.SetUSS(stores.NewUSS(conn))
+	return &recordRouter
+}
+
+// CreateConsumer - helper method
+func (rr *RecordRouter) CreateConsumer(configurer rs.Configurer) {
+	rr.RConsumer = rs.RConsumer{}
+	err := rr.RConsumer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RConsumer for RecordRouter")
+	}
+}
+
+// CreateProducer - helper method
+func (rr *RecordRouter) CreateProducer(configurer rs.Configurer) {
+	rr.RProducer = rs.RProducer{}
+	err := rr.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for RecordRouter")
+	}
+}
+
+// SetPRS - Dependency injection of stores.RecordStore
+func (rr *RecordRouter) SetPRS(prs stores.RecordStore) {
+	rr.prs = prs
+}
+
+// SetUSS - Dependency injection of stores.SettingsStore
+func (rr *RecordRouter) SetUSS(uss stores.SettingsStore) {
+	rr.uss = uss
+}
+
+// Setup - main method, that sets up all routes and handlers for them
+func (rr *RecordRouter) Setup() {
+	rr.routes = make(map[string]func(amqp091.Delivery) responses.Response)
+	rr.routes["list"] = rr.handleList
+	q, err := rr.RConsumer.CreateQueue(durableQueue("trainings.records"))
+	if err != nil {
+		log.Fatal("error creating queue for records consumer")
+	}
+	err = rr.RConsumer.SetBinding(q, "trainings.records.#", EXCHANGE_NAME)
+	if err != nil {
+		log.Fatal("error creating binding for records consumer")
+	}
+	//creating dispatcher
+	dispatcher := newRouteDispatcher()
+	for path, f := range rr.routes {
+		dispatcher.RegisterHandler("trainings.records."+path, rs.NewHandlerFunc(func(msg amqp091.Delivery) {
+			process(rr.RProducer, msg, f, "tgbot.records."+path)
+		}))
+	}
+	rr.RConsumer.RegisterDispatcher(q, dispatcher, manualAck())
+}
+
+func (rr *RecordRouter) handleList(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	listQuery, err := converters.ParseListRecords(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to list records with user: %d, exercise: %d", listQuery.UserId, listQuery.ExerciseId))
+	var records []stores.PersonalRecord
+	if listQuery.ExerciseId == 0 {
+		records, err = rr.prs.FindByUser(listQuery.UserId)
+	} else {
+		records, err = rr.prs.FindByExercise(listQuery.UserId, listQuery.ExerciseId)
+	}
+	if err != nil {
+		return responses.Error(fmt.Errorf("error listing records: %w", err))
+	}
+	settings, err := rr.uss.Find(listQuery.UserId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error finding settings: %w", err))
+	}
+	return responses.Success(units.ToUser(records, settings))
+}
+
+// Stop - Closure for closing channels of consumer and producer
+func (rr RecordRouter) Stop() {
+	rr.RConsumer.Stop()
+	rr.RProducer.Stop()
+}
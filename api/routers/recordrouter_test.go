@@ -0,0 +1,92 @@
+package routers
+
+import (
+	"encoding/json"
+	"testing"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+func init() {
+	registerRouter(setupRecordRouter)
+}
+
+// setupRecordRouter - sets up RecordRouter with stub stores
+func setupRecordRouter(configurer rs.Configurer) stopper {
+	router := &RecordRouter{}
+	router.CreateConsumer(configurer)
+	router.CreateProducer(configurer)
+	router.SetPRS(stores.RecordStoreStub{})
+	router.SetUSS(stores.SettingsStoreStub{})
+	router.Setup()
+	return router
+}
+
+func TestListRecords(t *testing.T) {
+	data := []struct {
+		testName       string
+		message        string
+		expectedResult string
+		errMessage     string
+	}{
+		{
+			"Negative case: wrong input",
+			`{"exercise_id":1}`,
+			wrongInput,
+			"Error listing records, received: %v",
+		},
+		{
+			"Negative case: no records",
+			`{"user_id":1}`,
+			"ERROR: error listing records: sql: no rows in result set",
+			"Error listing records, received: %v",
+		},
+		{
+			"Positive case",
+			`{"user_id":2,"exercise_id":1}`,
+			"SUCCESS: [\n{\n\"id\": 1,\n\"user_id\": 2,\n\"exercise_id\": 1,\n\"kind\": \"max_weight\",\n\"weight\": 0,\n\"value\": 62.5,\n\"set_id\": 2,\n\"achieved_at\": \"0001-01-01T00:00:00Z\"\n},\n{\n\"id\": 2,\n\"user_id\": 2,\n\"exercise_id\": 1,\n\"kind\": \"max_reps\",\n\"weight\": 60,\n\"value\": 10,\n\"set_id\": 1,\n\"achieved_at\": \"0001-01-01T00:00:00Z\"\n}\n]",
+			"Error listing records, received: %v",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy("trainings.records.list", d.message)
+		})
+		body := <-clientConsumer.LastMessageCh
+		if body.RoutingKey != "tgbot.records.list" {
+			t.Errorf("error wrong result routing key")
+		}
+		received := string(body.Body)
+		if received != d.expectedResult {
+			t.Errorf(d.errMessage, received)
+		}
+	}
+}
+
+func TestNewRecordEvent(t *testing.T) {
+	publishLegacy("trainings.set.add", `{"user_id":2,"exercise_id":7,"weight":70,"reps":5}`)
+	//event is published before the answer
+	event := <-clientConsumer.LastMessageCh
+	if event.RoutingKey != NewRecordRoutingKey {
+		t.Fatalf("got wrong routing key of event: %s", event.RoutingKey)
+	}
+	decoded, err := responses.Decode(event.Body)
+	if err != nil {
+		t.Fatalf("error decoding event: %v", err)
+	}
+	var newRecord NewRecordEvent
+	err = json.Unmarshal(decoded.Data.(json.RawMessage), &newRecord)
+	if err != nil {
+		t.Fatalf("error decoding data of event: %v", err)
+	}
+	if newRecord.Event != "records.new" || newRecord.Record.Kind != stores.MaxWeight ||
+		newRecord.Record.Value != 70 || newRecord.Record.Previous != 60 {
+		t.Errorf("got wrong event: %#v", newRecord)
+	}
+	answer := <-clientConsumer.LastMessageCh
+	if answer.RoutingKey != "tgbot.set.add" || string(answer.Body) != "SUCCESS: id:1" {
+		t.Errorf("got wrong answer: %s %s", answer.RoutingKey, answer.Body)
+	}
+}
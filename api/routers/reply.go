@@ -0,0 +1,35 @@
+package routers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// reply - publishes response for request msg, if request has ReplyTo it is used as queue name for response,
+// otherwise response is published to EXCHANGE_NAME with routingKey. CorrelationId of request is always echoed
+func reply(producer rs.RProducer, msg amqp091.Delivery, response responses.Response, routingKey string) {
+	exchange := EXCHANGE_NAME
+	if msg.ReplyTo != "" {
+		exchange = ""
+		routingKey = msg.ReplyTo
+	}
+	err := producer.Ch.PublishWithContext(
+		context.Background(),
+		exchange,
+		routingKey,
+		false,
+		false,
+		amqp091.Publishing{
+			ContentType:   "application/json",
+			CorrelationId: msg.CorrelationId,
+			Body:          []byte(response.Encode(responses.IsLegacy(msg))),
+		})
+	if err != nil {
+		slog.Error(fmt.Sprintf("error sending response to %s: %v", routingKey, err))
+	}
+}
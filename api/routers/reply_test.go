@@ -0,0 +1,54 @@
+package routers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fridrock/trainingservice/api/utils/converters"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/client"
+)
+
+func TestCorrelatedCalls(t *testing.T) {
+	data := []struct {
+		testName     string
+		routingKey   string
+		request      converters.ExGroupPropeties
+		expectedCode responses.Code
+	}{
+		{"Negative case wrong input", "trainings.exgroup.find", converters.ExGroupPropeties{UserId: 2}, responses.Validation},
+		{"Negative case not found", "trainings.exgroup.find", converters.ExGroupPropeties{UserId: 2, Name: "Unexisting"}, responses.NotFound},
+		{"Negative case conflict", "trainings.training.finish", converters.ExGroupPropeties{UserId: 1}, responses.Conflict},
+		{"Positive case found", "trainings.exgroup.find", converters.ExGroupPropeties{UserId: 2, Name: "Back"}, responses.OK},
+		{"Positive case started", "trainings.training.start", converters.ExGroupPropeties{UserId: 2}, responses.OK},
+	}
+	//all calls are made at the same time, every one should get its own response
+	var wg sync.WaitGroup
+	for _, d := range data {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := rpcClient.Call(context.Background(), d.routingKey, d.request)
+			if err != nil {
+				t.Errorf("%s: error calling service: %v", d.testName, err)
+				return
+			}
+			if response.Code != d.expectedCode {
+				t.Errorf("%s: got response of other request: %#v", d.testName, response)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCallTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	//nobody listens to this routing key
+	_, err := rpcClient.Call(ctx, "trainings.unknown.route", converters.UserID{UserId: 2})
+	if err != client.Timeout {
+		t.Errorf("error waiting for unanswered call: %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package routers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	RETRY_EXCHANGE_NAME       = "sport_bot.retry"
+	DEAD_LETTER_EXCHANGE_NAME = "sport_bot.dead"
+	DEAD_LETTER_QUEUE_NAME    = "sport_bot.dead"
+	// AttemptHeader - header with amount of failed attempts to handle message
+	AttemptHeader = "x-attempt"
+	// ErrorHeader - header with description of the last error of dead-lettered message
+	ErrorHeader = "x-error"
+	// retryQueueHeader - header, by which retry exchange routes message to the delay queue
+	retryQueueHeader = "x-retry-queue"
+)
+
+// RetryPolicy - bounded retry policy with exponential delay between attempts
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+}
+
+// DefaultRetryPolicy - policy, that is used by routers if other wasn't set
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   time.Second,
+	}
+}
+
+var retryPolicy = DefaultRetryPolicy()
+
+// SetRetryPolicy - sets policy for all routers, must be called before SetupRetryTopology
+func SetRetryPolicy(policy RetryPolicy) {
+	retryPolicy = policy
+}
+
+// Delay - delay before next attempt, after attempt failed
+func (rp RetryPolicy) Delay(attempt int) time.Duration {
+	return rp.BaseDelay << attempt
+}
+
+// delayQueueName - name of queue, which holds messages waiting for next attempt
+func (rp RetryPolicy) delayQueueName(attempt int) string {
+	return fmt.Sprintf("%s.%d", RETRY_EXCHANGE_NAME, rp.Delay(attempt).Milliseconds())
+}
+
+// SetupRetryTopology - declares retry exchange with delay queues and dead letter exchange with queue.
+// Delay queues dead-letter expired messages back to EXCHANGE_NAME with their original routing key
+func SetupRetryTopology(configurer rs.Configurer) error {
+	ch, err := configurer.GetConnection().Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+	err = ch.ExchangeDeclare(EXCHANGE_NAME, "topic", true, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+	err = ch.ExchangeDeclare(RETRY_EXCHANGE_NAME, "headers", true, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+	for attempt := 0; attempt < retryPolicy.MaxAttempts-1; attempt++ {
+		name := retryPolicy.delayQueueName(attempt)
+		_, err = ch.QueueDeclare(name, true, false, false, false, amqp091.Table{
+			"x-message-ttl":          retryPolicy.Delay(attempt).Milliseconds(),
+			"x-dead-letter-exchange": EXCHANGE_NAME,
+		})
+		if err != nil {
+			return err
+		}
+		err = ch.QueueBind(name, "", RETRY_EXCHANGE_NAME, false, amqp091.Table{
+			"x-match":        "all",
+			retryQueueHeader: name,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	err = ch.ExchangeDeclare(DEAD_LETTER_EXCHANGE_NAME, "fanout", true, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+	_, err = ch.QueueDeclare(DEAD_LETTER_QUEUE_NAME, true, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+	return ch.QueueBind(DEAD_LETTER_QUEUE_NAME, "", DEAD_LETTER_EXCHANGE_NAME, false, nil)
+}
+
+// manualAck - consumer config, that is used by routers, messages are acknowledged by process
+func manualAck() rs.ConsumerConfig {
+	config := rs.DefaultConsumerConfig()
+	config.AutoAck = false
+	return config
+}
+
+// attemptOf - amount of failed attempts to handle message
+func attemptOf(msg amqp091.Delivery) int {
+	switch attempt := msg.Headers[AttemptHeader].(type) {
+	case int32:
+		return int(attempt)
+	case int64:
+		return int(attempt)
+	case int:
+		return attempt
+	}
+	return 0
+}
+
+// durableQueue - config of named durable queue of router, so requests published while service is restarted
+// aren't lost
+func durableQueue(name string) rs.QueueConfig {
+	config := rs.DefaultQueueConfig()
+	config.Name = name
+	config.Durable = true
+	config.AutoDelete = false
+	return config
+}
+
+// process - handles message and acknowledges it. Messages, that failed with transient error, are sent
+// to retry exchange until policy allows it, then they are sent to dead letter exchange and answered with error.
+// Other errors are answered immediately, as next attempt fails the same way
+func process(producer rs.RProducer, msg amqp091.Delivery, handle func(amqp091.Delivery) responses.Response, routingKey string) {
+	response := safeHandle(handle, msg)
+	if response.Transient {
+		attempt := attemptOf(msg)
+		var err error
+		if attempt+1 < retryPolicy.MaxAttempts {
+			slog.Warn(fmt.Sprintf("retrying %s, attempt %d: %s", msg.RoutingKey, attempt+1, response.Message))
+			err = republish(producer, msg, RETRY_EXCHANGE_NAME, amqp091.Table{
+				AttemptHeader:    int32(attempt + 1),
+				retryQueueHeader: retryPolicy.delayQueueName(attempt),
+			})
+			if err == nil {
+				msg.Ack(false)
+				return
+			}
+		} else {
+			slog.Error(fmt.Sprintf("dead-lettering %s after %d attempts: %s", msg.RoutingKey, attempt+1, response.Message))
+			err = republish(producer, msg, DEAD_LETTER_EXCHANGE_NAME, amqp091.Table{
+				AttemptHeader: int32(attempt + 1),
+				ErrorHeader:   response.Message,
+			})
+		}
+		if err != nil {
+			slog.Error(fmt.Sprintf("error republishing %s: %v", msg.RoutingKey, err))
+			msg.Nack(false, true)
+			return
+		}
+	}
+	reply(producer, msg, response, routingKey)
+	msg.Ack(false)
+}
+
+// safeHandle - converts panic of handler to internal error
+func safeHandle(handle func(amqp091.Delivery) responses.Response, msg amqp091.Delivery) (response responses.Response) {
+	defer func() {
+		if r := recover(); r != nil {
+			response = responses.Error(fmt.Errorf("internal server error: %v", r))
+		}
+	}()
+	return handle(msg)
+}
+
+// republish - publishes copy of message with additional headers to exchange, keeping its routing key
+func republish(producer rs.RProducer, msg amqp091.Delivery, exchange string, headers amqp091.Table) error {
+	merged := amqp091.Table{}
+	for k, v := range msg.Headers {
+		merged[k] = v
+	}
+	for k, v := range headers {
+		merged[k] = v
+	}
+	return producer.Ch.PublishWithContext(
+		context.Background(),
+		exchange,
+		msg.RoutingKey,
+		false,
+		false,
+		amqp091.Publishing{
+			ContentType:   msg.ContentType,
+			DeliveryMode:  amqp091.Persistent,
+			CorrelationId: msg.CorrelationId,
+			ReplyTo:       msg.ReplyTo,
+			MessageId:     msg.MessageId,
+			Headers:       merged,
+			Body:          msg.Body,
+		})
+}
+
+// routeDispatcher - same as rs.RDispatcher, but rejects messages with unknown routing key,
+// so they don't stay unacknowledged
+type routeDispatcher struct {
+	handlers map[string]rs.Handler
+}
+
+func newRouteDispatcher() routeDispatcher {
+	return routeDispatcher{
+		handlers: make(map[string]rs.Handler),
+	}
+}
+
+func (rd *routeDispatcher) RegisterHandler(routingKey string, h rs.Handler) {
+	rd.handlers[routingKey] = h
+}
+
+func (rd routeDispatcher) Dispatch(msgs <-chan amqp091.Delivery) {
+	for d := range msgs {
+		h, ok := rd.handlers[d.RoutingKey]
+		if !ok {
+			slog.Error(fmt.Sprintf("unknown routing key: %s", d.RoutingKey))
+			d.Reject(false)
+			continue
+		}
+		go h.Handle(d)
+	}
+}
@@ -0,0 +1,59 @@
+package routers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 4, BaseDelay: time.Second}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+	for attempt, delay := range expected {
+		if policy.Delay(attempt) != delay {
+			t.Errorf("got wrong delay for attempt %d: %v", attempt, policy.Delay(attempt))
+		}
+	}
+}
+
+func TestAttemptOf(t *testing.T) {
+	if attemptOf(amqp091.Delivery{}) != 0 {
+		t.Error("message without header has attempts")
+	}
+	if attemptOf(amqp091.Delivery{Headers: amqp091.Table{AttemptHeader: int32(2)}}) != 2 {
+		t.Error("got wrong amount of attempts")
+	}
+}
+
+func TestRetryAndDeadLetter(t *testing.T) {
+	publishLegacy("trainings.exgroup.create", `{"user_id":1,"name":"Internal"}`)
+	//error is answered only after all attempts
+	received := string((<-clientConsumer.LastMessageCh).Body)
+	if received != "ERROR: internal server error: driver: bad connection" {
+		t.Errorf("got wrong response after retries: %s", received)
+	}
+	dead, ok, err := clientProducer.Ch.Get(DEAD_LETTER_QUEUE_NAME, true)
+	if err != nil || !ok {
+		t.Fatalf("message wasn't dead-lettered: %v", err)
+	}
+	if attemptOf(dead) != retryPolicy.MaxAttempts {
+		t.Errorf("got wrong attempt header: %v", dead.Headers[AttemptHeader])
+	}
+	if dead.RoutingKey != "trainings.exgroup.create" {
+		t.Errorf("dead-lettered message lost routing key: %s", dead.RoutingKey)
+	}
+}
+
+func TestNoRetryOfPermanentError(t *testing.T) {
+	publishLegacy("trainings.exgroup.create", `{"user_id":1,"name":"Invalid"}`)
+	//error is answered after first attempt
+	received := string((<-clientConsumer.LastMessageCh).Body)
+	if received != "ERROR: internal server error: pq: violates check constraint" {
+		t.Errorf("got wrong response: %s", received)
+	}
+	_, ok, err := clientProducer.Ch.Get(DEAD_LETTER_QUEUE_NAME, true)
+	if err != nil || ok {
+		t.Errorf("message with permanent error was dead-lettered: %v", err)
+	}
+}
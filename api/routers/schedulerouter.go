@@ -0,0 +1,134 @@
+package routers
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/converters"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// ScheduleRouter - structure, that contains both consumer, and producer for messaging inside Schedule domain
+type ScheduleRouter struct {
+	rs.RConsumer
+	rs.RProducer
+	scs    stores.ScheduleStore
+	pms    stores.ProcessedMessageStore
+	routes map[string]func(amqp091.Delivery) responses.Response
+}
+
+// NewScheduleRouter - Default method for creation ScheduleRouter, requires rs.Configurer to create channels
+// for consumer and producer and connection to database, which is shared by its stores
+func NewScheduleRouter(configurer rs.Configurer, conn *sqlx.DB) *ScheduleRouter {
+	scheduleRouter := ScheduleRouter{}
+	scheduleRouter.CreateConsumer(configurer)
+	scheduleRouter.CreateProducer(configurer)
+	scheduleRouter.SetSCS(stores.NewSCS(conn))
+	scheduleRouter.SetPMS(stores.NewPMS(conn))
+	return &scheduleRouter
+}
+
+// CreateConsumer - helper method
+func (sr *ScheduleRouter) CreateConsumer(configurer rs.Configurer) {
+	sr.RConsumer = rs.RConsumer{}
+	err := sr.RConsumer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RConsumer for ScheduleRouter")
+	}
+}
+
+// CreateProducer - helper method
+func (sr *ScheduleRouter) CreateProducer(configurer rs.Configurer) {
+	sr.RProducer = rs.RProducer{}
+	err := sr.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for ScheduleRouter")
+	}
+}
+
+// SetSCS - Dependency injection of stores.ScheduleStore
+func (sr *ScheduleRouter) SetSCS(scs stores.ScheduleStore) {
+	sr.scs = scs
+}
+
+// SetPMS - Dependency injection of stores.ProcessedMessageStore
+func (sr *ScheduleRouter) SetPMS(pms stores.ProcessedMessageStore) {
+	sr.pms = pms
+}
+
+// Setup - main method, that sets up all routes and handlers for them
+func (sr *ScheduleRouter) Setup() {
+	sr.routes = make(map[string]func(amqp091.Delivery) responses.Response)
+	sr.routes["set"] = idempotent(sr.pms, sr.handleSet)
+	sr.routes["get"] = sr.handleGet
+	sr.routes["delete"] = idempotent(sr.pms, sr.handleDelete)
+	q, err := sr.RConsumer.CreateQueue(durableQueue("trainings.schedule"))
+	if err != nil {
+		log.Fatal("error creating queue for schedule consumer")
+	}
+	err = sr.RConsumer.SetBinding(q, "trainings.schedule.#", EXCHANGE_NAME)
+	if err != nil {
+		log.Fatal("error creating binding for schedule consumer")
+	}
+	//creating dispatcher
+	dispatcher := newRouteDispatcher()
+	for path, f := range sr.routes {
+		dispatcher.RegisterHandler("trainings.schedule."+path, rs.NewHandlerFunc(func(msg amqp091.Delivery) {
+			process(sr.RProducer, msg, f, "tgbot.schedule."+path)
+		}))
+	}
+	sr.RConsumer.RegisterDispatcher(q, dispatcher, manualAck())
+}
+
+func (sr *ScheduleRouter) handleSet(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	schedule, err := converters.FromJsonToSchedule(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to set schedule: %#v", schedule))
+	err = sr.scs.Set(schedule)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error setting schedule: %w", err))
+	}
+	return responses.Success(nil)
+}
+
+func (sr *ScheduleRouter) handleGet(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	userId, err := converters.ParseUserID(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to get schedule with user: %d", userId))
+	schedule, err := sr.scs.FindByUser(userId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting schedule: %w", err))
+	}
+	return responses.Success(schedule)
+}
+
+func (sr *ScheduleRouter) handleDelete(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	userId, err := converters.ParseUserID(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to delete schedule with user: %d", userId))
+	err = sr.scs.DeleteByUser(userId)
+	if err != nil {
+		return responses.Error(err)
+	}
+	return responses.Success(nil)
+}
+
+// Stop - Closure for closing channels of consumer and producer
+func (sr ScheduleRouter) Stop() {
+	sr.RConsumer.Stop()
+	sr.RProducer.Stop()
+}
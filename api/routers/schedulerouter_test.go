@@ -0,0 +1,83 @@
+package routers
+
+import (
+	"strings"
+	"testing"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+func init() {
+	registerRouter(setupScheduleRouter)
+}
+
+// setupScheduleRouter - sets up ScheduleRouter with stub stores
+func setupScheduleRouter(configurer rs.Configurer) stopper {
+	router := &ScheduleRouter{}
+	router.CreateConsumer(configurer)
+	router.CreateProducer(configurer)
+	router.SetSCS(stores.ScheduleStoreStub{})
+	router.SetPMS(stores.NewPMSStub())
+	router.Setup()
+	return router
+}
+
+func TestScheduleRoutes(t *testing.T) {
+	data := []struct {
+		testName       string
+		routingKey     string
+		message        string
+		expectedResult string
+	}{
+		{
+			"Negative case: set with unknown weekday",
+			"trainings.schedule.set",
+			`{"user_id":2,"slots":[{"weekday":"funday","at":"18:00"}]}`,
+			wrongInput,
+		},
+		{
+			"Positive case: set",
+			"trainings.schedule.set",
+			`{"user_id":2,"timezone":"Europe/Moscow","slots":[{"weekday":"mon","at":"18:00"},{"weekday":"wed","at":"18:00"}]}`,
+			success,
+		},
+		{
+			"Negative case: get missing schedule",
+			"trainings.schedule.get",
+			`{"user_id":1}`,
+			"ERROR: error getting schedule: sql: no rows in result set",
+		},
+		{
+			"Positive case: get",
+			"trainings.schedule.get",
+			`{"user_id":2}`,
+			`SUCCESS: {"user_id":2,"timezone":"Europe/Moscow","slots":[{"id":1,"weekday":"mon","at":"18:00"},{"id":2,"weekday":"wed","at":"18:00"}]}`,
+		},
+		{
+			"Negative case: delete missing schedule",
+			"trainings.schedule.delete",
+			`{"user_id":1}`,
+			notDeleted,
+		},
+		{
+			"Positive case: delete",
+			"trainings.schedule.delete",
+			`{"user_id":2}`,
+			success,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy(d.routingKey, d.message)
+			body := <-clientConsumer.LastMessageCh
+			if body.RoutingKey != "tgbot"+strings.TrimPrefix(d.routingKey, "trainings") {
+				t.Errorf("error wrong result routing key: %s", body.RoutingKey)
+			}
+			received := string(body.Body)
+			if received != d.expectedResult {
+				t.Errorf("Error handling schedule, received: %v", received)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package routers
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"time"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/converters"
+	"github.com/fridrock/trainingservice/api/utils/reminder"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// SettingsRouter - structure, that contains both consumer, and producer for messaging inside Settings domain
+type SettingsRouter struct {
+	rs.RConsumer
+	rs.RProducer
+	uss    stores.SettingsStore
+	pms    stores.ProcessedMessageStore
+	routes map[string]func(amqp091.Delivery) responses.Response
+}
+
+// NewSettingsRouter - Default method for creation SettingsRouter, requires rs.Configurer to create channels
+// for consumer and producer and connection to database, which is shared by its stores
+func NewSettingsRouter(configurer rs.Configurer, conn *sqlx.DB) *SettingsRouter {
+	settingsRouter := SettingsRouter{}
+	settingsRouter.CreateConsumer(configurer)
+	settingsRouter.CreateProducer(configurer)
+	settingsRouter.SetUSS(stores.NewUSS(conn))
+	settingsRouter.SetPMS(stores.NewPMS(conn))
+	return &settingsRouter
+}
+
+// CreateConsumer - helper method
+func (sr *SettingsRouter) CreateConsumer(configurer rs.Configurer) {
+	sr.RConsumer = rs.RConsumer{}
+	err := sr.RConsumer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RConsumer for SettingsRouter")
+	}
+}
+
+// CreateProducer - helper method
+func (sr *SettingsRouter) CreateProducer(configurer rs.Configurer) {
+	sr.RProducer = rs.RProducer{}
+	err := sr.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for SettingsRouter")
+	}
+}
+
+// SetUSS - Dependency injection of stores.SettingsStore
+func (sr *SettingsRouter) SetUSS(uss stores.SettingsStore) {
+	sr.uss = uss
+}
+
+// SetPMS - Dependency injection of stores.ProcessedMessageStore
+func (sr *SettingsRouter) SetPMS(pms stores.ProcessedMessageStore) {
+	sr.pms = pms
+}
+
+// Setup - main method, that sets up all routes and handlers for them
+func (sr *SettingsRouter) Setup() {
+	sr.routes = make(map[string]func(amqp091.Delivery) responses.Response)
+	sr.routes["get"] = sr.handleGet
+	sr.routes["set"] = idempotent(sr.pms, sr.handleSet)
+	q, err := sr.RConsumer.CreateQueue(durableQueue("trainings.settings"))
+	if err != nil {
+		log.Fatal("error creating queue for settings consumer")
+	}
+	err = sr.RConsumer.SetBinding(q, "trainings.settings.#", EXCHANGE_NAME)
+	if err != nil {
+		log.Fatal("error creating binding for settings consumer")
+	}
+	//creating dispatcher
+	dispatcher := newRouteDispatcher()
+	for path, f := range sr.routes {
+		dispatcher.RegisterHandler("trainings.settings."+path, rs.NewHandlerFunc(func(msg amqp091.Delivery) {
+			process(sr.RProducer, msg, f, "tgbot.settings."+path)
+		}))
+	}
+	sr.RConsumer.RegisterDispatcher(q, dispatcher, manualAck())
+}
+
+func (sr *SettingsRouter) handleGet(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	userId, err := converters.ParseUserID(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to get settings with user: %d", userId))
+	settings, err := sr.uss.Find(userId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting settings: %w", err))
+	}
+	return responses.Success(settings)
+}
+
+func (sr *SettingsRouter) handleSet(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	userId, err := converters.ParseUserID(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	current, err := sr.uss.Find(userId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error setting settings: %w", err))
+	}
+	settings, err := converters.FromJsonToSettings(body, current)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to set settings: %#v", settings))
+	err = sr.uss.Save(settings)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error setting settings: %w", err))
+	}
+	return responses.Success(nil)
+}
+
+// Stop - Closure for closing channels of consumer and producer
+func (sr SettingsRouter) Stop() {
+	sr.RConsumer.Stop()
+	sr.RProducer.Stop()
+}
+
+// userSettings - settings of user together with location of their timezone, in which dates of user are evaluated
+func userSettings(uss stores.SettingsStore, userId int64) (stores.Settings, *time.Location, error) {
+	settings, err := uss.Find(userId)
+	if err != nil {
+		return settings, nil, err
+	}
+	loc, err := reminder.LoadLocation(settings.Timezone)
+	return settings, loc, err
+}
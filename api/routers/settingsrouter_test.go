@@ -0,0 +1,101 @@
+package routers
+
+import (
+	"strings"
+	"testing"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+func init() {
+	registerRouter(setupSettingsRouter)
+}
+
+// setupSettingsRouter - sets up SettingsRouter with stub stores
+func setupSettingsRouter(configurer rs.Configurer) stopper {
+	router := &SettingsRouter{}
+	router.CreateConsumer(configurer)
+	router.CreateProducer(configurer)
+	router.SetUSS(stores.SettingsStoreStub{})
+	router.SetPMS(stores.NewPMSStub())
+	router.Setup()
+	return router
+}
+
+func TestSettingsRoutes(t *testing.T) {
+	data := []struct {
+		testName       string
+		routingKey     string
+		message        string
+		expectedResult string
+	}{
+		{
+			"Negative case: set unknown timezone",
+			"trainings.settings.set",
+			`{"user_id":3,"timezone":"Moscow"}`,
+			wrongInput,
+		},
+		{
+			"Positive case: set",
+			"trainings.settings.set",
+			`{"user_id":3,"timezone":"Europe/Moscow"}`,
+			success,
+		},
+		{
+			"Negative case: set unknown weight unit",
+			"trainings.settings.set",
+			`{"user_id":3,"weight_unit":"stone"}`,
+			wrongInput,
+		},
+		{
+			"Negative case: set negative default rest",
+			"trainings.settings.set",
+			`{"user_id":3,"default_rest":-60}`,
+			wrongInput,
+		},
+		{
+			"Negative case: set unknown week start",
+			"trainings.settings.set",
+			`{"user_id":3,"week_start":"someday"}`,
+			wrongInput,
+		},
+		{
+			"Positive case: set part of settings",
+			"trainings.settings.set",
+			`{"user_id":5,"weight_unit":"lb","default_rest":90}`,
+			success,
+		},
+		{
+			"Positive case: get",
+			"trainings.settings.get",
+			`{"user_id":3}`,
+			`SUCCESS: {"user_id":3,"weight_unit":"kg","distance_unit":"km","timezone":"Europe/Moscow","default_rest":0,"week_start":"mon"}`,
+		},
+		{
+			"Positive case: get default",
+			"trainings.settings.get",
+			`{"user_id":2}`,
+			`SUCCESS: {"user_id":2,"weight_unit":"kg","distance_unit":"km","timezone":"UTC","default_rest":0,"week_start":"mon"}`,
+		},
+		{
+			"Positive case: get imperial",
+			"trainings.settings.get",
+			`{"user_id":5}`,
+			`SUCCESS: {"user_id":5,"weight_unit":"lb","distance_unit":"mi","timezone":"UTC","default_rest":0,"week_start":"sun"}`,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy(d.routingKey, d.message)
+			body := <-clientConsumer.LastMessageCh
+			if body.RoutingKey != "tgbot"+strings.TrimPrefix(d.routingKey, "trainings") {
+				t.Errorf("error wrong result routing key: %s", body.RoutingKey)
+			}
+			received := string(body.Body)
+			if received != d.expectedResult {
+				t.Errorf("Error handling settings, received: %v", received)
+			}
+		})
+	}
+}
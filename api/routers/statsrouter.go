@@ -0,0 +1,111 @@
+package routers
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/converters"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/api/utils/units"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// StatsRouter - structure, that contains both consumer, and producer for messaging inside Stats domain
+type StatsRouter struct {
+	rs.RConsumer
+	rs.RProducer
+	sts    stores.StatsStore
+	uss    stores.SettingsStore
+	routes map[string]func(amqp091.Delivery) responses.Response
+}
+
+// NewStatsRouter - Default method for creation StatsRouter, requires rs.Configurer to create channels
+// for consumer and producer and connection to database, which is shared by its stores
+func NewStatsRouter(configurer rs.Configurer, conn *sqlx.DB) *StatsRouter {
+	statsRouter := StatsRouter{}
+	statsRouter.CreateConsumer(configurer)
+	statsRouter.CreateProducer(configurer)
+	statsRouter.SetSTS(stores.NewSTS(conn))
+	statsRouter.SetUSS(stores.NewUSS(conn))
+	return &statsRouter
+}
+
+// CreateConsumer - helper method
+func (str *StatsRouter) CreateConsumer(configurer rs.Configurer) {
+	str.RConsumer = rs.RConsumer{}
+	err := str.RConsumer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RConsumer for StatsRouter")
+	}
+}
+
+// CreateProducer - helper method
+func (str *StatsRouter) CreateProducer(configurer rs.Configurer) {
+	str.RProducer = rs.RProducer{}
+	err := str.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for StatsRouter")
+	}
+}
+
+// SetSTS - Dependency injection of stores.StatsStore
+func (str *StatsRouter) SetSTS(sts stores.StatsStore) {
+	str.sts = sts
+}
+
+// SetUSS - Dependency injection of stores.SettingsStore
+func (str *StatsRouter) SetUSS(uss stores.SettingsStore) {
+	str.uss = uss
+}
+
+// Setup - main method, that sets up all routes and handlers for them
+func (str *StatsRouter) Setup() {
+	str.routes = make(map[string]func(amqp091.Delivery) responses.Response)
+	str.routes["summary"] = str.handleSummary
+	q, err := str.RConsumer.CreateQueue(durableQueue("trainings.stats"))
+	if err != nil {
+		log.Fatal("error creating queue for stats consumer")
+	}
+	err = str.RConsumer.SetBinding(q, "trainings.stats.#", EXCHANGE_NAME)
+	if err != nil {
+		log.Fatal("error creating binding for stats consumer")
+	}
+	//creating dispatcher
+	dispatcher := newRouteDispatcher()
+	for path, f := range str.routes {
+		dispatcher.RegisterHandler("trainings.stats."+path, rs.NewHandlerFunc(func(msg amqp091.Delivery) {
+			process(str.RProducer, msg, f, "tgbot.stats."+path)
+		}))
+	}
+	str.RConsumer.RegisterDispatcher(q, dispatcher, manualAck())
+}
+
+func (str *StatsRouter) handleSummary(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	statsRange, err := converters.ParseStatsRange(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to get summary with user: %d, from: %v, to: %v",
+		statsRange.UserId, statsRange.From, statsRange.To))
+	settings, loc, err := userSettings(str.uss, statsRange.UserId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting summary: %w", err))
+	}
+	statsRange = statsRange.In(loc)
+	summary, err := str.sts.Summary(statsRange.UserId, statsRange.From, statsRange.To)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error getting summary: %w", err))
+	}
+	return responses.Success(units.ToUser(summary, settings))
+}
+
+// Stop - Closure for closing channels of consumer and producer
+func (str StatsRouter) Stop() {
+	str.RConsumer.Stop()
+	str.RProducer.Stop()
+}
@@ -0,0 +1,76 @@
+package routers
+
+import (
+	"testing"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+func init() {
+	registerRouter(setupStatsRouter)
+}
+
+// setupStatsRouter - sets up StatsRouter with stub stores
+func setupStatsRouter(configurer rs.Configurer) stopper {
+	router := &StatsRouter{}
+	router.CreateConsumer(configurer)
+	router.CreateProducer(configurer)
+	router.SetSTS(stores.StatsStoreStub{})
+	router.SetUSS(stores.SettingsStoreStub{})
+	router.Setup()
+	return router
+}
+
+func TestStatsSummary(t *testing.T) {
+	data := []struct {
+		testName       string
+		message        string
+		expectedResult string
+		errMessage     string
+	}{
+		{
+			"Negative case: wrong input",
+			`{"user_id":2,"from":"2024-06-30","to":"2024-06-01"}`,
+			wrongInput,
+			"Error getting summary, received: %v",
+		},
+		{
+			"Positive case: no trainings",
+			`{"user_id":1,"from":"2024-06-01","to":"2024-06-30"}`,
+			`SUCCESS: {"user_id":1,"from":"2024-06-01T00:00:00Z","to":"2024-07-01T00:00:00Z","sessions":0,"total_duration":0,"average_duration":0,"training_days":0,"days_per_week":0,"longest_streak":0,"cardio":{"sessions":0,"distance":0,"duration":0,"elevation":0,"calories":0,"avg_heart_rate":0,"max_heart_rate":0,"pace":0,"speed":0}}`,
+			"Error getting summary, received: %v",
+		},
+		{
+			"Positive case",
+			`{"user_id":2,"from":"2024-06-01","to":"2024-06-30"}`,
+			`SUCCESS: {"user_id":2,"from":"2024-06-01T00:00:00Z","to":"2024-07-01T00:00:00Z","sessions":12,"total_duration":43200,"average_duration":3600,"training_days":12,"days_per_week":2.8,"longest_streak":3,"cardio":{"sessions":4,"distance":20,"duration":6000,"elevation":120,"calories":1400,"avg_heart_rate":148,"max_heart_rate":176,"pace":300,"speed":12}}`,
+			"Error getting summary, received: %v",
+		},
+		{
+			"Positive case: dates in timezone of user",
+			`{"user_id":3,"from":"2024-06-01","to":"2024-06-30"}`,
+			`SUCCESS: {"user_id":3,"from":"2024-06-01T00:00:00+03:00","to":"2024-07-01T00:00:00+03:00","sessions":12,"total_duration":43200,"average_duration":3600,"training_days":12,"days_per_week":2.8,"longest_streak":3,"cardio":{"sessions":4,"distance":20,"duration":6000,"elevation":120,"calories":1400,"avg_heart_rate":148,"max_heart_rate":176,"pace":300,"speed":12}}`,
+			"Error getting summary, received: %v",
+		},
+		{
+			"Positive case: cardio in miles",
+			`{"user_id":5,"from":"2024-06-01","to":"2024-06-30"}`,
+			`SUCCESS: {"user_id":5,"from":"2024-06-01T00:00:00Z","to":"2024-07-01T00:00:00Z","sessions":12,"total_duration":43200,"average_duration":3600,"training_days":12,"days_per_week":2.8,"longest_streak":3,"cardio":{"sessions":4,"distance":12.43,"duration":6000,"elevation":120,"calories":1400,"avg_heart_rate":148,"max_heart_rate":176,"pace":482.8,"speed":7.46}}`,
+			"Error getting summary, received: %v",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy("trainings.stats.summary", d.message)
+		})
+		body := <-clientConsumer.LastMessageCh
+		if body.RoutingKey != "tgbot.stats.summary" {
+			t.Errorf("error wrong result routing key")
+		}
+		received := string(body.Body)
+		if received != d.expectedResult {
+			t.Errorf(d.errMessage, received)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package routers
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/converters"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/api/utils/suggest"
+	"github.com/fridrock/trainingservice/api/utils/units"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// SuggestRouter - structure, that contains both consumer, and producer for messaging inside Suggest domain
+type SuggestRouter struct {
+	rs.RConsumer
+	rs.RProducer
+	ess    stores.ExerciseSetStore
+	uss    stores.SettingsStore
+	routes map[string]func(amqp091.Delivery) responses.Response
+}
+
+// NewSuggestRouter - Default method for creation SuggestRouter, requires rs.Configurer to create channels
+// for consumer and producer and connection to database, which is shared by its stores
+func NewSuggestRouter(configurer rs.Configurer, conn *sqlx.DB) *SuggestRouter {
+	suggestRouter := SuggestRouter{}
+	suggestRouter.CreateConsumer(configurer)
+	suggestRouter.CreateProducer(configurer)
+	suggestRouter.SetESS(stores.NewESS(conn))
+	suggestRouter.SetUSS(stores.NewUSS(conn))
+	return &suggestRouter
+}
+
+// CreateConsumer - helper method
+func (sr *SuggestRouter) CreateConsumer(configurer rs.Configurer) {
+	sr.RConsumer = rs.RConsumer{}
+	err := sr.RConsumer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RConsumer for SuggestRouter")
+	}
+}
+
+// CreateProducer - helper method
+func (sr *SuggestRouter) CreateProducer(configurer rs.Configurer) {
+	sr.RProducer = rs.RProducer{}
+	err := sr.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for SuggestRouter")
+	}
+}
+
+// SetESS - Dependency injection of stores.ExerciseSetStore
+func (sr *SuggestRouter) SetESS(ess stores.ExerciseSetStore) {
+	sr.ess = ess
+}
+
+// SetUSS - Dependency injection of stores.SettingsStore
+func (sr *SuggestRouter) SetUSS(uss stores.SettingsStore) {
+	sr.uss = uss
+}
+
+// Setup - main method, that sets up all routes and handlers for them
+func (sr *SuggestRouter) Setup() {
+	sr.routes = make(map[string]func(amqp091.Delivery) responses.Response)
+	sr.routes["next"] = sr.handleNext
+	q, err := sr.RConsumer.CreateQueue(durableQueue("trainings.suggest"))
+	if err != nil {
+		log.Fatal("error creating queue for suggest consumer")
+	}
+	err = sr.RConsumer.SetBinding(q, "trainings.suggest.#", EXCHANGE_NAME)
+	if err != nil {
+		log.Fatal("error creating binding for suggest consumer")
+	}
+	//creating dispatcher
+	dispatcher := newRouteDispatcher()
+	for path, f := range sr.routes {
+		dispatcher.RegisterHandler("trainings.suggest."+path, rs.NewHandlerFunc(func(msg amqp091.Delivery) {
+			process(sr.RProducer, msg, f, "tgbot.suggest."+path)
+		}))
+	}
+	sr.RConsumer.RegisterDispatcher(q, dispatcher, manualAck())
+}
+
+func (sr *SuggestRouter) handleNext(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	query, err := converters.ParseSuggestQuery(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to suggest next set with user: %d, exercise: %d, strategy: %s",
+		query.UserId, query.ExerciseId, query.Strategy))
+	sets, err := sr.ess.FindLastSessions(query.UserId, query.ExerciseId, query.Sessions)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error suggesting next set: %w", err))
+	}
+	settings, err := sr.uss.Find(query.UserId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error finding settings: %w", err))
+	}
+	//default increment of strategies is in kilograms, so it is replaced with increment in unit of user
+	if query.Increment == 0 {
+		query.Increment = units.DefaultIncrement(settings)
+	}
+	config := units.FromUser(query.Config, settings)
+	suggestion, err := suggest.Next(query.Strategy, config, toSessions(sets))
+	if err != nil {
+		return responses.Error(fmt.Errorf("error suggesting next set: %w", err))
+	}
+	return responses.Success(units.ToUser(suggestion, settings))
+}
+
+// toSessions - groups sets, which are ordered by training, into sessions of suggest package
+func toSessions(sets []stores.ExerciseSet) []suggest.Session {
+	var sessions []suggest.Session
+	for _, set := range sets {
+		last := len(sessions) - 1
+		if last < 0 || sessions[last].TrainingId != set.TrainingId {
+			sessions = append(sessions, suggest.Session{TrainingId: set.TrainingId, Date: set.CreatedAt})
+			last++
+		}
+		sessions[last].Sets = append(sessions[last].Sets, suggest.Set{
+			Weight: set.Weight,
+			Reps:   set.Reps,
+			Rpe:    set.Rpe,
+		})
+	}
+	return sessions
+}
+
+// Stop - Closure for closing channels of consumer and producer
+func (sr SuggestRouter) Stop() {
+	sr.RConsumer.Stop()
+	sr.RProducer.Stop()
+}
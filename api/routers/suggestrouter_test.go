@@ -0,0 +1,67 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/api/utils/suggest"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+func init() {
+	registerRouter(setupSuggestRouter)
+}
+
+// setupSuggestRouter - sets up SuggestRouter with stub stores
+func setupSuggestRouter(configurer rs.Configurer) stopper {
+	router := &SuggestRouter{}
+	router.CreateConsumer(configurer)
+	router.CreateProducer(configurer)
+	router.SetESS(stores.ExerciseSetStoreStub{})
+	router.SetUSS(stores.SettingsStoreStub{})
+	router.Setup()
+	return router
+}
+
+// callSuggest - calls suggest route and decodes suggestion of successful response
+func callSuggest(t *testing.T, request string) (responses.Response, suggest.Suggestion) {
+	var suggestion suggest.Suggestion
+	d, err := rpcClient.CallRaw(context.Background(), "trainings.suggest.next", []byte(request))
+	if err != nil {
+		t.Fatalf("error calling next: %v", err)
+	}
+	response, err := responses.Decode(d.Body)
+	if err != nil {
+		t.Fatalf("error decoding response of next: %v", err)
+	}
+	if response.Status == responses.StatusSuccess {
+		err = json.Unmarshal(response.Data.(json.RawMessage), &suggestion)
+		if err != nil {
+			t.Fatalf("error decoding data of next: %v", err)
+		}
+	}
+	return response, suggestion
+}
+
+func TestSuggestNext(t *testing.T) {
+	response, _ := callSuggest(t, `{"user_id":2,"exercise_id":1,"strategy":"unknown"}`)
+	if response.Code != responses.Validation {
+		t.Errorf("got wrong code with unknown strategy: %s", response.Code)
+	}
+	response, _ = callSuggest(t, `{"user_id":2,"exercise_id":2}`)
+	if response.Code != responses.NotFound {
+		t.Errorf("got wrong code without history: %s", response.Code)
+	}
+	//last session reached top of rep range
+	_, suggestion := callSuggest(t, `{"user_id":2,"exercise_id":1}`)
+	if suggestion.Strategy != suggest.DefaultStrategy || suggestion.Weight != 65 || suggestion.Reps != 8 {
+		t.Errorf("got wrong double progression suggestion: %#v", suggestion)
+	}
+	_, suggestion = callSuggest(t, `{"user_id":2,"exercise_id":1,"strategy":"rpe","target_rpe":10}`)
+	if suggestion.Strategy != "rpe" || suggestion.Weight != 67.5 || suggestion.Reps != 12 {
+		t.Errorf("got wrong rpe suggestion: %#v", suggestion)
+	}
+}
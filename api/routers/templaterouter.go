@@ -0,0 +1,188 @@
+package routers
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/converters"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/api/utils/units"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// TemplateRouter - structure, that contains both consumer, and producer for messaging inside Template domain
+type TemplateRouter struct {
+	rs.RConsumer
+	rs.RProducer
+	tps    stores.TemplateStore
+	uss    stores.SettingsStore
+	pms    stores.ProcessedMessageStore
+	routes map[string]func(amqp091.Delivery) responses.Response
+}
+
+// NewTemplateRouter - Default method for creation TemplateRouter, requires rs.Configurer to create channels
+// for consumer and producer and connection to database, which is shared by its stores
+func NewTemplateRouter(configurer rs.Configurer, conn *sqlx.DB) *TemplateRouter {
+	templateRouter := TemplateRouter{}
+	templateRouter.CreateConsumer(configurer)
+	templateRouter.CreateProducer(configurer)
+	templateRouter.SetTPS(stores.NewTPS(conn))
+	templateRouter.SetUSS(stores.NewUSS(conn))
+	templateRouter.SetPMS(stores.NewPMS(conn))
+	return &templateRouter
+}
+
+// CreateConsumer - helper method
+func (tpr *TemplateRouter) CreateConsumer(configurer rs.Configurer) {
+	tpr.RConsumer = rs.RConsumer{}
+	err := tpr.RConsumer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RConsumer for TemplateRouter")
+	}
+}
+
+// CreateProducer - helper method
+func (tpr *TemplateRouter) CreateProducer(configurer rs.Configurer) {
+	tpr.RProducer = rs.RProducer{}
+	err := tpr.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for TemplateRouter")
+	}
+}
+
+// SetTPS - Dependency injection of stores.TemplateStore
+func (tpr *TemplateRouter) SetTPS(tps stores.TemplateStore) {
+	tpr.tps = tps
+}
+
+// SetPMS - Dependency injection of stores.ProcessedMessageStore
+func (tpr *TemplateRouter) SetPMS(pms stores.ProcessedMessageStore) {
+	tpr.pms = pms
+}
+
+// SetUSS - Dependency injection of stores.SettingsStore
+func (tpr *TemplateRouter) SetUSS(uss stores.SettingsStore) {
+	tpr.uss = uss
+}
+
+// Setup - main method, that sets up all routes and handlers for them
+func (tpr *TemplateRouter) Setup() {
+	tpr.routes = make(map[string]func(amqp091.Delivery) responses.Response)
+	tpr.routes["create"] = idempotent(tpr.pms, tpr.handleCreate)
+	tpr.routes["find"] = tpr.handleFind
+	tpr.routes["list"] = tpr.handleList
+	tpr.routes["update"] = idempotent(tpr.pms, tpr.handleUpdate)
+	tpr.routes["delete"] = idempotent(tpr.pms, tpr.handleDelete)
+	q, err := tpr.RConsumer.CreateQueue(durableQueue("trainings.template"))
+	if err != nil {
+		log.Fatal("error creating queue for template consumer")
+	}
+	err = tpr.RConsumer.SetBinding(q, "trainings.template.#", EXCHANGE_NAME)
+	if err != nil {
+		log.Fatal("error creating binding for template consumer")
+	}
+	//creating dispatcher
+	dispatcher := newRouteDispatcher()
+	for path, f := range tpr.routes {
+		dispatcher.RegisterHandler("trainings.template."+path, rs.NewHandlerFunc(func(msg amqp091.Delivery) {
+			process(tpr.RProducer, msg, f, "tgbot.template."+path)
+		}))
+	}
+	tpr.RConsumer.RegisterDispatcher(q, dispatcher, manualAck())
+}
+
+func (tpr *TemplateRouter) handleCreate(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	template, err := converters.FromJsonToTemplate(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to create template: %#v", template))
+	settings, err := tpr.uss.Find(template.UserId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error finding settings: %w", err))
+	}
+	gotId, err := tpr.tps.Save(units.FromUser(template, settings))
+	if err != nil {
+		return responses.Error(fmt.Errorf("error creating template: %w", err))
+	}
+	return responses.Created(gotId)
+}
+
+func (tpr *TemplateRouter) handleFind(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	query, err := converters.ParseTemplateQuery(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to find template with user_id: %d, id: %d", query.UserId, query.TemplateId))
+	template, err := tpr.tps.FindById(query.UserId, query.TemplateId)
+	if err != nil {
+		return responses.Error(err)
+	}
+	settings, err := tpr.uss.Find(query.UserId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error finding settings: %w", err))
+	}
+	return responses.Success(units.ToUser(template, settings))
+}
+
+func (tpr *TemplateRouter) handleList(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	userId, err := converters.ParseUserID(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to list templates with user_id: %d", userId))
+	templates, err := tpr.tps.FindByUser(userId)
+	if err != nil {
+		return responses.Error(err)
+	}
+	settings, err := tpr.uss.Find(userId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error finding settings: %w", err))
+	}
+	return responses.Success(units.ToUser(templates, settings))
+}
+
+func (tpr *TemplateRouter) handleUpdate(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	template, err := converters.ParseUpdateTemplate(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to update template: %#v", template))
+	settings, err := tpr.uss.Find(template.UserId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error finding settings: %w", err))
+	}
+	err = tpr.tps.Update(units.FromUser(template, settings))
+	if err != nil {
+		return responses.Error(err)
+	}
+	return responses.Success(nil)
+}
+
+func (tpr *TemplateRouter) handleDelete(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	query, err := converters.ParseTemplateQuery(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to delete template with user_id: %d, id: %d", query.UserId, query.TemplateId))
+	err = tpr.tps.DeleteById(query.UserId, query.TemplateId)
+	if err != nil {
+		return responses.Error(err)
+	}
+	return responses.Success(nil)
+}
+
+// Stop - Closure for closing channels of consumer and producer
+func (tpr TemplateRouter) Stop() {
+	tpr.RConsumer.Stop()
+	tpr.RProducer.Stop()
+}
@@ -0,0 +1,108 @@
+package routers
+
+import (
+	"strings"
+	"testing"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+func init() {
+	registerRouter(setupTemplateRouter)
+}
+
+// setupTemplateRouter - sets up TemplateRouter with stub stores
+func setupTemplateRouter(configurer rs.Configurer) stopper {
+	router := &TemplateRouter{}
+	router.CreateConsumer(configurer)
+	router.CreateProducer(configurer)
+	router.SetTPS(stores.TemplateStoreStub{})
+	router.SetUSS(stores.SettingsStoreStub{})
+	router.SetPMS(stores.NewPMSStub())
+	router.Setup()
+	return router
+}
+
+func TestTemplateRoutes(t *testing.T) {
+	data := []struct {
+		testName       string
+		routingKey     string
+		message        string
+		expectedResult string
+	}{
+		{
+			"Negative case: create without exercises",
+			"trainings.template.create",
+			`{"user_id":2,"name":"Push day"}`,
+			wrongInput,
+		},
+		{
+			"Positive case: create",
+			"trainings.template.create",
+			`{"user_id":2,"name":"Push day","exercises":[{"exercise_id":1,"sets":3,"reps":10,"weight":60,"rest":90}]}`,
+			"SUCCESS: id:1",
+		},
+		{
+			"Negative case: find unexisting",
+			"trainings.template.find",
+			`{"user_id":2,"template_id":2}`,
+			"ERROR: sql: no rows in result set",
+		},
+		{
+			"Positive case: find",
+			"trainings.template.find",
+			`{"user_id":2,"template_id":1}`,
+			`SUCCESS: {"id":1,"user_id":2,"name":"Push day","exercises":[{"id":1,"template_id":1,"position":1,"exercise_id":1,"sets":3,"reps":10,"weight":60,"rest":90}]}`,
+		},
+		{
+			"Positive case: list",
+			"trainings.template.list",
+			`{"user_id":2}`,
+			"SUCCESS: [\n{\n\"id\": 1,\n\"user_id\": 2,\n\"name\": \"Push day\",\n\"exercises\": [\n{\n\"id\": 1,\n\"template_id\": 1,\n\"position\": 1,\n\"exercise_id\": 1,\n\"sets\": 3,\n\"reps\": 10,\n\"weight\": 60,\n\"rest\": 90\n}\n]\n}\n]",
+		},
+		{
+			"Negative case: update unexisting",
+			"trainings.template.update",
+			`{"id":2,"user_id":2,"name":"Leg day","exercises":[{"exercise_id":2,"sets":5,"reps":5}]}`,
+			"ERROR: no rows updated",
+		},
+		{
+			"Positive case: update",
+			"trainings.template.update",
+			`{"id":1,"user_id":2,"name":"Leg day","exercises":[{"exercise_id":2,"sets":5,"reps":5}]}`,
+			success,
+		},
+		{
+			"Negative case: delete unexisting",
+			"trainings.template.delete",
+			`{"user_id":2,"template_id":2}`,
+			notDeleted,
+		},
+		{
+			"Negative case: delete template of program",
+			"trainings.template.delete",
+			`{"user_id":2,"template_id":3}`,
+			"ERROR: " + stores.TemplateInProgram.Error(),
+		},
+		{
+			"Positive case: delete",
+			"trainings.template.delete",
+			`{"user_id":2,"template_id":1}`,
+			success,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy(d.routingKey, d.message)
+			body := <-clientConsumer.LastMessageCh
+			if body.RoutingKey != "tgbot"+strings.TrimPrefix(d.routingKey, "trainings") {
+				t.Errorf("error wrong result routing key: %s", body.RoutingKey)
+			}
+			received := string(body.Body)
+			if received != d.expectedResult {
+				t.Errorf("Error handling template, received: %v", received)
+			}
+		})
+	}
+}
@@ -1,16 +1,15 @@
 package routers
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"log/slog"
 
 	rs "github.com/fridrock/rabbitsimplier"
 	"github.com/fridrock/trainingservice/api/utils/converters"
-	"github.com/fridrock/trainingservice/db/core"
+	"github.com/fridrock/trainingservice/api/utils/responses"
 	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
 	"github.com/rabbitmq/amqp091-go"
 )
 
@@ -19,18 +18,20 @@ type TrainingRouter struct {
 	rs.RConsumer
 	rs.RProducer
 	ts     stores.TrainingStore
-	routes map[string]func(amqp091.Delivery) string
+	pms    stores.ProcessedMessageStore
+	routes map[string]func(amqp091.Delivery) responses.Response
 }
 
-const EXCHANGE_NAME = "sport_bot"
+const EXCHANGE_NAME = responses.Exchange
 
 // NewTraningRouter - Default method for creation TrainingRouter, requires rs.Configurer to create channels
-// for consumer and producer
-func NewTrainingRouter(configurer rs.Configurer) *TrainingRouter {
+// for consumer and producer and connection to database, which is shared by its stores
+func NewTrainingRouter(configurer rs.Configurer, conn *sqlx.DB) *TrainingRouter {
 	trainingRouter := TrainingRouter{}
 	trainingRouter.CreateConsumer(configurer)
 	trainingRouter.CreateProducer(configurer)
-	trainingRouter.SetTS(stores.NewTs(core.CreateConnection()))
+	trainingRouter.SetTS(stores.NewTs(conn))
+	trainingRouter.SetPMS(stores.NewPMS(conn))
 	return &trainingRouter
 }
 
@@ -57,13 +58,22 @@ func (tr *TrainingRouter) SetTS(ts stores.TrainingStore) {
 	tr.ts = ts
 }
 
+// SetPMS - Dependency injection of stores.ProcessedMessageStore
+func (tr *TrainingRouter) SetPMS(pms stores.ProcessedMessageStore) {
+	tr.pms = pms
+}
+
 // Setup - main method, that sets up all routes and handlers for them
 func (tr *TrainingRouter) Setup() {
-	tr.routes = make(map[string]func(amqp091.Delivery) string)
-	tr.routes["start"] = tr.handleStart
-	tr.routes["finish"] = tr.handleFinish
+	tr.routes = make(map[string]func(amqp091.Delivery) responses.Response)
+	tr.routes["start"] = idempotent(tr.pms, tr.handleStart)
+	tr.routes["startFromTemplate"] = idempotent(tr.pms, tr.handleStartFromTemplate)
+	tr.routes["finish"] = idempotent(tr.pms, tr.handleFinish)
+	tr.routes["pause"] = idempotent(tr.pms, tr.handlePause)
+	tr.routes["resume"] = idempotent(tr.pms, tr.handleResume)
+	tr.routes["delete"] = idempotent(tr.pms, tr.handleDelete)
 	tr.routes["get"] = tr.handleGet
-	q, err := tr.RConsumer.CreateQueue()
+	q, err := tr.RConsumer.CreateQueue(durableQueue("trainings.training"))
 	if err != nil {
 		log.Fatal("error creating queue for exgroup consumer")
 	}
@@ -72,68 +82,113 @@ func (tr *TrainingRouter) Setup() {
 		log.Fatal("error creating binding for exgroup consumer")
 	}
 	//creating dispatcher
-	dispatcher := rs.NewRDispacher()
+	dispatcher := newRouteDispatcher()
 	for path, f := range tr.routes {
 		dispatcher.RegisterHandler("trainings.training."+path, rs.NewHandlerFunc(func(msg amqp091.Delivery) {
-			tr.sendResponse(f(msg), path)
+			process(tr.RProducer, msg, f, "tgbot.training."+path)
 		}))
 	}
-	tr.RConsumer.RegisterDispatcher(q, dispatcher)
-}
-
-func (tr *TrainingRouter) sendResponse(response, path string) {
-	tr.RProducer.PublishMessage(
-		context.Background(),
-		EXCHANGE_NAME,
-		"tgbot.training."+path,
-		response)
+	tr.RConsumer.RegisterDispatcher(q, dispatcher, manualAck())
 }
 
 // methods, that handles all messages and returns response
-func (tr *TrainingRouter) handleStart(msg amqp091.Delivery) string {
+func (tr *TrainingRouter) handleStart(msg amqp091.Delivery) responses.Response {
 	body := msg.Body
 	userId, err := converters.ParseUserID(body)
 	if err != nil {
-		return "ERROR: wrong input"
+		return responses.WrongInput(err)
 	}
 	slog.Info(fmt.Sprintf("request start training with user: %d", userId))
 	trainingId, err := tr.ts.StartTraining(userId)
 	if err != nil {
-		return fmt.Sprintf("ERROR: error starting training: %v", err)
+		return responses.Error(fmt.Errorf("error starting training: %w", err))
+	}
+	return responses.Created(trainingId)
+}
+
+func (tr *TrainingRouter) handleStartFromTemplate(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	query, err := converters.ParseTemplateQuery(body)
+	if err != nil {
+		return responses.WrongInput(err)
 	}
-	return fmt.Sprintf("SUCCESS: id:%d", trainingId)
+	slog.Info(fmt.Sprintf("request start training with user: %d, template: %d", query.UserId, query.TemplateId))
+	trainingId, err := tr.ts.StartFromTemplate(query.UserId, query.TemplateId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error starting training: %w", err))
+	}
+	return responses.Created(trainingId)
 }
 
-func (tr *TrainingRouter) handleFinish(msg amqp091.Delivery) string {
+func (tr *TrainingRouter) handleFinish(msg amqp091.Delivery) responses.Response {
 	body := msg.Body
 	userId, err := converters.ParseUserID(body)
 	if err != nil {
-		return "ERROR: wrong input"
+		return responses.WrongInput(err)
 	}
 	slog.Info(fmt.Sprintf("request finish training with user: %d", userId))
 	err = tr.ts.FinishTraining(userId)
 	if err != nil {
-		return fmt.Sprintf("ERROR: error finishing training: %v", err)
+		return responses.Error(fmt.Errorf("error finishing training: %w", err))
 	}
-	return "SUCCESS"
+	return responses.Success(nil)
 }
 
-func (tr *TrainingRouter) handleGet(msg amqp091.Delivery) string {
+func (tr *TrainingRouter) handlePause(msg amqp091.Delivery) responses.Response {
 	body := msg.Body
 	userId, err := converters.ParseUserID(body)
 	if err != nil {
-		return "ERROR: wrong input"
+		return responses.WrongInput(err)
 	}
-	slog.Info(fmt.Sprintf("request getting trainings with user: %d", userId))
-	trainings, err := tr.ts.GetTrainings(userId)
+	slog.Info(fmt.Sprintf("request pause training with user: %d", userId))
+	err = tr.ts.PauseTraining(userId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error pausing training: %w", err))
+	}
+	return responses.Success(nil)
+}
+
+func (tr *TrainingRouter) handleResume(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	userId, err := converters.ParseUserID(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request resume training with user: %d", userId))
+	err = tr.ts.ResumeTraining(userId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error resuming training: %w", err))
+	}
+	return responses.Success(nil)
+}
+
+// handleDelete - soft-deletes finished training together with its sets, it can be restored from trash
+func (tr *TrainingRouter) handleDelete(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	query, err := converters.ParseTrainingQuery(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request delete training with user: %d, training: %d", query.UserId, query.TrainingId))
+	err = tr.ts.DeleteTraining(query.UserId, query.TrainingId)
 	if err != nil {
-		return fmt.Sprintf("ERROR: error getting trainings: %v", err)
+		return responses.Error(fmt.Errorf("error deleting training: %w", err))
 	}
-	r, err := json.MarshalIndent(trainings, "", "")
+	return responses.Success(nil)
+}
+
+func (tr *TrainingRouter) handleGet(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	userId, err := converters.ParseUserID(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request getting trainings with user: %d", userId))
+	trainings, err := tr.ts.GetTrainings(userId)
 	if err != nil {
-		return fmt.Sprintf("ERROR: %v", err)
+		return responses.Error(fmt.Errorf("error getting trainings: %w", err))
 	}
-	return fmt.Sprintf("SUCCESS: %v", string(r))
+	return responses.Success(trainings)
 }
 
 // Stop - Closure for closing channels of consumer and producer
@@ -1,11 +1,28 @@
 package routers
 
 import (
-	"context"
 	"strings"
 	"testing"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/db/stores"
 )
 
+func init() {
+	registerRouter(setupTrainingRouter)
+}
+
+// setupTrainingRouter - sets up TrainingRouter with stub stores
+func setupTrainingRouter(configurer rs.Configurer) stopper {
+	router := &TrainingRouter{}
+	router.CreateConsumer(configurer)
+	router.CreateProducer(configurer)
+	router.SetTS(stores.TrainingStoreStub{})
+	router.SetPMS(stores.NewPMSStub())
+	router.Setup()
+	return router
+}
+
 func TestStartTraining(t *testing.T) {
 	data := []struct {
 		testName       string
@@ -19,6 +36,12 @@ func TestStartTraining(t *testing.T) {
 			wrongInput,
 			"Error starting training, received: %v",
 		},
+		{
+			"Negative case: training in progress",
+			`{"user_id":3}`,
+			"ERROR: error starting training: training is already in progress",
+			"Error starting training, received: %v",
+		},
 		{
 			"Positive case",
 			`{"user_id":1}`,
@@ -28,7 +51,7 @@ func TestStartTraining(t *testing.T) {
 	}
 	for _, d := range data {
 		t.Run(d.testName, func(t *testing.T) {
-			clientProducer.PublishMessage(context.Background(), "sport_bot", "trainings.training.start", d.message)
+			publishLegacy("trainings.training.start", d.message)
 		})
 		body := <-clientConsumer.LastMessageCh
 		if body.RoutingKey != "tgbot.training.start" {
@@ -69,7 +92,7 @@ func TestFinishTraining(t *testing.T) {
 	}
 	for _, d := range data {
 		t.Run(d.testName, func(t *testing.T) {
-			clientProducer.PublishMessage(context.Background(), "sport_bot", "trainings.training.finish", d.message)
+			publishLegacy("trainings.training.finish", d.message)
 		})
 		body := <-clientConsumer.LastMessageCh
 		if body.RoutingKey != "tgbot.training.finish" {
@@ -82,6 +105,53 @@ func TestFinishTraining(t *testing.T) {
 	}
 }
 
+func TestDeleteTraining(t *testing.T) {
+	data := []struct {
+		testName       string
+		message        string
+		expectedResult string
+		errMessage     string
+	}{
+		{
+			"Negative case: wrong input",
+			`{"user_id":2}`,
+			wrongInput,
+			"Error deleting training, received: %v",
+		},
+		{
+			"Negative case: no such training",
+			`{"user_id":2,"training_id":404}`,
+			"ERROR: error deleting training: no rows deleted",
+			"Error deleting training, received: %v",
+		},
+		{
+			"Negative case: training in progress",
+			`{"user_id":3,"training_id":12}`,
+			"ERROR: error deleting training: training is already in progress",
+			"Error deleting training, received: %v",
+		},
+		{
+			"Positive case",
+			`{"user_id":2,"training_id":12}`,
+			"SUCCESS",
+			"Error deleting training, received: %v",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy("trainings.training.delete", d.message)
+		})
+		body := <-clientConsumer.LastMessageCh
+		if body.RoutingKey != "tgbot.training.delete" {
+			t.Errorf("error wrong result routing key")
+		}
+		received := string(body.Body)
+		if received != d.expectedResult {
+			t.Errorf(d.errMessage, received)
+		}
+	}
+}
+
 func TestGetTrainings(t *testing.T) {
 	data := []struct {
 		testName       string
@@ -111,7 +181,7 @@ func TestGetTrainings(t *testing.T) {
 	}
 	for _, d := range data {
 		t.Run(d.testName, func(t *testing.T) {
-			clientProducer.PublishMessage(context.Background(), "sport_bot", "trainings.training.get", d.message)
+			publishLegacy("trainings.training.get", d.message)
 		})
 		body := <-clientConsumer.LastMessageCh
 		if body.RoutingKey != "tgbot.training.get" {
@@ -129,3 +199,98 @@ func TestGetTrainings(t *testing.T) {
 		}
 	}
 }
+
+func TestPauseResumeTraining(t *testing.T) {
+	data := []struct {
+		testName       string
+		routingKey     string
+		message        string
+		expectedResult string
+	}{
+		{
+			"Negative case: wrong input",
+			"trainings.training.pause",
+			`{"userid":2}`,
+			wrongInput,
+		},
+		{
+			"Negative case: no open training",
+			"trainings.training.pause",
+			`{"user_id":1}`,
+			"ERROR: error pausing training: no open training to pause",
+		},
+		{
+			"Positive case paused",
+			"trainings.training.pause",
+			`{"user_id":2}`,
+			success,
+		},
+		{
+			"Negative case: no paused training",
+			"trainings.training.resume",
+			`{"user_id":1}`,
+			"ERROR: error resuming training: no paused training to resume",
+		},
+		{
+			"Positive case resumed",
+			"trainings.training.resume",
+			`{"user_id":2}`,
+			success,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy(d.routingKey, d.message)
+			body := <-clientConsumer.LastMessageCh
+			if body.RoutingKey != "tgbot"+strings.TrimPrefix(d.routingKey, "trainings") {
+				t.Errorf("error wrong result routing key: %s", body.RoutingKey)
+			}
+			received := string(body.Body)
+			if received != d.expectedResult {
+				t.Errorf("Error pausing training, received: %v", received)
+			}
+		})
+	}
+}
+
+func TestStartFromTemplate(t *testing.T) {
+	data := []struct {
+		testName       string
+		message        string
+		expectedResult string
+	}{
+		{
+			"Negative case: wrong input",
+			`{"user_id":2}`,
+			wrongInput,
+		},
+		{
+			"Negative case: unexisting template",
+			`{"user_id":2,"template_id":404}`,
+			"ERROR: error starting training: sql: no rows in result set",
+		},
+		{
+			"Negative case: training in progress",
+			`{"user_id":3,"template_id":1}`,
+			"ERROR: error starting training: training is already in progress",
+		},
+		{
+			"Positive case",
+			`{"user_id":2,"template_id":1}`,
+			"SUCCESS: id:12",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy("trainings.training.startFromTemplate", d.message)
+			body := <-clientConsumer.LastMessageCh
+			if body.RoutingKey != "tgbot.training.startFromTemplate" {
+				t.Errorf("error wrong result routing key: %s", body.RoutingKey)
+			}
+			received := string(body.Body)
+			if received != d.expectedResult {
+				t.Errorf("Error starting training from template, received: %v", received)
+			}
+		})
+	}
+}
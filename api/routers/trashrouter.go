@@ -0,0 +1,120 @@
+package routers
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/converters"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/jmoiron/sqlx"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// TrashRouter - structure, that contains both consumer, and producer for messaging inside Trash domain
+type TrashRouter struct {
+	rs.RConsumer
+	rs.RProducer
+	trs    stores.TrashStore
+	pms    stores.ProcessedMessageStore
+	routes map[string]func(amqp091.Delivery) responses.Response
+}
+
+// NewTrashRouter - Default method for creation TrashRouter, requires rs.Configurer to create channels
+// for consumer and producer and connection to database, which is shared by its stores
+func NewTrashRouter(configurer rs.Configurer, conn *sqlx.DB) *TrashRouter {
+	trashRouter := TrashRouter{}
+	trashRouter.CreateConsumer(configurer)
+	trashRouter.CreateProducer(configurer)
+	trashRouter.SetTRS(stores.NewTRS(conn))
+	trashRouter.SetPMS(stores.NewPMS(conn))
+	return &trashRouter
+}
+
+// CreateConsumer - helper method
+func (trr *TrashRouter) CreateConsumer(configurer rs.Configurer) {
+	trr.RConsumer = rs.RConsumer{}
+	err := trr.RConsumer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RConsumer for TrashRouter")
+	}
+}
+
+// CreateProducer - helper method
+func (trr *TrashRouter) CreateProducer(configurer rs.Configurer) {
+	trr.RProducer = rs.RProducer{}
+	err := trr.RProducer.CreateChannel(configurer.GetConnection())
+	if err != nil {
+		log.Fatal("error creating RProducer for TrashRouter")
+	}
+}
+
+// SetTRS - Dependency injection of stores.TrashStore
+func (trr *TrashRouter) SetTRS(trs stores.TrashStore) {
+	trr.trs = trs
+}
+
+// SetPMS - Dependency injection of stores.ProcessedMessageStore
+func (trr *TrashRouter) SetPMS(pms stores.ProcessedMessageStore) {
+	trr.pms = pms
+}
+
+// Setup - main method, that sets up all routes and handlers for them
+func (trr *TrashRouter) Setup() {
+	trr.routes = make(map[string]func(amqp091.Delivery) responses.Response)
+	trr.routes["list"] = trr.handleList
+	trr.routes["restore"] = idempotent(trr.pms, trr.handleRestore)
+	q, err := trr.RConsumer.CreateQueue(durableQueue("trainings.trash"))
+	if err != nil {
+		log.Fatal("error creating queue for trash consumer")
+	}
+	err = trr.RConsumer.SetBinding(q, "trainings.trash.#", EXCHANGE_NAME)
+	if err != nil {
+		log.Fatal("error creating binding for trash consumer")
+	}
+	//creating dispatcher
+	dispatcher := newRouteDispatcher()
+	for path, f := range trr.routes {
+		dispatcher.RegisterHandler("trainings.trash."+path, rs.NewHandlerFunc(func(msg amqp091.Delivery) {
+			process(trr.RProducer, msg, f, "tgbot.trash."+path)
+		}))
+	}
+	trr.RConsumer.RegisterDispatcher(q, dispatcher, manualAck())
+}
+
+func (trr *TrashRouter) handleList(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	userId, err := converters.ParseUserID(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to list trash with user: %d", userId))
+	items, err := trr.trs.FindByUser(userId)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error listing trash: %w", err))
+	}
+	return responses.Success(items)
+}
+
+// handleRestore - restores deleted item together with items deleted with it
+func (trr *TrashRouter) handleRestore(msg amqp091.Delivery) responses.Response {
+	body := msg.Body
+	query, err := converters.ParseTrashRestore(body)
+	if err != nil {
+		return responses.WrongInput(err)
+	}
+	slog.Info(fmt.Sprintf("request to restore %s %d of user %d", query.Kind, query.Id, query.UserId))
+	err = trr.trs.Restore(query.UserId, query.Kind, query.Id)
+	if err != nil {
+		return responses.Error(fmt.Errorf("error restoring %s: %w", query.Kind, err))
+	}
+	return responses.Success(nil)
+}
+
+// Stop - Closure for closing channels of consumer and producer
+func (trr TrashRouter) Stop() {
+	trr.RConsumer.Stop()
+	trr.RProducer.Stop()
+}
@@ -0,0 +1,95 @@
+package routers
+
+import (
+	"strings"
+	"testing"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+func init() {
+	registerRouter(setupTrashRouter)
+}
+
+// setupTrashRouter - sets up TrashRouter with stub stores
+func setupTrashRouter(configurer rs.Configurer) stopper {
+	router := &TrashRouter{}
+	router.CreateConsumer(configurer)
+	router.CreateProducer(configurer)
+	router.SetTRS(stores.TrashStoreStub{})
+	router.SetPMS(stores.NewPMSStub())
+	router.Setup()
+	return router
+}
+
+func TestTrashRoutes(t *testing.T) {
+	data := []struct {
+		testName       string
+		routingKey     string
+		message        string
+		expectedResult string
+	}{
+		{
+			"Negative case: list wrong input",
+			"trainings.trash.list",
+			`{"userid":2}`,
+			wrongInput,
+		},
+		{
+			"Negative case: list with no deleted items",
+			"trainings.trash.list",
+			`{"user_id":1}`,
+			"ERROR: error listing trash: sql: no rows in result set",
+		},
+		{
+			"Positive case: list",
+			"trainings.trash.list",
+			`{"user_id":2}`,
+			"SUCCESS: [\n{\n\"kind\": \"set\",\n\"id\": 3,\n\"name\": \"Bench press\",\n\"deleted_at\": \"2024-09-10T12:00:00Z\"\n},\n{\n\"kind\": \"training\",\n\"id\": 2,\n\"deleted_at\": \"2024-09-10T11:00:00Z\"\n},\n{\n\"kind\": \"group\",\n\"id\": 1,\n\"name\": \"Back\",\n\"deleted_at\": \"2024-09-10T10:00:00Z\"\n}\n]",
+		},
+		{
+			"Negative case: restore unknown kind",
+			"trainings.trash.restore",
+			`{"user_id":2,"kind":"template","id":1}`,
+			wrongInput,
+		},
+		{
+			"Negative case: restore unexisting item",
+			"trainings.trash.restore",
+			`{"user_id":2,"kind":"set","id":404}`,
+			"ERROR: error restoring set: no rows updated",
+		},
+		{
+			"Negative case: restore exercise of deleted group",
+			"trainings.trash.restore",
+			`{"user_id":2,"kind":"exercise","id":2}`,
+			"ERROR: error restoring exercise: parent of deleted item is deleted, restore it first",
+		},
+		{
+			"Negative case: restore group with taken name",
+			"trainings.trash.restore",
+			`{"user_id":2,"kind":"group","id":3}`,
+			"ERROR: error restoring group: exercise group with this name already exists",
+		},
+		{
+			"Positive case: restore",
+			"trainings.trash.restore",
+			`{"user_id":2,"kind":"training","id":2}`,
+			success,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			publishLegacy(d.routingKey, d.message)
+			body := <-clientConsumer.LastMessageCh
+			if body.RoutingKey != "tgbot"+strings.TrimPrefix(d.routingKey, "trainings") {
+				t.Errorf("error wrong result routing key: %s", body.RoutingKey)
+			}
+			received := string(body.Body)
+			if received != d.expectedResult {
+				t.Errorf("Error handling trash, received: %v", received)
+			}
+		})
+	}
+}
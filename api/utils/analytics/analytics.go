@@ -0,0 +1,156 @@
+package analytics
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+var (
+	UnknownFormula = errors.New("unknown one-rep max formula")
+)
+
+// Formula - formula of estimated one-rep max
+type Formula string
+
+const (
+	// Epley - weight * (1 + reps / 30), same formula is used for personal records
+	Epley Formula = "epley"
+	// Brzycki - weight * 36 / (37 - reps), isn't defined for 37 reps and more
+	Brzycki Formula = "brzycki"
+)
+
+// ParseFormula - returns formula by its name, empty name means Epley
+func ParseFormula(name string) (Formula, error) {
+	switch Formula(name) {
+	case "", Epley:
+		return Epley, nil
+	case Brzycki:
+		return Brzycki, nil
+	}
+	return "", UnknownFormula
+}
+
+// OneRepMax - estimated one-rep max of set, returns zero if it can't be estimated
+func OneRepMax(formula Formula, weight float64, reps int64) float64 {
+	if weight <= 0 || reps <= 0 {
+		return 0
+	}
+	switch formula {
+	case Epley:
+		return weight * (1 + float64(reps)/30)
+	case Brzycki:
+		if reps >= 37 {
+			return 0
+		}
+		return weight * 36 / float64(37-reps)
+	}
+	return 0
+}
+
+// OneRepMaxPoint - best estimated one-rep max of exercise in one training
+type OneRepMaxPoint struct {
+	TrainingId int64     `json:"training_id"`
+	Date       time.Time `json:"date"`
+	Weight     float64   `json:"weight" unit:"weight"`
+	Reps       int64     `json:"reps"`
+	OneRepMax  float64   `json:"one_rep_max" unit:"weight"`
+}
+
+// OneRepMaxProgress - best estimated one-rep max for every training, sets must be ordered by training begins.
+// Trainings without sets, which allow estimation, are skipped
+func OneRepMaxProgress(formula Formula, sets []stores.AnalyzedSet) []OneRepMaxPoint {
+	points := []OneRepMaxPoint{}
+	for _, set := range sets {
+		oneRepMax := OneRepMax(formula, set.Weight, set.Reps)
+		if oneRepMax == 0 {
+			continue
+		}
+		last := len(points) - 1
+		if last < 0 || points[last].TrainingId != set.TrainingId {
+			points = append(points, OneRepMaxPoint{TrainingId: set.TrainingId, Date: set.TrainingBegins})
+			last++
+		}
+		if oneRepMax > points[last].OneRepMax {
+			points[last].Weight = set.Weight
+			points[last].Reps = set.Reps
+			points[last].OneRepMax = oneRepMax
+		}
+	}
+	return points
+}
+
+// SessionTonnage - total lifted weight (weight * reps) of one training
+type SessionTonnage struct {
+	TrainingId int64     `json:"training_id"`
+	Date       time.Time `json:"date"`
+	Sets       int64     `json:"sets"`
+	Reps       int64     `json:"reps"`
+	Tonnage    float64   `json:"tonnage" unit:"weight"`
+}
+
+// Tonnage - tonnage of every training, sets must be ordered by training begins
+func Tonnage(sets []stores.AnalyzedSet) []SessionTonnage {
+	sessions := []SessionTonnage{}
+	for _, set := range sets {
+		last := len(sessions) - 1
+		if last < 0 || sessions[last].TrainingId != set.TrainingId {
+			sessions = append(sessions, SessionTonnage{TrainingId: set.TrainingId, Date: set.TrainingBegins})
+			last++
+		}
+		sessions[last].Sets++
+		sessions[last].Reps += set.Reps
+		sessions[last].Tonnage += set.Weight * float64(set.Reps)
+	}
+	return sessions
+}
+
+// GroupVolume - volume of exercise group in a week, which begins on the first day of week of user
+type GroupVolume struct {
+	Week      time.Time `json:"week"`
+	GroupId   int64     `json:"group_id"`
+	GroupName string    `json:"group_name"`
+	Sets      int64     `json:"sets"`
+	Reps      int64     `json:"reps"`
+	Tonnage   float64   `json:"tonnage" unit:"weight"`
+	Duration  int64     `json:"duration"`
+}
+
+// WeeklyVolume - volume of every exercise group by weeks, which begin on weekStart, ordered by week and group name
+func WeeklyVolume(sets []stores.AnalyzedSet, weekStart time.Weekday) []GroupVolume {
+	type key struct {
+		week    time.Time
+		groupId int64
+	}
+	indexes := map[key]int{}
+	volumes := []GroupVolume{}
+	for _, set := range sets {
+		k := key{week: WeekOf(set.TrainingBegins, weekStart), groupId: set.GroupId}
+		i, ok := indexes[k]
+		if !ok {
+			i = len(volumes)
+			indexes[k] = i
+			volumes = append(volumes, GroupVolume{Week: k.week, GroupId: set.GroupId, GroupName: set.GroupName})
+		}
+		volumes[i].Sets++
+		volumes[i].Reps += set.Reps
+		volumes[i].Tonnage += set.Weight * float64(set.Reps)
+		volumes[i].Duration += set.Duration
+	}
+	sort.SliceStable(volumes, func(i, j int) bool {
+		if !volumes[i].Week.Equal(volumes[j].Week) {
+			return volumes[i].Week.Before(volumes[j].Week)
+		}
+		return volumes[i].GroupName < volumes[j].GroupName
+	})
+	return volumes
+}
+
+// WeekOf - beginning of the first day of the week, which contains t, weeks begin on weekStart
+func WeekOf(t time.Time, weekStart time.Weekday) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := (int(day.Weekday()) - int(weekStart) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,137 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/google/go-cmp/cmp"
+)
+
+func analyzedSet(trainingId int64, begins time.Time, groupId int64, weight float64, reps int64) stores.AnalyzedSet {
+	return stores.AnalyzedSet{
+		ExerciseSet: stores.ExerciseSet{
+			TrainingId: trainingId,
+			Weight:     weight,
+			Reps:       reps,
+		},
+		TrainingBegins: begins,
+		GroupId:        groupId,
+		GroupName:      map[int64]string{1: "Back", 2: "Legs"}[groupId],
+	}
+}
+
+func TestParseFormula(t *testing.T) {
+	data := []struct {
+		name            string
+		expectedFormula Formula
+		expectedError   error
+	}{
+		{"", Epley, nil},
+		{"epley", Epley, nil},
+		{"brzycki", Brzycki, nil},
+		{"lombardi", "", UnknownFormula},
+	}
+	for _, d := range data {
+		formula, err := ParseFormula(d.name)
+		if formula != d.expectedFormula || err != d.expectedError {
+			t.Errorf("got wrong formula for %q: %v, %v", d.name, formula, err)
+		}
+	}
+}
+
+func TestOneRepMax(t *testing.T) {
+	data := []struct {
+		testName  string
+		formula   Formula
+		weight    float64
+		reps      int64
+		oneRepMax float64
+	}{
+		{"epley", Epley, 60, 10, 80},
+		{"brzycki", Brzycki, 100, 1, 100},
+		{"brzycki 10 reps", Brzycki, 54, 10, 72},
+		{"brzycki undefined", Brzycki, 60, 37, 0},
+		{"without weight", Epley, 0, 10, 0},
+		{"without reps", Epley, 60, 0, 0},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			if oneRepMax := OneRepMax(d.formula, d.weight, d.reps); oneRepMax != d.oneRepMax {
+				t.Errorf("got wrong one-rep max: %v", oneRepMax)
+			}
+		})
+	}
+}
+
+func TestOneRepMaxProgress(t *testing.T) {
+	first := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 0, 2)
+	sets := []stores.AnalyzedSet{
+		analyzedSet(1, first, 1, 60, 10),
+		analyzedSet(1, first, 1, 65, 5),
+		analyzedSet(2, second, 1, 0, 20),
+		analyzedSet(3, second.AddDate(0, 0, 2), 1, 60, 13),
+	}
+	expected := []OneRepMaxPoint{
+		{TrainingId: 1, Date: first, Weight: 60, Reps: 10, OneRepMax: 80},
+		{TrainingId: 3, Date: second.AddDate(0, 0, 2), Weight: 60, Reps: 13, OneRepMax: 86},
+	}
+	if diff := cmp.Diff(expected, OneRepMaxProgress(Epley, sets)); diff != "" {
+		t.Errorf("got wrong progress: %s", diff)
+	}
+}
+
+func TestTonnage(t *testing.T) {
+	first := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 0, 2)
+	sets := []stores.AnalyzedSet{
+		analyzedSet(1, first, 1, 60, 10),
+		analyzedSet(1, first, 2, 100, 5),
+		analyzedSet(2, second, 1, 0, 20),
+	}
+	expected := []SessionTonnage{
+		{TrainingId: 1, Date: first, Sets: 2, Reps: 15, Tonnage: 1100},
+		{TrainingId: 2, Date: second, Sets: 1, Reps: 20, Tonnage: 0},
+	}
+	if diff := cmp.Diff(expected, Tonnage(sets)); diff != "" {
+		t.Errorf("got wrong tonnage: %s", diff)
+	}
+	if sessions := Tonnage(nil); sessions == nil || len(sessions) != 0 {
+		t.Errorf("got wrong tonnage without sets: %#v", sessions)
+	}
+}
+
+func TestWeeklyVolume(t *testing.T) {
+	monday := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	sets := []stores.AnalyzedSet{
+		analyzedSet(1, monday.Add(10*time.Hour), 2, 100, 5),
+		analyzedSet(1, monday.Add(10*time.Hour), 1, 60, 10),
+		analyzedSet(2, monday.AddDate(0, 0, 6).Add(20*time.Hour), 1, 60, 8),
+		analyzedSet(3, monday.AddDate(0, 0, 7), 1, 60, 10),
+	}
+	expected := []GroupVolume{
+		{Week: monday, GroupId: 1, GroupName: "Back", Sets: 2, Reps: 18, Tonnage: 1080},
+		{Week: monday, GroupId: 2, GroupName: "Legs", Sets: 1, Reps: 5, Tonnage: 500},
+		{Week: monday.AddDate(0, 0, 7), GroupId: 1, GroupName: "Back", Sets: 1, Reps: 10, Tonnage: 600},
+	}
+	if diff := cmp.Diff(expected, WeeklyVolume(sets, time.Monday)); diff != "" {
+		t.Errorf("got wrong volume: %s", diff)
+	}
+}
+
+func TestWeekOf(t *testing.T) {
+	monday := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 7; day++ {
+		if week := WeekOf(monday.AddDate(0, 0, day).Add(23*time.Hour), time.Monday); !week.Equal(monday) {
+			t.Errorf("got wrong week of %d day: %v", day, week)
+		}
+	}
+	//weeks beginning on Sunday
+	sunday := monday.AddDate(0, 0, -1)
+	for day := 0; day < 7; day++ {
+		if week := WeekOf(sunday.AddDate(0, 0, day).Add(23*time.Hour), time.Sunday); !week.Equal(sunday) {
+			t.Errorf("got wrong sunday week of %d day: %v", day, week)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/fridrock/trainingservice/api/utils/muscles"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+// SecondaryShare - part of set, which is counted for secondary muscles of exercise
+const SecondaryShare = 0.5
+
+// MuscleSets - sets of muscle in range of dates, weekly sets is average number of sets per week of range
+type MuscleSets struct {
+	Muscle     string         `json:"muscle"`
+	Region     muscles.Region `json:"region"`
+	Sets       float64        `json:"sets"`
+	WeeklySets float64        `json:"weekly_sets"`
+}
+
+// WeeklyMuscleSets - sets of muscle in a week, which begins on the first day of week of user
+type WeeklyMuscleSets struct {
+	Week   time.Time `json:"week"`
+	Muscle string    `json:"muscle"`
+	Sets   float64   `json:"sets"`
+}
+
+// RatioBalance - sets of both sides of muscles.Ratio and their ratio, ratio is zero if second side has no sets
+type RatioBalance struct {
+	Name       string  `json:"name"`
+	First      float64 `json:"first"`
+	Second     float64 `json:"second"`
+	Ratio      float64 `json:"ratio"`
+	Imbalanced bool    `json:"imbalanced"`
+}
+
+// BalanceReport - sets of every muscle of taxonomy in range of dates, neglected muscles are muscles without sets.
+// Sets of exercises, which aren't mapped to muscles, are only counted in unmapped sets
+type BalanceReport struct {
+	Weeks        int64              `json:"weeks"`
+	Muscles      []MuscleSets       `json:"muscles"`
+	Weekly       []WeeklyMuscleSets `json:"weekly"`
+	Ratios       []RatioBalance     `json:"ratios"`
+	Neglected    []string           `json:"neglected"`
+	UnmappedSets int64              `json:"unmapped_sets"`
+}
+
+// Balance - report of sets per muscle in [from, to), weeks begin on weekStart. Set is counted as one set for each of
+// its primary muscles and as SecondaryShare of set for each of its secondary ones. Nothing is flagged as neglected or
+// imbalanced, if there are no mapped sets
+func Balance(sets []stores.MuscleSet, from time.Time, to time.Time, weekStart time.Weekday) BalanceReport {
+	report := BalanceReport{
+		Weeks:     int64(math.Ceil(math.Round(to.Sub(from).Hours()/24) / 7)),
+		Muscles:   []MuscleSets{},
+		Weekly:    []WeeklyMuscleSets{},
+		Ratios:    []RatioBalance{},
+		Neglected: []string{},
+	}
+	type key struct {
+		week   time.Time
+		muscle string
+	}
+	total := map[string]float64{}
+	weekly := map[key]float64{}
+	for _, set := range sets {
+		shares := setShares(set)
+		if len(shares) == 0 {
+			report.UnmappedSets++
+			continue
+		}
+		week := WeekOf(set.TrainingBegins, weekStart)
+		for muscle, share := range shares {
+			total[muscle] += share
+			weekly[key{week: week, muscle: muscle}] += share
+		}
+	}
+	order := map[string]int{}
+	for i, muscle := range muscles.Taxonomy {
+		order[muscle.Name] = i
+		sets := total[muscle.Name]
+		report.Muscles = append(report.Muscles, MuscleSets{
+			Muscle:     muscle.Name,
+			Region:     muscle.Region,
+			Sets:       round(sets),
+			WeeklySets: round(sets / math.Max(float64(report.Weeks), 1)),
+		})
+		if sets == 0 && len(total) > 0 {
+			report.Neglected = append(report.Neglected, muscle.Name)
+		}
+	}
+	for k, sets := range weekly {
+		report.Weekly = append(report.Weekly, WeeklyMuscleSets{Week: k.week, Muscle: k.muscle, Sets: round(sets)})
+	}
+	sort.Slice(report.Weekly, func(i, j int) bool {
+		if !report.Weekly[i].Week.Equal(report.Weekly[j].Week) {
+			return report.Weekly[i].Week.Before(report.Weekly[j].Week)
+		}
+		return order[report.Weekly[i].Muscle] < order[report.Weekly[j].Muscle]
+	})
+	for _, ratio := range muscles.Ratios {
+		report.Ratios = append(report.Ratios, ratioBalance(ratio, total))
+	}
+	return report
+}
+
+// setShares - part of set counted for every muscle of taxonomy trained by set, muscles outside of taxonomy are
+// ignored
+func setShares(set stores.MuscleSet) map[string]float64 {
+	shares := map[string]float64{}
+	for _, muscle := range set.SecondaryMuscles {
+		if muscles.IsMuscle(muscle) {
+			shares[muscle] = SecondaryShare
+		}
+	}
+	for _, muscle := range set.PrimaryMuscles {
+		if muscles.IsMuscle(muscle) {
+			shares[muscle] = 1
+		}
+	}
+	return shares
+}
+
+func ratioBalance(ratio muscles.Ratio, total map[string]float64) RatioBalance {
+	balance := RatioBalance{Name: ratio.Name}
+	for _, muscle := range ratio.First {
+		balance.First += total[muscle]
+	}
+	for _, muscle := range ratio.Second {
+		balance.Second += total[muscle]
+	}
+	if balance.Second > 0 {
+		balance.Ratio = balance.First / balance.Second
+		balance.Imbalanced = balance.Ratio > ratio.Max || balance.Ratio < 1/ratio.Max
+	} else {
+		balance.Imbalanced = balance.First > 0
+	}
+	balance.First = round(balance.First)
+	balance.Second = round(balance.Second)
+	balance.Ratio = round(balance.Ratio)
+	return balance
+}
+
+// round - rounds x to two decimal places
+func round(x float64) float64 {
+	return math.Round(x*100) / 100
+}
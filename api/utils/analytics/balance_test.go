@@ -0,0 +1,78 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/google/go-cmp/cmp"
+)
+
+func muscleSet(begins time.Time, primary []string, secondary []string) stores.MuscleSet {
+	return stores.MuscleSet{
+		AnalyzedSet:      stores.AnalyzedSet{TrainingBegins: begins},
+		PrimaryMuscles:   primary,
+		SecondaryMuscles: secondary,
+	}
+}
+
+func TestBalance(t *testing.T) {
+	monday := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	pullUp := muscleSet(monday.Add(10*time.Hour), []string{"lats"}, []string{"biceps"})
+	sets := []stores.MuscleSet{
+		muscleSet(monday.Add(10*time.Hour), []string{"quads", "glutes"}, nil),
+		pullUp, pullUp, pullUp,
+		muscleSet(monday.Add(10*time.Hour), nil, nil),
+		muscleSet(monday.AddDate(0, 0, 8), []string{"chest"}, []string{"triceps", "shoulders", "chest", "necks"}),
+	}
+	report := Balance(sets, monday, monday.AddDate(0, 0, 14), time.Monday)
+	if report.Weeks != 2 || report.UnmappedSets != 1 {
+		t.Errorf("got wrong weeks or unmapped sets: %d, %d", report.Weeks, report.UnmappedSets)
+	}
+	found := map[string]MuscleSets{}
+	for _, muscle := range report.Muscles {
+		found[muscle.Muscle] = muscle
+	}
+	if len(report.Muscles) != 14 || found["lats"].Sets != 3 || found["lats"].WeeklySets != 1.5 ||
+		found["chest"].Sets != 1 || found["triceps"].Sets != 0.5 || found["quads"].Region != "lower" {
+		t.Errorf("got wrong muscles: %#v", report.Muscles)
+	}
+	expectedWeekly := []WeeklyMuscleSets{
+		{Week: monday, Muscle: "lats", Sets: 3},
+		{Week: monday, Muscle: "biceps", Sets: 1.5},
+		{Week: monday, Muscle: "glutes", Sets: 1},
+		{Week: monday, Muscle: "quads", Sets: 1},
+		{Week: monday.AddDate(0, 0, 7), Muscle: "chest", Sets: 1},
+		{Week: monday.AddDate(0, 0, 7), Muscle: "shoulders", Sets: 0.5},
+		{Week: monday.AddDate(0, 0, 7), Muscle: "triceps", Sets: 0.5},
+	}
+	if diff := cmp.Diff(expectedWeekly, report.Weekly); diff != "" {
+		t.Errorf("got wrong weekly sets: %s", diff)
+	}
+	expectedRatios := []RatioBalance{
+		{Name: "push_pull", First: 2, Second: 4.5, Ratio: 0.44, Imbalanced: true},
+		{Name: "upper_lower", First: 6.5, Second: 2, Ratio: 3.25, Imbalanced: true},
+		{Name: "quads_hamstrings", First: 1, Second: 0, Ratio: 0, Imbalanced: true},
+	}
+	if diff := cmp.Diff(expectedRatios, report.Ratios); diff != "" {
+		t.Errorf("got wrong ratios: %s", diff)
+	}
+	expectedNeglected := []string{"traps", "forearms", "abs", "obliques", "lower_back", "hamstrings", "calves"}
+	if diff := cmp.Diff(expectedNeglected, report.Neglected); diff != "" {
+		t.Errorf("got wrong neglected muscles: %s", diff)
+	}
+}
+
+func TestBalanceWithoutMapping(t *testing.T) {
+	monday := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	sets := []stores.MuscleSet{muscleSet(monday, nil, nil), muscleSet(monday, []string{}, []string{})}
+	report := Balance(sets, monday, monday.AddDate(0, 0, 3), time.Monday)
+	if report.Weeks != 1 || report.UnmappedSets != 2 || len(report.Neglected) != 0 || len(report.Weekly) != 0 {
+		t.Errorf("got wrong report: %#v", report)
+	}
+	for _, ratio := range report.Ratios {
+		if ratio.Imbalanced {
+			t.Errorf("ratio without sets is imbalanced: %#v", ratio)
+		}
+	}
+}
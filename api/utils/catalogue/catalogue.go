@@ -0,0 +1,112 @@
+package catalogue
+
+import (
+	_ "embed"
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+//go:embed catalogue.json
+var data []byte
+
+// Threshold - minimal trigram similarity of exercise to be matched by search
+const Threshold = 0.3
+
+// Match - catalogue exercise found by search, score is in range (0, 1], 1 is exact match of name or alias
+type Match struct {
+	stores.CatalogueExercise
+	Score float64 `json:"score"`
+}
+
+// Load - exercises of catalogue from embedded data file
+func Load() ([]stores.CatalogueExercise, error) {
+	var exercises []stores.CatalogueExercise
+	err := json.Unmarshal(data, &exercises)
+	return exercises, err
+}
+
+// Search - exercises, which names or aliases are similar to query, ordered by score, at most limit ones.
+// Names with words starting with query score 0.9, others are scored by trigram similarity scaled to 0.8, so typos
+// and word order are tolerated, but rank below names containing query
+func Search(exercises []stores.CatalogueExercise, query string, limit int) []Match {
+	query = normalize(query)
+	matches := []Match{}
+	if query == "" {
+		return matches
+	}
+	queryTrigrams := trigrams(query)
+	for _, exercise := range exercises {
+		best := 0.0
+		for _, name := range append([]string{exercise.Name}, exercise.Aliases...) {
+			best = math.Max(best, score(query, queryTrigrams, normalize(name)))
+		}
+		if best > 0 {
+			matches = append(matches, Match{CatalogueExercise: exercise, Score: math.Round(best*100) / 100})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Name < matches[j].Name
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+func score(query string, queryTrigrams map[string]bool, name string) float64 {
+	if name == query {
+		return 1
+	}
+	if strings.Contains(" "+name, " "+query) {
+		return 0.9
+	}
+	s := similarity(queryTrigrams, trigrams(name))
+	if s < Threshold {
+		return 0
+	}
+	return s * 0.8
+}
+
+// normalize - lower case words of s separated by single spaces, punctuation is treated as space
+func normalize(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// trigrams - set of trigrams of words of normalized s, every word is padded with two spaces in front and one
+// space after it, as in postgres pg_trgm
+func trigrams(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(s) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+// similarity - share of common trigrams among all trigrams of both sets
+func similarity(a map[string]bool, b map[string]bool) float64 {
+	common := 0
+	for trigram := range a {
+		if b[trigram] {
+			common++
+		}
+	}
+	total := len(a) + len(b) - common
+	if total == 0 {
+		return 0
+	}
+	return float64(common) / float64(total)
+}
@@ -0,0 +1,75 @@
+package catalogue
+
+import (
+	"testing"
+
+	"github.com/fridrock/trainingservice/api/utils/muscles"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+func TestLoad(t *testing.T) {
+	exercises, err := Load()
+	if err != nil {
+		t.Fatalf("error loading catalogue: %v", err)
+	}
+	ids := make(map[int64]bool)
+	names := make(map[string]bool)
+	for _, exercise := range exercises {
+		if exercise.Id == 0 || ids[exercise.Id] || names[exercise.Name] {
+			t.Errorf("exercise has empty or duplicated id or name: %#v", exercise)
+		}
+		ids[exercise.Id] = true
+		names[exercise.Name] = true
+		if exercise.Type != stores.Gym && exercise.Type != stores.Workout && exercise.Type != stores.Cardio {
+			t.Errorf("exercise has unknown type: %#v", exercise)
+		}
+		if len(exercise.PrimaryMuscles) == 0 || exercise.Equipment == "" {
+			t.Errorf("exercise has no muscles or equipment: %#v", exercise)
+		}
+		if muscles.Validate(exercise.PrimaryMuscles) != nil || muscles.Validate(exercise.SecondaryMuscles) != nil {
+			t.Errorf("exercise has muscles outside of taxonomy: %#v", exercise)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	exercises := []stores.CatalogueExercise{
+		{Id: 1, Name: "Bench press", Aliases: []string{"flat bench"}},
+		{Id: 2, Name: "Incline bench press"},
+		{Id: 3, Name: "Running", Aliases: []string{"jogging"}},
+		{Id: 4, Name: "Crunch"},
+		{Id: 5, Name: "Deadlift"},
+	}
+	data := []struct {
+		testName string
+		query    string
+		expected []int64
+	}{
+		{"exact name comes first", "bench press", []int64{1, 2}},
+		{"case and punctuation are ignored", "Bench-Press!", []int64{1, 2}},
+		{"alias", "jogging", []int64{3}},
+		{"words starting with query", "run", []int64{3}},
+		{"typo", "deadlfit", []int64{5}},
+		{"swapped words", "press bench", []int64{1, 2}},
+		{"nothing similar", "xyz", []int64{}},
+		{"empty query", " ", []int64{}},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			matches := Search(exercises, d.query, 10)
+			if len(matches) != len(d.expected) {
+				t.Fatalf("got wrong matches: %#v", matches)
+			}
+			for i, match := range matches {
+				if match.Id != d.expected[i] {
+					t.Errorf("got wrong matches: %#v", matches)
+				}
+			}
+		})
+	}
+	//exact match has the highest score and limit is applied
+	matches := Search(exercises, "bench press", 1)
+	if len(matches) != 1 || matches[0].Score != 1 {
+		t.Errorf("got wrong limited matches: %#v", matches)
+	}
+}
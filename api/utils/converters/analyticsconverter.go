@@ -0,0 +1,42 @@
+package converters
+
+import (
+	"encoding/json"
+
+	"github.com/fridrock/trainingservice/api/utils/analytics"
+)
+
+type OneRepMaxQuery struct {
+	StatsRange
+	ExerciseId int64
+	Formula    analytics.Formula
+}
+
+// ParseOneRepMaxQuery - parses request for one-rep max progress of exercise in range of dates,
+// formula is optional and is Epley if omitted
+func ParseOneRepMaxQuery(request []byte) (OneRepMaxQuery, error) {
+	statsRange, err := ParseStatsRange(request)
+	if err != nil {
+		return OneRepMaxQuery{}, err
+	}
+	var query struct {
+		ExerciseId int64  `json:"exercise_id"`
+		Formula    string `json:"formula"`
+	}
+	err = json.Unmarshal(request, &query)
+	if err != nil {
+		return OneRepMaxQuery{}, err
+	}
+	if query.ExerciseId == 0 {
+		return OneRepMaxQuery{}, emptyField
+	}
+	formula, err := analytics.ParseFormula(query.Formula)
+	if err != nil {
+		return OneRepMaxQuery{}, err
+	}
+	return OneRepMaxQuery{
+		StatsRange: statsRange,
+		ExerciseId: query.ExerciseId,
+		Formula:    formula,
+	}, nil
+}
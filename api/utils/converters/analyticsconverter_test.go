@@ -0,0 +1,59 @@
+package converters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fridrock/trainingservice/api/utils/analytics"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseOneRepMaxQuery(t *testing.T) {
+	statsRange := StatsRange{
+		UserId: 2,
+		From:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+	data := []struct {
+		testName      string
+		query         string
+		expectedQuery OneRepMaxQuery
+		expectedError error
+	}{
+		{
+			"negative case: empty exercise",
+			`{"user_id":2,"from":"2024-06-01","to":"2024-06-30"}`,
+			OneRepMaxQuery{},
+			emptyField,
+		},
+		{
+			"negative case: unknown formula",
+			`{"user_id":2,"exercise_id":1,"from":"2024-06-01","to":"2024-06-30","formula":"lombardi"}`,
+			OneRepMaxQuery{},
+			analytics.UnknownFormula,
+		},
+		{
+			"positive case: default formula",
+			`{"user_id":2,"exercise_id":1,"from":"2024-06-01","to":"2024-06-30"}`,
+			OneRepMaxQuery{StatsRange: statsRange, ExerciseId: 1, Formula: analytics.Epley},
+			nil,
+		},
+		{
+			"positive case",
+			`{"user_id":2,"exercise_id":1,"from":"2024-06-01","to":"2024-06-30","formula":"brzycki"}`,
+			OneRepMaxQuery{StatsRange: statsRange, ExerciseId: 1, Formula: analytics.Brzycki},
+			nil,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			res, err := ParseOneRepMaxQuery([]byte(d.query))
+			if err != d.expectedError {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(res, d.expectedQuery); diff != "" {
+				t.Errorf("error while parsing, got wrong values: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package converters
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// limits of amount of exercises found by catalogue search
+const (
+	DefaultCatalogueLimit = 10
+	MaxCatalogueLimit     = 50
+)
+
+var (
+	wrongLimit = errors.New("limit must be in range 1-50")
+)
+
+type CatalogueSearch struct {
+	UserId int64  `json:"user_id"`
+	Query  string `json:"query"`
+	Limit  int    `json:"limit"`
+}
+
+// ParseCatalogueSearch - parses request for catalogue search, limit is optional
+func ParseCatalogueSearch(request []byte) (search CatalogueSearch, err error) {
+	err = json.Unmarshal(request, &search)
+	if err != nil {
+		return CatalogueSearch{}, err
+	}
+	if search.Query == "" {
+		return CatalogueSearch{}, emptyField
+	}
+	if search.Limit == 0 {
+		search.Limit = DefaultCatalogueLimit
+	}
+	if search.Limit < 0 || search.Limit > MaxCatalogueLimit {
+		return CatalogueSearch{}, wrongLimit
+	}
+	return search, nil
+}
+
+type CatalogueQuery struct {
+	UserId          int64 `json:"user_id"`
+	CatalogueId     int64 `json:"catalogue_id"`
+	ExerciseGroupId int64 `json:"exercise_group_id"`
+}
+
+// ParseCatalogueQuery - parses request for catalogue exercise, group is required only for linking
+func ParseCatalogueQuery(request []byte) (query CatalogueQuery, err error) {
+	err = json.Unmarshal(request, &query)
+	if err != nil {
+		return CatalogueQuery{}, err
+	}
+	if query.CatalogueId == 0 {
+		return CatalogueQuery{}, emptyField
+	}
+	return query, nil
+}
+
+// ParseCatalogueLink - parses request for adding catalogue exercise to exercise group of user
+func ParseCatalogueLink(request []byte) (CatalogueQuery, error) {
+	query, err := ParseCatalogueQuery(request)
+	if err != nil {
+		return query, err
+	}
+	if query.UserId == 0 || query.ExerciseGroupId == 0 {
+		return CatalogueQuery{}, emptyField
+	}
+	return query, nil
+}
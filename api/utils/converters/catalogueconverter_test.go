@@ -0,0 +1,72 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseCatalogueSearch(t *testing.T) {
+	data := []struct {
+		testName       string
+		request        string
+		expectedSearch CatalogueSearch
+		expectedError  error
+	}{
+		{
+			"negative case: empty query",
+			`{"user_id":2}`,
+			CatalogueSearch{},
+			emptyField,
+		},
+		{
+			"negative case: too big limit",
+			`{"user_id":2,"query":"bench","limit":100}`,
+			CatalogueSearch{},
+			wrongLimit,
+		},
+		{
+			"positive case: default limit",
+			`{"user_id":2,"query":"bench"}`,
+			CatalogueSearch{UserId: 2, Query: "bench", Limit: DefaultCatalogueLimit},
+			nil,
+		},
+		{
+			"positive case",
+			`{"user_id":2,"query":"bench","limit":3}`,
+			CatalogueSearch{UserId: 2, Query: "bench", Limit: 3},
+			nil,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			res, err := ParseCatalogueSearch([]byte(d.request))
+			if err != d.expectedError {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(d.expectedSearch, res); diff != "" {
+				t.Errorf("error while parsing, got wrong values: %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseCatalogueLink(t *testing.T) {
+	//negative cases
+	_, err := ParseCatalogueQuery([]byte(`{"user_id":2}`))
+	if err != emptyField {
+		t.Errorf("no error with empty catalogue_id: %v", err)
+	}
+	_, err = ParseCatalogueLink([]byte(`{"user_id":2,"catalogue_id":1}`))
+	if err != emptyField {
+		t.Errorf("no error with empty exercise_group_id: %v", err)
+	}
+	//positive case
+	res, err := ParseCatalogueLink([]byte(`{"user_id":2,"catalogue_id":1,"exercise_group_id":3}`))
+	if err != nil {
+		t.Error(err)
+	}
+	if diff := cmp.Diff(CatalogueQuery{UserId: 2, CatalogueId: 1, ExerciseGroupId: 3}, res); diff != "" {
+		t.Errorf("error while parsing, got wrong values: %s", diff)
+	}
+}
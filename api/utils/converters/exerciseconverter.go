@@ -0,0 +1,71 @@
+package converters
+
+import (
+	"encoding/json"
+
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+func FromJsonToExercise(exerciseEncoded []byte) (stores.Exercise, error) {
+	var exercise stores.Exercise
+	err := json.Unmarshal(exerciseEncoded, &exercise)
+	if err != nil {
+		return exercise, err
+	}
+	if exercise.UserId == 0 || exercise.Name == "" || exercise.ExerciseTypeId == 0 || exercise.ExerciseGroupId == 0 {
+		return stores.Exercise{}, emptyField
+	}
+	return exercise, nil
+}
+
+func ParseUpdateExercise(request []byte) (stores.Exercise, error) {
+	exercise, err := FromJsonToExercise(request)
+	if err != nil {
+		return exercise, err
+	}
+	if exercise.Id == 0 {
+		return stores.Exercise{}, emptyField
+	}
+	return exercise, nil
+}
+
+type ExerciseGroupProperties struct {
+	UserId int64  `json:"user_id"`
+	Group  string `json:"group"`
+}
+
+func ParseExerciseGroupProperties(request []byte) (int64, string, error) {
+	var properties ExerciseGroupProperties
+	err := json.Unmarshal(request, &properties)
+	if err != nil {
+		return 0, "", err
+	}
+	if properties.UserId == 0 || properties.Group == "" {
+		return 0, "", emptyField
+	}
+	return properties.UserId, properties.Group, nil
+}
+
+// ExerciseQuery - request for finding or deleting exercise of user by name, group is optional and is required only
+// if user has exercises with the name in several groups
+type ExerciseQuery struct {
+	UserId int64  `json:"user_id"`
+	Name   string `json:"name"`
+	Group  string `json:"group"`
+}
+
+// ParseExerciseQuery - parses request for finding or deleting exercise of user by name, user_id and name are
+// checked like in ParseExGroupProperties
+func ParseExerciseQuery(request []byte) (ExerciseQuery, error) {
+	userId, name, err := ParseExGroupProperties(request)
+	if err != nil {
+		return ExerciseQuery{}, err
+	}
+	query := ExerciseQuery{}
+	err = json.Unmarshal(request, &query)
+	if err != nil {
+		return ExerciseQuery{}, err
+	}
+	query.UserId, query.Name = userId, name
+	return query, nil
+}
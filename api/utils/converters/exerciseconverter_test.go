@@ -0,0 +1,99 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFromJsonToExercise(t *testing.T) {
+	data := []struct {
+		testName         string
+		query            string
+		expectedExercise stores.Exercise
+		expectedError    error
+	}{
+		{
+			"negative case: empty group",
+			`{"user_id":2,"name":"Pull up","exercise_type_id":2}`,
+			stores.Exercise{},
+			emptyField,
+		},
+		{
+			"positive case",
+			`{"user_id":2,"name":"Pull up","rest":90,"exercise_type_id":2,"exercise_group_id":1}`,
+			stores.Exercise{
+				Name:            "Pull up",
+				Rest:            90,
+				ExerciseTypeId:  2,
+				UserId:          2,
+				ExerciseGroupId: 1,
+			},
+			nil,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			res, err := FromJsonToExercise([]byte(d.query))
+			if err != d.expectedError {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(res, d.expectedExercise); diff != "" {
+				t.Errorf("error while parsing, got wrong values: %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseUpdateExercise(t *testing.T) {
+	//negative case
+	_, err := ParseUpdateExercise([]byte(`{"user_id":2,"name":"Pull up","exercise_type_id":2,"exercise_group_id":1}`))
+	if err != emptyField {
+		t.Errorf("no error with empty id: %v", err)
+	}
+	//positive case
+	exercise, err := ParseUpdateExercise([]byte(`{"id":4,"user_id":2,"name":"Pull up","exercise_type_id":2,"exercise_group_id":1}`))
+	if err != nil {
+		t.Error(err)
+	}
+	if exercise.Id != 4 {
+		t.Error("got wrong id")
+	}
+}
+
+func TestParseExerciseGroupProperties(t *testing.T) {
+	//positive case
+	userId, group, err := ParseExerciseGroupProperties([]byte(`{"user_id":3,"group":"Back"}`))
+	if err != nil {
+		t.Error(err)
+	}
+	if userId != 3 || group != "Back" {
+		t.Error("got wrong values")
+	}
+	//negative case
+	userId, group, err = ParseExerciseGroupProperties([]byte(`{"user_id":3}`))
+	if err == nil || userId != 0 || group != "" {
+		t.Error("no error with empty field group")
+	}
+}
+
+func TestParseExerciseQuery(t *testing.T) {
+	//positive cases
+	query, err := ParseExerciseQuery([]byte(`{"user_id":3,"name":"Pull up"}`))
+	if err != nil {
+		t.Error(err)
+	}
+	if query != (ExerciseQuery{UserId: 3, Name: "Pull up"}) {
+		t.Errorf("got wrong query: %#v", query)
+	}
+	query, err = ParseExerciseQuery([]byte(`{"user_id":3,"name":"Pull up","group":"Back"}`))
+	if err != nil || query.Group != "Back" {
+		t.Errorf("got wrong query with group: %#v, %v", query, err)
+	}
+	//negative case
+	query, err = ParseExerciseQuery([]byte(`{"user_id":3,"group":"Back"}`))
+	if err == nil || query != (ExerciseQuery{}) {
+		t.Error("no error with empty field name")
+	}
+}
@@ -0,0 +1,96 @@
+package converters
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+var (
+	wrongRpe          = errors.New("rpe must be in range 1-10")
+	negativeMetric    = errors.New("metrics of set can't be negative")
+	wrongHeartRate    = errors.New("max heart rate can't be less than average one")
+	incompleteGym     = errors.New("gym set requires weight and reps")
+	incompleteWorkout = errors.New("workout set requires reps or duration")
+	incompleteCardio  = errors.New("cardio set requires duration or distance")
+	unknownType       = errors.New("unknown exercise type")
+)
+
+// FromJsonToExerciseSet - parses set, pace and speed are derived from distance and duration, so passed ones are
+// ignored. Metrics required by type of exercise are checked by ValidateSet
+func FromJsonToExerciseSet(setEncoded []byte) (stores.ExerciseSet, error) {
+	var set stores.ExerciseSet
+	err := json.Unmarshal(setEncoded, &set)
+	if err != nil {
+		return set, err
+	}
+	if set.UserId == 0 || set.ExerciseId == 0 {
+		return stores.ExerciseSet{}, emptyField
+	}
+	if set.Weight == 0 && set.Reps == 0 && set.Duration == 0 && set.Distance == 0 {
+		return stores.ExerciseSet{}, emptyField
+	}
+	err = validateMetrics(set)
+	if err != nil {
+		return stores.ExerciseSet{}, err
+	}
+	set.Pace = 0
+	set.Speed = 0
+	return set, nil
+}
+
+// validateMetrics - checks, that metrics of set aren't negative, max heart rate isn't less than average one and rpe
+// is omitted or in range 1-10
+func validateMetrics(set stores.ExerciseSet) error {
+	if set.Weight < 0 || set.Reps < 0 || set.Duration < 0 || set.Distance < 0 || set.Elevation < 0 ||
+		set.AvgHeartRate < 0 || set.MaxHeartRate < 0 || set.Calories < 0 {
+		return negativeMetric
+	}
+	if set.AvgHeartRate > 0 && set.MaxHeartRate > 0 && set.MaxHeartRate < set.AvgHeartRate {
+		return wrongHeartRate
+	}
+	if set.Rpe != 0 && (set.Rpe < 1 || set.Rpe > 10) {
+		return wrongRpe
+	}
+	return nil
+}
+
+// ValidateSet - checks, that set has metrics required by type of its exercise: gym sets need weight and reps,
+// workout sets need reps or duration, cardio sets need duration or distance
+func ValidateSet(set stores.ExerciseSet, exerciseType stores.ExerciseType) error {
+	switch exerciseType.Name {
+	case stores.Gym:
+		if set.Weight == 0 || set.Reps == 0 {
+			return incompleteGym
+		}
+	case stores.Workout:
+		if set.Reps == 0 && set.Duration == 0 {
+			return incompleteWorkout
+		}
+	case stores.Cardio:
+		if set.Duration == 0 && set.Distance == 0 {
+			return incompleteCardio
+		}
+	default:
+		return unknownType
+	}
+	return nil
+}
+
+type ListSets struct {
+	UserId     int64 `json:"user_id"`
+	TrainingId int64 `json:"training_id"`
+}
+
+// ParseListSets - parses request for listing sets, training_id is optional and means current training if omitted
+func ParseListSets(request []byte) (listQuery ListSets, err error) {
+	err = json.Unmarshal(request, &listQuery)
+	if err != nil {
+		return listQuery, err
+	}
+	if listQuery.UserId == 0 {
+		err = emptyField
+	}
+	return listQuery, err
+}
@@ -0,0 +1,134 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFromJsonToExerciseSet(t *testing.T) {
+	data := []struct {
+		testName      string
+		query         string
+		expectedSet   stores.ExerciseSet
+		expectedError error
+	}{
+		{
+			"negative case: no exercise",
+			`{"user_id":2,"reps":10}`,
+			stores.ExerciseSet{},
+			emptyField,
+		},
+		{
+			"negative case: no metrics",
+			`{"user_id":2,"exercise_id":1}`,
+			stores.ExerciseSet{},
+			emptyField,
+		},
+		{
+			"negative case: wrong rpe",
+			`{"user_id":2,"exercise_id":1,"reps":10,"rpe":11}`,
+			stores.ExerciseSet{},
+			wrongRpe,
+		},
+		{
+			"negative case: rpe below 1",
+			`{"user_id":2,"exercise_id":1,"reps":10,"rpe":0.5}`,
+			stores.ExerciseSet{},
+			wrongRpe,
+		},
+		{
+			"negative case: negative distance",
+			`{"user_id":2,"exercise_id":1,"duration":600,"distance":-1}`,
+			stores.ExerciseSet{},
+			negativeMetric,
+		},
+		{
+			"negative case: max heart rate below average",
+			`{"user_id":2,"exercise_id":1,"duration":600,"avg_heart_rate":150,"max_heart_rate":140}`,
+			stores.ExerciseSet{},
+			wrongHeartRate,
+		},
+		{
+			"positive case",
+			`{"user_id":2,"exercise_id":1,"weight":62.5,"reps":8}`,
+			stores.ExerciseSet{
+				UserId:     2,
+				ExerciseId: 1,
+				Weight:     62.5,
+				Reps:       8,
+			},
+			nil,
+		},
+		{
+			"positive case: cardio metrics, derived pace is ignored",
+			`{"user_id":2,"exercise_id":3,"distance":5,"duration":1500,"avg_heart_rate":150,"max_heart_rate":172,"pace":1}`,
+			stores.ExerciseSet{
+				UserId:       2,
+				ExerciseId:   3,
+				Duration:     1500,
+				Distance:     5,
+				AvgHeartRate: 150,
+				MaxHeartRate: 172,
+			},
+			nil,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			res, err := FromJsonToExerciseSet([]byte(d.query))
+			if err != d.expectedError {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(res, d.expectedSet); diff != "" {
+				t.Errorf("error while parsing, got wrong values: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateSet(t *testing.T) {
+	gym := stores.ExerciseType{Id: 3, Name: stores.Gym}
+	workout := stores.ExerciseType{Id: 2, Name: stores.Workout}
+	cardio := stores.ExerciseType{Id: 1, Name: stores.Cardio}
+	data := []struct {
+		testName      string
+		set           stores.ExerciseSet
+		exerciseType  stores.ExerciseType
+		expectedError error
+	}{
+		{"gym set with weight and reps", stores.ExerciseSet{Weight: 60, Reps: 10}, gym, nil},
+		{"gym set without weight", stores.ExerciseSet{Reps: 10}, gym, incompleteGym},
+		{"workout set with reps", stores.ExerciseSet{Reps: 20}, workout, nil},
+		{"workout set with duration", stores.ExerciseSet{Duration: 60}, workout, nil},
+		{"workout set with weight only", stores.ExerciseSet{Weight: 20}, workout, incompleteWorkout},
+		{"cardio set with distance", stores.ExerciseSet{Distance: 5}, cardio, nil},
+		{"cardio set with duration", stores.ExerciseSet{Duration: 1200}, cardio, nil},
+		{"cardio set with reps only", stores.ExerciseSet{Reps: 10}, cardio, incompleteCardio},
+		{"unknown type", stores.ExerciseSet{Reps: 10}, stores.ExerciseType{Id: 4, Name: "SWIM"}, unknownType},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			if err := ValidateSet(d.set, d.exerciseType); err != d.expectedError {
+				t.Errorf("wrong validation: %v", err)
+			}
+		})
+	}
+}
+
+func TestParseListSets(t *testing.T) {
+	//negative case
+	_, err := ParseListSets([]byte(`{"training_id":3}`))
+	if err != emptyField {
+		t.Errorf("no error with empty user_id: %v", err)
+	}
+	//positive case
+	res, err := ParseListSets([]byte(`{"user_id":2,"training_id":3}`))
+	if err != nil {
+		t.Error(err)
+	}
+	if diff := cmp.Diff(res, ListSets{UserId: 2, TrainingId: 3}); diff != "" {
+		t.Errorf("error while parsing, got wrong values: %s", diff)
+	}
+}
@@ -0,0 +1,22 @@
+package converters
+
+import (
+	"encoding/json"
+)
+
+type ExerciseTypeQuery struct {
+	Id int64 `json:"id"`
+}
+
+// ParseExerciseTypeQuery - parses request for exercise type, returns its id
+func ParseExerciseTypeQuery(request []byte) (int64, error) {
+	var query ExerciseTypeQuery
+	err := json.Unmarshal(request, &query)
+	if err != nil {
+		return 0, err
+	}
+	if query.Id == 0 {
+		return 0, emptyField
+	}
+	return query.Id, nil
+}
@@ -0,0 +1,18 @@
+package converters
+
+import (
+	"testing"
+)
+
+func TestParseExerciseTypeQuery(t *testing.T) {
+	//negative case
+	_, err := ParseExerciseTypeQuery([]byte(`{"user_id":2}`))
+	if err != emptyField {
+		t.Errorf("no error with empty id: %v", err)
+	}
+	//positive case
+	id, err := ParseExerciseTypeQuery([]byte(`{"id":3}`))
+	if err != nil || id != 3 {
+		t.Errorf("error while parsing, got: %d, %v", id, err)
+	}
+}
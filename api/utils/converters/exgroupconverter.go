@@ -3,12 +3,27 @@ package converters
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 
 	"github.com/fridrock/trainingservice/db/stores"
 )
 
 var (
-	emptyField = errors.New("empty Field")
+	emptyField  = errors.New("empty Field")
+	unknownMode = errors.New("unknown delete mode")
+	sameTarget  = errors.New("exercises can't be moved to the deleted group")
+)
+
+// DeleteMode - what happens with exercises of deleted exercise group
+type DeleteMode string
+
+const (
+	// Restrict - group with exercises isn't deleted
+	Restrict DeleteMode = "restrict"
+	// Move - exercises are moved to target group, then group is deleted
+	Move DeleteMode = "move"
+	// Cascade - group is soft-deleted together with its exercises and can be restored
+	Cascade DeleteMode = "cascade"
 )
 
 func ExGroupToJson(exg stores.ExGroup) ([]byte, error) {
@@ -42,6 +57,41 @@ func ParseExGroupProperties(request []byte) (int64, string, error) {
 	return properties.UserId, properties.Name, err
 }
 
+type DeleteExGroup struct {
+	UserId int64      `json:"user_id"`
+	Name   string     `json:"name"`
+	Mode   DeleteMode `json:"mode"`
+	Target string     `json:"target"`
+}
+
+// ParseDeleteExGroup - parses request for deleting group, mode is optional and is Restrict if omitted,
+// target is name of group, which exercises are moved to, it is required only by Move mode
+func ParseDeleteExGroup(request []byte) (DeleteExGroup, error) {
+	var deleteQuery DeleteExGroup
+	err := json.Unmarshal(request, &deleteQuery)
+	if err != nil {
+		return DeleteExGroup{}, err
+	}
+	if deleteQuery.UserId == 0 || deleteQuery.Name == "" {
+		return DeleteExGroup{}, emptyField
+	}
+	switch deleteQuery.Mode {
+	case "":
+		deleteQuery.Mode = Restrict
+	case Restrict, Cascade:
+	case Move:
+		if deleteQuery.Target == "" {
+			return DeleteExGroup{}, emptyField
+		}
+		if strings.EqualFold(deleteQuery.Target, deleteQuery.Name) {
+			return DeleteExGroup{}, sameTarget
+		}
+	default:
+		return DeleteExGroup{}, unknownMode
+	}
+	return deleteQuery, nil
+}
+
 type UpdateExGroup struct {
 	UserId  int64  `json:"user_id"`
 	Name    string `json:"name"`
@@ -77,3 +127,16 @@ func ParseUserID(request []byte) (int64, error) {
 	}
 	return userIdRequest.UserId, nil
 }
+
+type IdempotencyKey struct {
+	Key string `json:"idempotency_key"`
+}
+
+// ParseIdempotencyKey - returns optional idempotency_key of request, empty if it is absent
+func ParseIdempotencyKey(request []byte) string {
+	var idempotencyKey IdempotencyKey
+	if err := json.Unmarshal(request, &idempotencyKey); err != nil {
+		return ""
+	}
+	return idempotencyKey.Key
+}
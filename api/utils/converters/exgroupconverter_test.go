@@ -120,3 +120,46 @@ func TestParseFindByUser(t *testing.T) {
 		})
 	}
 }
+
+func TestParseIdempotencyKey(t *testing.T) {
+	if key := ParseIdempotencyKey([]byte(`{"user_id":2}`)); key != "" {
+		t.Errorf("got key of request without it: %s", key)
+	}
+	if key := ParseIdempotencyKey([]byte(`{"user_id":2,"idempotency_key":"abc"}`)); key != "abc" {
+		t.Errorf("got wrong key: %s", key)
+	}
+}
+
+func TestParseDeleteExGroup(t *testing.T) {
+	data := []struct {
+		testName      string
+		query         string
+		expectedQuery DeleteExGroup
+		expectedError error
+	}{
+		{"without name", `{"user_id":2}`, DeleteExGroup{}, emptyField},
+		{"default mode", `{"user_id":2,"name":"Back"}`, DeleteExGroup{UserId: 2, Name: "Back", Mode: Restrict}, nil},
+		{"cascade", `{"user_id":2,"name":"Back","mode":"cascade"}`, DeleteExGroup{UserId: 2, Name: "Back", Mode: Cascade}, nil},
+		{"unknown mode", `{"user_id":2,"name":"Back","mode":"drop"}`, DeleteExGroup{}, unknownMode},
+		{"move without target", `{"user_id":2,"name":"Back","mode":"move"}`, DeleteExGroup{}, emptyField},
+		{"move to itself", `{"user_id":2,"name":"Back","mode":"move","target":"Back"}`, DeleteExGroup{}, sameTarget},
+		{"move to itself in other case", `{"user_id":2,"name":"Back","mode":"move","target":"back"}`, DeleteExGroup{}, sameTarget},
+		{
+			"move",
+			`{"user_id":2,"name":"Back","mode":"move","target":"Pull"}`,
+			DeleteExGroup{UserId: 2, Name: "Back", Mode: Move, Target: "Pull"},
+			nil,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			deleteQuery, err := ParseDeleteExGroup([]byte(d.query))
+			if err != d.expectedError {
+				t.Errorf("got wrong error: %v", err)
+			}
+			if diff := cmp.Diff(d.expectedQuery, deleteQuery); diff != "" {
+				t.Errorf("got wrong query: %s", diff)
+			}
+		})
+	}
+}
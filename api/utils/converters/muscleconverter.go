@@ -0,0 +1,42 @@
+package converters
+
+import (
+	"encoding/json"
+
+	"github.com/fridrock/trainingservice/api/utils/muscles"
+)
+
+type MuscleMapping struct {
+	UserId  int64    `json:"user_id"`
+	Name    string   `json:"name"`
+	Group   string   `json:"group"`
+	Muscles []string `json:"muscles"`
+}
+
+// ParseMuscleMapping - parses request for mapping exercise group or exercise with name to muscles of taxonomy,
+// muscles are required, but can be empty to remove mapping. Repeated muscles are dropped. Group is optional and
+// narrows search of exercise like in ParseExerciseQuery
+func ParseMuscleMapping(request []byte) (MuscleMapping, error) {
+	var mapping MuscleMapping
+	err := json.Unmarshal(request, &mapping)
+	if err != nil {
+		return MuscleMapping{}, err
+	}
+	if mapping.UserId == 0 || mapping.Name == "" || mapping.Muscles == nil {
+		return MuscleMapping{}, emptyField
+	}
+	err = muscles.Validate(mapping.Muscles)
+	if err != nil {
+		return MuscleMapping{}, err
+	}
+	unique := []string{}
+	seen := make(map[string]bool)
+	for _, muscle := range mapping.Muscles {
+		if !seen[muscle] {
+			seen[muscle] = true
+			unique = append(unique, muscle)
+		}
+	}
+	mapping.Muscles = unique
+	return mapping, nil
+}
@@ -0,0 +1,44 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/fridrock/trainingservice/api/utils/muscles"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseMuscleMapping(t *testing.T) {
+	data := []struct {
+		testName        string
+		request         string
+		expectedMapping MuscleMapping
+		expectedError   error
+	}{
+		{"without muscles", `{"user_id":2,"name":"Back"}`, MuscleMapping{}, emptyField},
+		{"without name", `{"user_id":2,"muscles":["lats"]}`, MuscleMapping{}, emptyField},
+		{"unknown muscle", `{"user_id":2,"name":"Back","muscles":["lats","wings"]}`, MuscleMapping{}, muscles.UnknownMuscle},
+		{
+			"repeated muscles",
+			`{"user_id":2,"name":"Back","muscles":["lats","traps","lats"]}`,
+			MuscleMapping{UserId: 2, Name: "Back", Muscles: []string{"lats", "traps"}},
+			nil,
+		},
+		{
+			"removing mapping",
+			`{"user_id":2,"name":"Back","muscles":[]}`,
+			MuscleMapping{UserId: 2, Name: "Back", Muscles: []string{}},
+			nil,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			mapping, err := ParseMuscleMapping([]byte(d.request))
+			if err != d.expectedError {
+				t.Errorf("got wrong error: %v", err)
+			}
+			if diff := cmp.Diff(d.expectedMapping, mapping); diff != "" {
+				t.Errorf("got wrong mapping: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package converters
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+var (
+	wrongDeload = errors.New("deload factor must be in (0, 1]")
+)
+
+// DefaultDeloadFactor - factor of weights in deload weeks, if program doesn't set it
+const DefaultDeloadFactor = 0.9
+
+// FromJsonToProgram - parses program, it must have name, weeks and at least one workout day
+func FromJsonToProgram(programEncoded []byte) (stores.Program, error) {
+	var program stores.Program
+	err := json.Unmarshal(programEncoded, &program)
+	if err != nil {
+		return program, err
+	}
+	if program.UserId == 0 || program.Name == "" || program.Weeks <= 0 || len(program.Days) == 0 {
+		return stores.Program{}, emptyField
+	}
+	for _, day := range program.Days {
+		if day.TemplateId == 0 {
+			return stores.Program{}, emptyField
+		}
+	}
+	for _, progression := range program.Progressions {
+		if progression.ExerciseId == 0 {
+			return stores.Program{}, emptyField
+		}
+	}
+	if program.DeloadEvery < 0 {
+		program.DeloadEvery = 0
+	}
+	if program.DeloadFactor == 0 {
+		program.DeloadFactor = DefaultDeloadFactor
+	}
+	if program.DeloadFactor < 0 || program.DeloadFactor > 1 {
+		return stores.Program{}, wrongDeload
+	}
+	return program, nil
+}
+
+type ProgramQuery struct {
+	UserId    int64 `json:"user_id"`
+	ProgramId int64 `json:"program_id"`
+}
+
+func ParseProgramQuery(request []byte) (query ProgramQuery, err error) {
+	err = json.Unmarshal(request, &query)
+	if err != nil {
+		return query, err
+	}
+	if query.UserId == 0 || query.ProgramId == 0 {
+		err = emptyField
+	}
+	return query, err
+}
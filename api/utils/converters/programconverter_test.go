@@ -0,0 +1,72 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFromJsonToProgram(t *testing.T) {
+	data := []struct {
+		testName        string
+		program         string
+		expectedProgram stores.Program
+		expectedError   error
+	}{
+		{
+			"negative case: without days",
+			`{"user_id":2,"name":"Strength","weeks":8}`,
+			stores.Program{},
+			emptyField,
+		},
+		{
+			"negative case: wrong deload factor",
+			`{"user_id":2,"name":"Strength","weeks":8,"deload_every":4,"deload_factor":1.5,"days":[{"template_id":1}]}`,
+			stores.Program{},
+			wrongDeload,
+		},
+		{
+			"positive case: default deload factor",
+			`{"user_id":2,"name":"Strength","weeks":8,"deload_every":4,"days":[{"template_id":1},{"template_id":2}],
+				"progressions":[{"exercise_id":1,"increment":2.5}]}`,
+			stores.Program{
+				UserId:       2,
+				Name:         "Strength",
+				Weeks:        8,
+				DeloadEvery:  4,
+				DeloadFactor: DefaultDeloadFactor,
+				Days:         []stores.ProgramDay{{TemplateId: 1}, {TemplateId: 2}},
+				Progressions: []stores.Progression{{ExerciseId: 1, Increment: 2.5}},
+			},
+			nil,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			res, err := FromJsonToProgram([]byte(d.program))
+			if err != d.expectedError {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(res, d.expectedProgram); diff != "" {
+				t.Errorf("error while parsing, got wrong values: %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseProgramQuery(t *testing.T) {
+	//negative case
+	_, err := ParseProgramQuery([]byte(`{"user_id":2}`))
+	if err != emptyField {
+		t.Errorf("no error with empty program_id: %v", err)
+	}
+	//positive case
+	res, err := ParseProgramQuery([]byte(`{"user_id":2,"program_id":3}`))
+	if err != nil {
+		t.Error(err)
+	}
+	if diff := cmp.Diff(res, ProgramQuery{UserId: 2, ProgramId: 3}); diff != "" {
+		t.Errorf("error while parsing, got wrong values: %s", diff)
+	}
+}
@@ -0,0 +1,20 @@
+package converters
+
+import "encoding/json"
+
+type ListRecords struct {
+	UserId     int64 `json:"user_id"`
+	ExerciseId int64 `json:"exercise_id"`
+}
+
+// ParseListRecords - parses request for listing personal records, exercise_id is optional and means all exercises if omitted
+func ParseListRecords(request []byte) (listQuery ListRecords, err error) {
+	err = json.Unmarshal(request, &listQuery)
+	if err != nil {
+		return listQuery, err
+	}
+	if listQuery.UserId == 0 {
+		err = emptyField
+	}
+	return listQuery, err
+}
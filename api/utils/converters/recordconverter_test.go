@@ -0,0 +1,23 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseListRecords(t *testing.T) {
+	//negative case
+	_, err := ParseListRecords([]byte(`{"exercise_id":3}`))
+	if err != emptyField {
+		t.Errorf("no error with empty user_id: %v", err)
+	}
+	//positive case
+	res, err := ParseListRecords([]byte(`{"user_id":2}`))
+	if err != nil {
+		t.Error(err)
+	}
+	if diff := cmp.Diff(res, ListRecords{UserId: 2}); diff != "" {
+		t.Errorf("error while parsing, got wrong values: %s", diff)
+	}
+}
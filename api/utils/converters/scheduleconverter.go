@@ -0,0 +1,49 @@
+package converters
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/fridrock/trainingservice/api/utils/reminder"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+var (
+	duplicateSlot = errors.New("slot is repeated in schedule")
+)
+
+// FromJsonToSchedule - parses schedule, it must have at least one slot. Weekdays are normalized to short names,
+// empty timezone is left to be replaced with timezone of user
+func FromJsonToSchedule(scheduleEncoded []byte) (stores.Schedule, error) {
+	var schedule stores.Schedule
+	err := json.Unmarshal(scheduleEncoded, &schedule)
+	if err != nil {
+		return schedule, err
+	}
+	if schedule.UserId == 0 || len(schedule.Slots) == 0 {
+		return stores.Schedule{}, emptyField
+	}
+	if schedule.Timezone != "" {
+		_, err = reminder.LoadLocation(schedule.Timezone)
+		if err != nil {
+			return stores.Schedule{}, err
+		}
+	}
+	seen := make(map[string]bool)
+	for i, slot := range schedule.Slots {
+		slot.Weekday, err = reminder.ParseWeekday(slot.Weekday)
+		if err != nil {
+			return stores.Schedule{}, err
+		}
+		_, _, err = reminder.ParseClock(slot.At)
+		if err != nil {
+			return stores.Schedule{}, err
+		}
+		if seen[slot.Weekday+slot.At] {
+			return stores.Schedule{}, duplicateSlot
+		}
+		seen[slot.Weekday+slot.At] = true
+		schedule.Slots[i] = slot
+	}
+	return schedule, nil
+}
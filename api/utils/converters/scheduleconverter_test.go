@@ -0,0 +1,63 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/fridrock/trainingservice/api/utils/reminder"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFromJsonToSchedule(t *testing.T) {
+	data := []struct {
+		testName         string
+		schedule         string
+		expectedSchedule stores.Schedule
+		expectedError    error
+	}{
+		{
+			"negative case: without slots",
+			`{"user_id":2,"timezone":"Europe/Moscow"}`,
+			stores.Schedule{},
+			emptyField,
+		},
+		{
+			"negative case: unknown timezone",
+			`{"user_id":2,"timezone":"Mars/Olympus","slots":[{"weekday":"mon","at":"18:00"}]}`,
+			stores.Schedule{},
+			reminder.UnknownTimezone,
+		},
+		{
+			"negative case: wrong time",
+			`{"user_id":2,"slots":[{"weekday":"mon","at":"6pm"}]}`,
+			stores.Schedule{},
+			reminder.WrongClock,
+		},
+		{
+			"negative case: repeated slot",
+			`{"user_id":2,"slots":[{"weekday":"mon","at":"18:00"},{"weekday":"Monday","at":"18:00"}]}`,
+			stores.Schedule{},
+			duplicateSlot,
+		},
+		{
+			"positive case: without timezone",
+			`{"user_id":2,"slots":[{"weekday":"Monday","at":"18:00"},{"weekday":"fri","at":"07:30"}]}`,
+			stores.Schedule{
+				UserId: 2,
+				Slots:  []stores.ScheduleSlot{{Weekday: "mon", At: "18:00"}, {Weekday: "fri", At: "07:30"}},
+			},
+			nil,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			res, err := FromJsonToSchedule([]byte(d.schedule))
+			if err != d.expectedError {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(d.expectedSchedule, res); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package converters
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/fridrock/trainingservice/api/utils/reminder"
+	"github.com/fridrock/trainingservice/api/utils/units"
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+var (
+	negativeRest = errors.New("rest must not be negative")
+)
+
+// FromJsonToSettings - applies fields passed in request to current settings of user, omitted ones stay the same.
+// Timezone must be IANA name of location, week start is normalized to short name of weekday
+func FromJsonToSettings(settingsEncoded []byte, current stores.Settings) (stores.Settings, error) {
+	settings := current
+	err := json.Unmarshal(settingsEncoded, &settings)
+	if err != nil {
+		return stores.Settings{}, err
+	}
+	if settings.UserId == 0 || settings.Timezone == "" {
+		return stores.Settings{}, emptyField
+	}
+	err = units.ValidateWeightUnit(settings.WeightUnit)
+	if err != nil {
+		return stores.Settings{}, err
+	}
+	err = units.ValidateDistanceUnit(settings.DistanceUnit)
+	if err != nil {
+		return stores.Settings{}, err
+	}
+	_, err = reminder.LoadLocation(settings.Timezone)
+	if err != nil {
+		return stores.Settings{}, err
+	}
+	if settings.DefaultRest < 0 {
+		return stores.Settings{}, negativeRest
+	}
+	settings.WeekStart, err = reminder.ParseWeekday(settings.WeekStart)
+	if err != nil {
+		return stores.Settings{}, err
+	}
+	return settings, nil
+}
@@ -0,0 +1,80 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/fridrock/trainingservice/api/utils/reminder"
+	"github.com/fridrock/trainingservice/api/utils/units"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFromJsonToSettings(t *testing.T) {
+	data := []struct {
+		testName         string
+		settings         string
+		expectedSettings stores.Settings
+		expectedError    error
+	}{
+		{
+			"negative case: empty timezone",
+			`{"user_id":2,"timezone":""}`,
+			stores.Settings{},
+			emptyField,
+		},
+		{
+			"negative case: unknown timezone",
+			`{"user_id":2,"timezone":"Moscow"}`,
+			stores.Settings{},
+			reminder.UnknownTimezone,
+		},
+		{
+			"negative case: unknown weight unit",
+			`{"user_id":2,"weight_unit":"st"}`,
+			stores.Settings{},
+			units.UnknownWeightUnit,
+		},
+		{
+			"negative case: negative rest",
+			`{"user_id":2,"default_rest":-60}`,
+			stores.Settings{},
+			negativeRest,
+		},
+		{
+			"positive case: only timezone",
+			`{"user_id":2,"timezone":"Europe/Moscow"}`,
+			stores.Settings{
+				UserId:       2,
+				WeightUnit:   "kg",
+				DistanceUnit: "km",
+				Timezone:     "Europe/Moscow",
+				WeekStart:    "mon",
+			},
+			nil,
+		},
+		{
+			"positive case: all fields",
+			`{"user_id":2,"weight_unit":"lb","distance_unit":"mi","timezone":"UTC","default_rest":90,"week_start":"Sunday"}`,
+			stores.Settings{
+				UserId:       2,
+				WeightUnit:   "lb",
+				DistanceUnit: "mi",
+				Timezone:     "UTC",
+				DefaultRest:  90,
+				WeekStart:    "sun",
+			},
+			nil,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			res, err := FromJsonToSettings([]byte(d.settings), stores.DefaultSettings(2))
+			if err != d.expectedError {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(d.expectedSettings, res); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package converters
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	wrongRange = errors.New("wrong date range")
+)
+
+// DateLayout - layout of dates in requests
+const DateLayout = "2006-01-02"
+
+type StatsRange struct {
+	UserId int64     `json:"user_id"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+}
+
+// ParseStatsRange - parses request with user_id and inclusive range of dates "from" and "to",
+// returned To is the beginning of the day after "to"
+func ParseStatsRange(request []byte) (StatsRange, error) {
+	var rangeQuery struct {
+		UserId int64  `json:"user_id"`
+		From   string `json:"from"`
+		To     string `json:"to"`
+	}
+	err := json.Unmarshal(request, &rangeQuery)
+	if err != nil {
+		return StatsRange{}, err
+	}
+	if rangeQuery.UserId == 0 || rangeQuery.From == "" || rangeQuery.To == "" {
+		return StatsRange{}, emptyField
+	}
+	from, err := time.Parse(DateLayout, rangeQuery.From)
+	if err != nil {
+		return StatsRange{}, err
+	}
+	to, err := time.Parse(DateLayout, rangeQuery.To)
+	if err != nil {
+		return StatsRange{}, err
+	}
+	if to.Before(from) {
+		return StatsRange{}, wrongRange
+	}
+	return StatsRange{
+		UserId: rangeQuery.UserId,
+		From:   from,
+		To:     to.AddDate(0, 0, 1),
+	}, nil
+}
+
+// In - returns range of the same dates in loc, so range starts and ends at midnights of user
+func (sr StatsRange) In(loc *time.Location) StatsRange {
+	sr.From = time.Date(sr.From.Year(), sr.From.Month(), sr.From.Day(), 0, 0, 0, 0, loc)
+	sr.To = time.Date(sr.To.Year(), sr.To.Month(), sr.To.Day(), 0, 0, 0, 0, loc)
+	return sr
+}
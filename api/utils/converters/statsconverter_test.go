@@ -0,0 +1,70 @@
+package converters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseStatsRange(t *testing.T) {
+	data := []struct {
+		testName      string
+		query         string
+		expectedRange StatsRange
+		expectedError error
+	}{
+		{
+			"negative case: empty field",
+			`{"user_id":2,"from":"2024-06-01"}`,
+			StatsRange{},
+			emptyField,
+		},
+		{
+			"negative case: to before from",
+			`{"user_id":2,"from":"2024-06-30","to":"2024-06-01"}`,
+			StatsRange{},
+			wrongRange,
+		},
+		{
+			"positive case",
+			`{"user_id":2,"from":"2024-06-01","to":"2024-06-30"}`,
+			StatsRange{
+				UserId: 2,
+				From:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+				To:     time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			},
+			nil,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			res, err := ParseStatsRange([]byte(d.query))
+			if err != d.expectedError {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(res, d.expectedRange); diff != "" {
+				t.Errorf("error while parsing, got wrong values: %s", diff)
+			}
+		})
+	}
+	//wrong date format
+	_, err := ParseStatsRange([]byte(`{"user_id":2,"from":"01.06.2024","to":"2024-06-30"}`))
+	if err == nil {
+		t.Error("no error with wrong date format")
+	}
+}
+
+func TestStatsRangeIn(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	statsRange, _ := ParseStatsRange([]byte(`{"user_id":2,"from":"2024-03-30","to":"2024-03-31"}`))
+	statsRange = statsRange.In(berlin)
+	//range contains transition to summer time, so it is one hour shorter
+	if !statsRange.From.Equal(time.Date(2024, 3, 29, 23, 0, 0, 0, time.UTC)) ||
+		!statsRange.To.Equal(time.Date(2024, 3, 31, 22, 0, 0, 0, time.UTC)) {
+		t.Errorf("got wrong range in location: %v - %v", statsRange.From, statsRange.To)
+	}
+	if statsRange.To.Sub(statsRange.From) != 47*time.Hour {
+		t.Errorf("got wrong length of range: %v", statsRange.To.Sub(statsRange.From))
+	}
+}
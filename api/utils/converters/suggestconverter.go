@@ -0,0 +1,63 @@
+package converters
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/fridrock/trainingservice/api/utils/suggest"
+)
+
+// DefaultSuggestSessions - amount of the last sessions of exercise, which are used for suggestion, MaxSuggestSessions
+// is the greatest amount, which can be requested
+const (
+	DefaultSuggestSessions = 3
+	MaxSuggestSessions     = 20
+)
+
+var (
+	wrongRepsRange    = errors.New("min_reps can't be greater than max_reps")
+	negativeParameter = errors.New("parameters of strategy can't be negative")
+	tooManySessions   = errors.New("sessions must be from 1 to 20")
+)
+
+type SuggestQuery struct {
+	UserId     int64  `json:"user_id"`
+	ExerciseId int64  `json:"exercise_id"`
+	Strategy   string `json:"strategy"`
+	Sessions   int64  `json:"sessions"`
+	suggest.Config
+}
+
+// ParseSuggestQuery - parses request for suggestion of the next set, strategy must be registered in suggest package.
+// Omitted parameters and sessions are zero, they are replaced with defaults
+func ParseSuggestQuery(request []byte) (query SuggestQuery, err error) {
+	err = json.Unmarshal(request, &query)
+	if err != nil {
+		return SuggestQuery{}, err
+	}
+	if query.UserId == 0 || query.ExerciseId == 0 {
+		return SuggestQuery{}, emptyField
+	}
+	config := query.Config
+	if config.MinReps < 0 || config.MaxReps < 0 || config.TargetReps < 0 || config.TargetRpe < 0 ||
+		config.Increment < 0 {
+		return SuggestQuery{}, negativeParameter
+	}
+	if config.MinReps > 0 && config.MaxReps > 0 && config.MinReps > config.MaxReps {
+		return SuggestQuery{}, wrongRepsRange
+	}
+	if query.Sessions < 0 || query.Sessions > MaxSuggestSessions {
+		return SuggestQuery{}, tooManySessions
+	}
+	if query.Strategy == "" {
+		query.Strategy = suggest.DefaultStrategy
+	}
+	_, err = suggest.New(query.Strategy, query.Config)
+	if err != nil {
+		return SuggestQuery{}, err
+	}
+	if query.Sessions == 0 {
+		query.Sessions = DefaultSuggestSessions
+	}
+	return query, nil
+}
@@ -0,0 +1,77 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/fridrock/trainingservice/api/utils/suggest"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseSuggestQuery(t *testing.T) {
+	data := []struct {
+		testName      string
+		query         string
+		expectedQuery SuggestQuery
+		expectedError error
+	}{
+		{
+			"negative case: empty exercise",
+			`{"user_id":2}`,
+			SuggestQuery{},
+			emptyField,
+		},
+		{
+			"negative case: unknown strategy",
+			`{"user_id":2,"exercise_id":1,"strategy":"random"}`,
+			SuggestQuery{},
+			suggest.UnknownStrategy,
+		},
+		{
+			"negative case: min reps greater than max reps",
+			`{"user_id":2,"exercise_id":1,"min_reps":12,"max_reps":8}`,
+			SuggestQuery{},
+			wrongRepsRange,
+		},
+		{
+			"negative case: negative increment",
+			`{"user_id":2,"exercise_id":1,"increment":-2.5}`,
+			SuggestQuery{},
+			negativeParameter,
+		},
+		{
+			"negative case: too many sessions",
+			`{"user_id":2,"exercise_id":1,"sessions":21}`,
+			SuggestQuery{},
+			tooManySessions,
+		},
+		{
+			"positive case: defaults",
+			`{"user_id":2,"exercise_id":1}`,
+			SuggestQuery{UserId: 2, ExerciseId: 1, Strategy: suggest.DefaultStrategy, Sessions: DefaultSuggestSessions},
+			nil,
+		},
+		{
+			"positive case",
+			`{"user_id":2,"exercise_id":1,"strategy":"double","sessions":5,"min_reps":6,"max_reps":10,"increment":5}`,
+			SuggestQuery{
+				UserId:     2,
+				ExerciseId: 1,
+				Strategy:   "double",
+				Sessions:   5,
+				Config:     suggest.Config{MinReps: 6, MaxReps: 10, Increment: 5},
+			},
+			nil,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			res, err := ParseSuggestQuery([]byte(d.query))
+			if err != d.expectedError {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(res, d.expectedQuery); diff != "" {
+				t.Errorf("error while parsing, got wrong values: %s", diff)
+			}
+		})
+	}
+}
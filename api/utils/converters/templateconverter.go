@@ -0,0 +1,109 @@
+package converters
+
+import (
+	"encoding/json"
+
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+// FromJsonToTemplate - parses template, it must have name and at least one exercise with planned sets
+func FromJsonToTemplate(templateEncoded []byte) (stores.Template, error) {
+	var template stores.Template
+	err := json.Unmarshal(templateEncoded, &template)
+	if err != nil {
+		return template, err
+	}
+	if template.UserId == 0 || template.Name == "" || len(template.Exercises) == 0 {
+		return stores.Template{}, emptyField
+	}
+	for _, exercise := range template.Exercises {
+		if exercise.ExerciseId == 0 || exercise.Sets <= 0 {
+			return stores.Template{}, emptyField
+		}
+	}
+	return template, nil
+}
+
+func ParseUpdateTemplate(request []byte) (stores.Template, error) {
+	template, err := FromJsonToTemplate(request)
+	if err != nil {
+		return template, err
+	}
+	if template.Id == 0 {
+		return stores.Template{}, emptyField
+	}
+	return template, nil
+}
+
+type TemplateQuery struct {
+	UserId     int64 `json:"user_id"`
+	TemplateId int64 `json:"template_id"`
+}
+
+func ParseTemplateQuery(request []byte) (query TemplateQuery, err error) {
+	err = json.Unmarshal(request, &query)
+	if err != nil {
+		return query, err
+	}
+	if query.UserId == 0 || query.TemplateId == 0 {
+		err = emptyField
+	}
+	return query, err
+}
+
+type ConfirmSet struct {
+	UserId       int64   `json:"user_id"`
+	PlannedId    int64   `json:"planned_id"`
+	Weight       float64 `json:"weight"`
+	Reps         int64   `json:"reps"`
+	Duration     int64   `json:"duration"`
+	Rpe          float64 `json:"rpe"`
+	Distance     float64 `json:"distance"`
+	Elevation    float64 `json:"elevation"`
+	AvgHeartRate int64   `json:"avg_heart_rate"`
+	MaxHeartRate int64   `json:"max_heart_rate"`
+	Calories     int64   `json:"calories"`
+}
+
+// ParseConfirmSet - parses request for confirming planned set, metrics are optional and replace planned ones.
+// Metrics required by type of exercise are checked by ValidateSet after applying planned ones with ApplyPlanned
+func ParseConfirmSet(request []byte) (int64, stores.ExerciseSet, error) {
+	var confirm ConfirmSet
+	err := json.Unmarshal(request, &confirm)
+	if err != nil {
+		return 0, stores.ExerciseSet{}, err
+	}
+	if confirm.UserId == 0 || confirm.PlannedId == 0 {
+		return 0, stores.ExerciseSet{}, emptyField
+	}
+	set := stores.ExerciseSet{
+		UserId:       confirm.UserId,
+		Weight:       confirm.Weight,
+		Reps:         confirm.Reps,
+		Duration:     confirm.Duration,
+		Rpe:          confirm.Rpe,
+		Distance:     confirm.Distance,
+		Elevation:    confirm.Elevation,
+		AvgHeartRate: confirm.AvgHeartRate,
+		MaxHeartRate: confirm.MaxHeartRate,
+		Calories:     confirm.Calories,
+	}
+	err = validateMetrics(set)
+	if err != nil {
+		return 0, stores.ExerciseSet{}, err
+	}
+	return confirm.PlannedId, set, nil
+}
+
+// ApplyPlanned - set, that confirming of planned set adds: exercise of planned set and its weight and reps,
+// unless set replaces them
+func ApplyPlanned(planned stores.PlannedSet, set stores.ExerciseSet) stores.ExerciseSet {
+	set.ExerciseId = planned.ExerciseId
+	if set.Weight == 0 {
+		set.Weight = planned.Weight
+	}
+	if set.Reps == 0 {
+		set.Reps = planned.Reps
+	}
+	return set
+}
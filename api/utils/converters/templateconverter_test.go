@@ -0,0 +1,121 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFromJsonToTemplate(t *testing.T) {
+	data := []struct {
+		testName         string
+		template         string
+		expectedTemplate stores.Template
+		expectedError    error
+	}{
+		{
+			"negative case: without exercises",
+			`{"user_id":2,"name":"Push day","exercises":[]}`,
+			stores.Template{},
+			emptyField,
+		},
+		{
+			"negative case: exercise without sets",
+			`{"user_id":2,"name":"Push day","exercises":[{"exercise_id":1,"reps":10}]}`,
+			stores.Template{},
+			emptyField,
+		},
+		{
+			"positive case",
+			`{"user_id":2,"name":"Push day","exercises":[{"exercise_id":1,"sets":3,"reps":10,"weight":60,"rest":90}]}`,
+			stores.Template{
+				UserId: 2,
+				Name:   "Push day",
+				Exercises: []stores.TemplateExercise{
+					{ExerciseId: 1, Sets: 3, Reps: 10, Weight: 60, Rest: 90},
+				},
+			},
+			nil,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			res, err := FromJsonToTemplate([]byte(d.template))
+			if err != d.expectedError {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(res, d.expectedTemplate); diff != "" {
+				t.Errorf("error while parsing, got wrong values: %s", diff)
+			}
+		})
+	}
+	//update requires id
+	_, err := ParseUpdateTemplate([]byte(`{"user_id":2,"name":"Push day","exercises":[{"exercise_id":1,"sets":3}]}`))
+	if err != emptyField {
+		t.Errorf("no error updating template without id: %v", err)
+	}
+}
+
+func TestParseTemplateQuery(t *testing.T) {
+	//negative case
+	_, err := ParseTemplateQuery([]byte(`{"user_id":2}`))
+	if err != emptyField {
+		t.Errorf("no error with empty template_id: %v", err)
+	}
+	//positive case
+	res, err := ParseTemplateQuery([]byte(`{"user_id":2,"template_id":3}`))
+	if err != nil {
+		t.Error(err)
+	}
+	if diff := cmp.Diff(res, TemplateQuery{UserId: 2, TemplateId: 3}); diff != "" {
+		t.Errorf("error while parsing, got wrong values: %s", diff)
+	}
+}
+
+func TestParseConfirmSet(t *testing.T) {
+	//negative case
+	_, _, err := ParseConfirmSet([]byte(`{"user_id":2,"reps":8}`))
+	if err != emptyField {
+		t.Errorf("no error with empty planned_id: %v", err)
+	}
+	_, _, err = ParseConfirmSet([]byte(`{"user_id":2,"planned_id":3,"reps":8,"rpe":0.5}`))
+	if err != wrongRpe {
+		t.Errorf("no error with rpe below 1: %v", err)
+	}
+	//positive case
+	plannedId, set, err := ParseConfirmSet([]byte(`{"user_id":2,"planned_id":3,"reps":8}`))
+	if err != nil {
+		t.Error(err)
+	}
+	if plannedId != 3 || set != (stores.ExerciseSet{UserId: 2, Reps: 8}) {
+		t.Errorf("error while parsing, got wrong values: %d, %#v", plannedId, set)
+	}
+	//cardio metrics
+	_, set, err = ParseConfirmSet([]byte(`{"user_id":2,"planned_id":3,"duration":1800,"distance":5,"elevation":40,` +
+		`"avg_heart_rate":140,"max_heart_rate":170,"calories":400}`))
+	if err != nil {
+		t.Error(err)
+	}
+	expected := stores.ExerciseSet{UserId: 2, Duration: 1800, Distance: 5, Elevation: 40, AvgHeartRate: 140,
+		MaxHeartRate: 170, Calories: 400}
+	if set != expected {
+		t.Errorf("error while parsing cardio metrics, got wrong values: %#v", set)
+	}
+	_, _, err = ParseConfirmSet([]byte(`{"user_id":2,"planned_id":3,"distance":-5}`))
+	if err != negativeMetric {
+		t.Errorf("no error with negative distance: %v", err)
+	}
+	_, _, err = ParseConfirmSet([]byte(`{"user_id":2,"planned_id":3,"avg_heart_rate":150,"max_heart_rate":140}`))
+	if err != wrongHeartRate {
+		t.Errorf("no error with max heart rate below average: %v", err)
+	}
+}
+
+func TestApplyPlanned(t *testing.T) {
+	planned := stores.PlannedSet{Id: 3, ExerciseId: 2, Reps: 10, Weight: 60}
+	set := ApplyPlanned(planned, stores.ExerciseSet{UserId: 2, Reps: 8})
+	if set != (stores.ExerciseSet{UserId: 2, ExerciseId: 2, Reps: 8, Weight: 60}) {
+		t.Errorf("got wrong set: %#v", set)
+	}
+}
@@ -0,0 +1,20 @@
+package converters
+
+import "encoding/json"
+
+type TrainingQuery struct {
+	UserId     int64 `json:"user_id"`
+	TrainingId int64 `json:"training_id"`
+}
+
+// ParseTrainingQuery - parses request for training of user, both fields are required
+func ParseTrainingQuery(request []byte) (query TrainingQuery, err error) {
+	err = json.Unmarshal(request, &query)
+	if err != nil {
+		return TrainingQuery{}, err
+	}
+	if query.UserId == 0 || query.TrainingId == 0 {
+		return TrainingQuery{}, emptyField
+	}
+	return query, nil
+}
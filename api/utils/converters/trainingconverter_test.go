@@ -0,0 +1,46 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTrainingQuery(t *testing.T) {
+	data := []struct {
+		testName      string
+		request       string
+		expectedQuery TrainingQuery
+		expectedError error
+	}{
+		{
+			"negative case: no training",
+			`{"user_id":2}`,
+			TrainingQuery{},
+			emptyField,
+		},
+		{
+			"negative case: no user",
+			`{"training_id":5}`,
+			TrainingQuery{},
+			emptyField,
+		},
+		{
+			"positive case",
+			`{"user_id":2,"training_id":5}`,
+			TrainingQuery{UserId: 2, TrainingId: 5},
+			nil,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			res, err := ParseTrainingQuery([]byte(d.request))
+			if err != d.expectedError {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(d.expectedQuery, res); diff != "" {
+				t.Errorf("error while parsing, got wrong values: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package converters
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+var (
+	unknownKind = errors.New("unknown kind of deleted item")
+)
+
+type TrashQuery struct {
+	UserId int64            `json:"user_id"`
+	Kind   stores.TrashKind `json:"kind"`
+	Id     int64            `json:"id"`
+}
+
+// ParseTrashRestore - parses request for restoring deleted item, kind must be one of stores.TrashKind
+func ParseTrashRestore(request []byte) (query TrashQuery, err error) {
+	err = json.Unmarshal(request, &query)
+	if err != nil {
+		return TrashQuery{}, err
+	}
+	if query.UserId == 0 || query.Kind == "" || query.Id == 0 {
+		return TrashQuery{}, emptyField
+	}
+	switch query.Kind {
+	case stores.TrashGroup, stores.TrashExercise, stores.TrashTraining, stores.TrashSet:
+		return query, nil
+	default:
+		return TrashQuery{}, unknownKind
+	}
+}
@@ -0,0 +1,53 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTrashRestore(t *testing.T) {
+	data := []struct {
+		testName      string
+		request       string
+		expectedQuery TrashQuery
+		expectedError error
+	}{
+		{
+			"negative case: no kind",
+			`{"user_id":2,"id":3}`,
+			TrashQuery{},
+			emptyField,
+		},
+		{
+			"negative case: no id",
+			`{"user_id":2,"kind":"set"}`,
+			TrashQuery{},
+			emptyField,
+		},
+		{
+			"negative case: unknown kind",
+			`{"user_id":2,"kind":"template","id":3}`,
+			TrashQuery{},
+			unknownKind,
+		},
+		{
+			"positive case",
+			`{"user_id":2,"kind":"training","id":3}`,
+			TrashQuery{UserId: 2, Kind: stores.TrashTraining, Id: 3},
+			nil,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			res, err := ParseTrashRestore([]byte(d.request))
+			if err != d.expectedError {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(d.expectedQuery, res); diff != "" {
+				t.Errorf("error while parsing, got wrong values: %s", diff)
+			}
+		})
+	}
+}
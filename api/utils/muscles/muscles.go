@@ -0,0 +1,79 @@
+package muscles
+
+import (
+	"errors"
+)
+
+var (
+	UnknownMuscle = errors.New("unknown muscle")
+)
+
+// Region - part of body, muscle belongs to
+type Region string
+
+const (
+	Upper Region = "upper"
+	Lower Region = "lower"
+	Core  Region = "core"
+)
+
+// Muscle - muscle group of canonical taxonomy, same names are used by catalogue
+type Muscle struct {
+	Name   string `json:"name"`
+	Region Region `json:"region"`
+}
+
+// Taxonomy - canonical muscle groups, which exercise groups and exercises of users can be mapped to
+var Taxonomy = []Muscle{
+	{"chest", Upper},
+	{"shoulders", Upper},
+	{"triceps", Upper},
+	{"lats", Upper},
+	{"traps", Upper},
+	{"biceps", Upper},
+	{"forearms", Upper},
+	{"abs", Core},
+	{"obliques", Core},
+	{"lower_back", Core},
+	{"glutes", Lower},
+	{"quads", Lower},
+	{"hamstrings", Lower},
+	{"calves", Lower},
+}
+
+// Ratio - pair of opposing muscle sets, which volumes are expected to be close. Ratio of volumes outside of
+// [1/Max, Max] is imbalance
+type Ratio struct {
+	Name   string
+	First  []string
+	Second []string
+	Max    float64
+}
+
+// Ratios - checked pairs of opposing muscles
+var Ratios = []Ratio{
+	{"push_pull", []string{"chest", "shoulders", "triceps"}, []string{"lats", "traps", "biceps"}, 1.5},
+	{"upper_lower", []string{"chest", "shoulders", "triceps", "lats", "traps", "biceps", "forearms"},
+		[]string{"glutes", "quads", "hamstrings", "calves"}, 2},
+	{"quads_hamstrings", []string{"quads"}, []string{"hamstrings"}, 2},
+}
+
+// Validate - checks that all names are muscles of taxonomy
+func Validate(names []string) error {
+	for _, name := range names {
+		if !IsMuscle(name) {
+			return UnknownMuscle
+		}
+	}
+	return nil
+}
+
+// IsMuscle - whether name is muscle of taxonomy
+func IsMuscle(name string) bool {
+	for _, muscle := range Taxonomy {
+		if muscle.Name == name {
+			return true
+		}
+	}
+	return false
+}
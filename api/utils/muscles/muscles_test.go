@@ -0,0 +1,22 @@
+package muscles
+
+import (
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	if Validate([]string{"chest", "lats"}) != nil || Validate(nil) != nil {
+		t.Error("known muscles are invalid")
+	}
+	if Validate([]string{"chest", "Chest"}) != UnknownMuscle {
+		t.Error("unknown muscle is valid")
+	}
+}
+
+func TestRatios(t *testing.T) {
+	for _, ratio := range Ratios {
+		if Validate(ratio.First) != nil || Validate(ratio.Second) != nil || ratio.Max <= 1 {
+			t.Errorf("ratio has unknown muscles or wrong max: %#v", ratio)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package periodization
+
+import (
+	"math"
+
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+// Rules - progression rules of program. Program repeats the same workout days every week, weights of exercises
+// with increments grow every week except of deload weeks, when all weights are multiplied by DeloadFactor
+type Rules struct {
+	Weeks        int64
+	DaysPerWeek  int64
+	DeloadEvery  int64
+	DeloadFactor float64
+	Increments   map[int64]float64
+}
+
+// Position - place of workout in program, week and day are counted from 1
+type Position struct {
+	Week   int64 `json:"week"`
+	Day    int64 `json:"day"`
+	Deload bool  `json:"deload"`
+}
+
+// NewRules - creates rules of program
+func NewRules(program stores.Program) Rules {
+	increments := make(map[int64]float64, len(program.Progressions))
+	for _, progression := range program.Progressions {
+		increments[progression.ExerciseId] = progression.Increment
+	}
+	return Rules{
+		Weeks:        program.Weeks,
+		DaysPerWeek:  int64(len(program.Days)),
+		DeloadEvery:  program.DeloadEvery,
+		DeloadFactor: program.DeloadFactor,
+		Increments:   increments,
+	}
+}
+
+// PositionOf - position of workout with index in program, returns false if program is completed
+func (r Rules) PositionOf(dayIndex int64) (Position, bool) {
+	if r.DaysPerWeek == 0 || dayIndex < 0 || dayIndex >= r.Weeks*r.DaysPerWeek {
+		return Position{}, false
+	}
+	week := dayIndex / r.DaysPerWeek
+	return Position{
+		Week:   week + 1,
+		Day:    dayIndex%r.DaysPerWeek + 1,
+		Deload: r.isDeload(week),
+	}, true
+}
+
+// isDeload - checks if week, counted from 0, is deload week
+func (r Rules) isDeload(week int64) bool {
+	return r.DeloadEvery > 0 && (week+1)%r.DeloadEvery == 0
+}
+
+// Weight - planned weight of exercise in week, counted from 1. Weight grows only in weeks, that aren't deload
+func (r Rules) Weight(exerciseId int64, base float64, week int64) float64 {
+	if base == 0 {
+		return 0
+	}
+	week--
+	progressed := week
+	if r.DeloadEvery > 0 {
+		progressed -= week / r.DeloadEvery
+	}
+	weight := base + r.Increments[exerciseId]*float64(progressed)
+	if r.isDeload(week) {
+		weight *= r.DeloadFactor
+	}
+	return math.Round(weight*100) / 100
+}
+
+// Apply - exercises of template with weights planned for week, counted from 1
+func (r Rules) Apply(exercises []stores.TemplateExercise, week int64) []stores.TemplateExercise {
+	planned := make([]stores.TemplateExercise, len(exercises))
+	for i, exercise := range exercises {
+		exercise.Weight = r.Weight(exercise.ExerciseId, exercise.Weight, week)
+		planned[i] = exercise
+	}
+	return planned
+}
+
+// Workout - workout of program planned for today
+type Workout struct {
+	ProgramId int64 `json:"program_id"`
+	DayIndex  int64 `json:"day_index"`
+	Position
+	TemplateId int64                     `json:"template_id"`
+	Name       string                    `json:"name"`
+	Exercises  []stores.TemplateExercise `json:"exercises"`
+}
+
+// Plan - workout of template planned for position in program
+func (r Rules) Plan(programId int64, dayIndex int64, position Position, template stores.Template) Workout {
+	return Workout{
+		ProgramId:  programId,
+		DayIndex:   dayIndex,
+		Position:   position,
+		TemplateId: template.Id,
+		Name:       template.Name,
+		Exercises:  r.Apply(template.Exercises, position.Week),
+	}
+}
@@ -0,0 +1,81 @@
+package periodization
+
+import (
+	"testing"
+
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/google/go-cmp/cmp"
+)
+
+var rules = NewRules(stores.Program{
+	Weeks:        8,
+	DeloadEvery:  4,
+	DeloadFactor: 0.9,
+	Days:         []stores.ProgramDay{{Position: 1, TemplateId: 1}, {Position: 2, TemplateId: 2}, {Position: 3, TemplateId: 1}},
+	Progressions: []stores.Progression{{ExerciseId: 1, Increment: 2.5}},
+})
+
+func TestPositionOf(t *testing.T) {
+	data := []struct {
+		dayIndex         int64
+		expectedPosition Position
+		expectedOk       bool
+	}{
+		{0, Position{Week: 1, Day: 1}, true},
+		{5, Position{Week: 2, Day: 3}, true},
+		{9, Position{Week: 4, Day: 1, Deload: true}, true},
+		{23, Position{Week: 8, Day: 3, Deload: true}, true},
+		{24, Position{}, false},
+	}
+	for _, d := range data {
+		position, ok := rules.PositionOf(d.dayIndex)
+		if position != d.expectedPosition || ok != d.expectedOk {
+			t.Errorf("got wrong position of %d day: %#v, %v", d.dayIndex, position, ok)
+		}
+	}
+	if _, ok := (Rules{Weeks: 4}).PositionOf(0); ok {
+		t.Error("got position in program without days")
+	}
+}
+
+func TestWeight(t *testing.T) {
+	data := []struct {
+		testName       string
+		exerciseId     int64
+		base           float64
+		week           int64
+		expectedWeight float64
+	}{
+		{"first week", 1, 60, 1, 60},
+		{"third week", 1, 60, 3, 65},
+		{"deload week", 1, 60, 4, 60.75},
+		{"week after deload", 1, 60, 5, 67.5},
+		{"exercise without progression", 2, 40, 3, 40},
+		{"exercise without progression in deload", 2, 40, 8, 36},
+		{"bodyweight exercise", 1, 0, 3, 0},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			if weight := rules.Weight(d.exerciseId, d.base, d.week); weight != d.expectedWeight {
+				t.Errorf("got wrong weight: %v", weight)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	exercises := []stores.TemplateExercise{
+		{ExerciseId: 1, Sets: 3, Reps: 5, Weight: 100},
+		{ExerciseId: 2, Sets: 3, Reps: 12, Weight: 20},
+	}
+	expected := []stores.TemplateExercise{
+		{ExerciseId: 1, Sets: 3, Reps: 5, Weight: 105},
+		{ExerciseId: 2, Sets: 3, Reps: 12, Weight: 20},
+	}
+	if diff := cmp.Diff(expected, rules.Apply(exercises, 3)); diff != "" {
+		t.Errorf("got wrong planned exercises: %s", diff)
+	}
+	if exercises[0].Weight != 100 {
+		t.Error("template exercises were changed")
+	}
+}
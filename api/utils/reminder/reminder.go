@@ -0,0 +1,100 @@
+package reminder
+
+import (
+	"errors"
+	"strings"
+	"time"
+	// embedded timezone database, so locations of users are available without system one
+	_ "time/tzdata"
+)
+
+var (
+	UnknownWeekday  = errors.New("unknown weekday")
+	WrongClock      = errors.New("time must be in format HH:MM")
+	UnknownTimezone = errors.New("unknown timezone")
+)
+
+// weekdays - short names of weekdays, as they are stored in training_schedules
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParseWeekday - returns short name of weekday, both short and full english names are accepted
+func ParseWeekday(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) > 3 {
+		for short, weekday := range weekdays {
+			if strings.ToLower(weekday.String()) == name {
+				return short, nil
+			}
+		}
+		return "", UnknownWeekday
+	}
+	if _, ok := weekdays[name]; !ok {
+		return "", UnknownWeekday
+	}
+	return name, nil
+}
+
+// WeekdayOf - returns weekday of short name
+func WeekdayOf(name string) (time.Weekday, error) {
+	weekday, ok := weekdays[name]
+	if !ok {
+		return 0, UnknownWeekday
+	}
+	return weekday, nil
+}
+
+// ParseClock - returns hour and minute of time of day in format HH:MM
+func ParseClock(clock string) (hour int, minute int, err error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, 0, WrongClock
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// LastOccurrence - the latest moment not after now, when slot of weekday at clock happens in loc.
+// Clock, which doesn't exist because of daylight saving time transition, is shifted forward
+func LastOccurrence(weekday string, clock string, loc *time.Location, now time.Time) (time.Time, error) {
+	day, err := WeekdayOf(weekday)
+	if err != nil {
+		return time.Time{}, err
+	}
+	hour, minute, err := ParseClock(clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+	local := now.In(loc)
+	back := (int(local.Weekday()) - int(day) + 7) % 7
+	occurrence := time.Date(local.Year(), local.Month(), local.Day()-back, hour, minute, 0, 0, loc)
+	if occurrence.After(now) {
+		occurrence = time.Date(local.Year(), local.Month(), local.Day()-back-7, hour, minute, 0, 0, loc)
+	}
+	return occurrence, nil
+}
+
+// Trained - whether training, which began at begins, counts for slot occurred at occurrence: it began later
+// than slot or at the same day in loc
+func Trained(begins time.Time, occurrence time.Time, loc *time.Location) bool {
+	if !begins.Before(occurrence) {
+		return true
+	}
+	b, o := begins.In(loc), occurrence.In(loc)
+	return b.Year() == o.Year() && b.YearDay() == o.YearDay()
+}
+
+// LoadLocation - loads timezone of user, empty name means UTC
+func LoadLocation(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, UnknownTimezone
+	}
+	return loc, nil
+}
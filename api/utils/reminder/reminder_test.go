@@ -0,0 +1,93 @@
+package reminder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWeekday(t *testing.T) {
+	cases := map[string]string{"mon": "mon", "Monday": "mon", " FRI ": "fri", "sunday": "sun"}
+	for name, expected := range cases {
+		weekday, err := ParseWeekday(name)
+		if err != nil || weekday != expected {
+			t.Errorf("parsed %q as %q: %v", name, weekday, err)
+		}
+	}
+	for _, name := range []string{"", "mo", "funday", "mond"} {
+		if _, err := ParseWeekday(name); err != UnknownWeekday {
+			t.Errorf("parsed wrong weekday %q: %v", name, err)
+		}
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	hour, minute, err := ParseClock("18:30")
+	if err != nil || hour != 18 || minute != 30 {
+		t.Errorf("parsed wrong clock: %d:%d %v", hour, minute, err)
+	}
+	for _, clock := range []string{"", "25:00", "18", "6pm"} {
+		if _, _, err := ParseClock(clock); err != WrongClock {
+			t.Errorf("parsed wrong clock %q: %v", clock, err)
+		}
+	}
+}
+
+func TestLastOccurrence(t *testing.T) {
+	moscow, _ := LoadLocation("Europe/Moscow")
+	//wednesday 2024-06-12 16:00 in Moscow
+	now := time.Date(2024, 6, 12, 13, 0, 0, 0, time.UTC)
+	cases := []struct {
+		weekday  string
+		clock    string
+		expected time.Time
+	}{
+		{"wed", "15:00", time.Date(2024, 6, 12, 15, 0, 0, 0, moscow)},
+		{"wed", "16:00", time.Date(2024, 6, 12, 16, 0, 0, 0, moscow)},
+		{"wed", "18:00", time.Date(2024, 6, 5, 18, 0, 0, 0, moscow)},
+		{"mon", "18:00", time.Date(2024, 6, 10, 18, 0, 0, 0, moscow)},
+		{"thu", "07:00", time.Date(2024, 6, 6, 7, 0, 0, 0, moscow)},
+	}
+	for _, c := range cases {
+		occurrence, err := LastOccurrence(c.weekday, c.clock, moscow, now)
+		if err != nil || !occurrence.Equal(c.expected) {
+			t.Errorf("got wrong occurrence of %s %s: %s %v", c.weekday, c.clock, occurrence, err)
+		}
+	}
+}
+
+func TestLastOccurrenceDST(t *testing.T) {
+	berlin, _ := LoadLocation("Europe/Berlin")
+	//clocks moved forward at 2024-03-31 02:00, slot is in the same wall clock time before and after
+	now := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	occurrence, _ := LastOccurrence("sun", "18:00", berlin, now)
+	if !occurrence.Equal(time.Date(2024, 3, 31, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("got wrong occurrence after transition: %s", occurrence)
+	}
+	//02:30 doesn't exist at the day of transition
+	occurrence, _ = LastOccurrence("sun", "02:30", berlin, now)
+	if occurrence.Day() != 31 || occurrence.After(now) {
+		t.Errorf("got wrong occurrence of skipped time: %s", occurrence)
+	}
+}
+
+func TestTrained(t *testing.T) {
+	moscow, _ := LoadLocation("Europe/Moscow")
+	occurrence := time.Date(2024, 6, 12, 18, 0, 0, 0, moscow)
+	cases := []struct {
+		name     string
+		begins   time.Time
+		expected bool
+	}{
+		{"after slot", occurrence.Add(time.Hour), true},
+		{"earlier same day", time.Date(2024, 6, 12, 8, 0, 0, 0, moscow), true},
+		//previous day in UTC, but the same day in Moscow
+		{"after local midnight", time.Date(2024, 6, 11, 22, 0, 0, 0, time.UTC), true},
+		{"previous day", time.Date(2024, 6, 11, 20, 0, 0, 0, time.UTC), false},
+		{"week ago", occurrence.AddDate(0, 0, -7), false},
+	}
+	for _, c := range cases {
+		if Trained(c.begins, occurrence, moscow) != c.expected {
+			t.Errorf("wrong result for training %s", c.name)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package responses
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	"github.com/fridrock/trainingservice/errkind"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// Version - current version of response envelope
+const Version = 1
+
+// Exchange - exchange, which requests to service and responses of service are published to
+const Exchange = "sport_bot"
+
+// FormatHeader - name of request header, that selects format of response,
+// LegacyFormat value makes router answer with "SUCCESS:"/"ERROR:" strings
+const (
+	FormatHeader = "response_format"
+	LegacyFormat = "legacy"
+)
+
+// Status of response
+type Status string
+
+const (
+	StatusSuccess Status = "success"
+	StatusError   Status = "error"
+)
+
+// Code - stable machine-readable code of response
+type Code string
+
+const (
+	OK         Code = "ok"
+	Validation Code = "validation"
+	NotFound   Code = "not_found"
+	Conflict   Code = "conflict"
+	Internal   Code = "internal"
+)
+
+// Response - versioned envelope, which is sent back for every request
+type Response struct {
+	Version int    `json:"version"`
+	Status  Status `json:"status"`
+	Code    Code   `json:"code"`
+	Message string `json:"message,omitempty"`
+	Data    any    `json:"data,omitempty"`
+	// Transient - request failed because of temporary failure of database or network, so it can be retried
+	Transient bool `json:"-"`
+}
+
+// Id - data of response for requests, that create entities
+type Id struct {
+	Id int64 `json:"id"`
+}
+
+// Success - creates successful response with optional data
+func Success(data any) Response {
+	return Response{
+		Version: Version,
+		Status:  StatusSuccess,
+		Code:    OK,
+		Data:    data,
+	}
+}
+
+// Created - creates successful response with id of created entity
+func Created(id int64) Response {
+	return Success(Id{Id: id})
+}
+
+// WrongInput - creates response for request, which failed validation in converters
+func WrongInput(err error) Response {
+	return Response{
+		Version: Version,
+		Status:  StatusError,
+		Code:    Validation,
+		Message: fmt.Sprintf("wrong input: %v", err),
+	}
+}
+
+// Error - creates error response with code mapped from err
+func Error(err error) Response {
+	return Response{
+		Version:   Version,
+		Status:    StatusError,
+		Code:      CodeOf(err),
+		Message:   err.Error(),
+		Transient: IsTransient(err),
+	}
+}
+
+// sqlStateError - error of database driver with SQLSTATE code, like pq.Error
+type sqlStateError interface {
+	SQLState() string
+}
+
+// IsTransient - checks if err is caused by lost connection to database, network failure, database shutdown
+// or serialization failure, which may not happen on next attempt
+func IsTransient(err error) bool {
+	var netErr net.Error
+	var stateErr sqlStateError
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, driver.ErrBadConn), errors.As(err, &netErr):
+		return true
+	case errors.As(err, &stateErr):
+		state := stateErr.SQLState()
+		return strings.HasPrefix(state, "08") || state == "57P01" || state == "40001"
+	default:
+		return false
+	}
+}
+
+// CodeOf - maps errors of stores and converters to codes of response, expected errors are declared with
+// errkind kinds
+func CodeOf(err error) Code {
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case err == nil:
+		return OK
+	case errors.Is(err, sql.ErrNoRows), errkind.Of(err) == errkind.NotFound:
+		return NotFound
+	case errkind.Of(err) == errkind.Conflict:
+		return Conflict
+	case errors.As(err, &syntaxError), errors.As(err, &typeError):
+		return Validation
+	default:
+		return Internal
+	}
+}
+
+// IsLegacy - checks if request asks for legacy string response
+func IsLegacy(msg amqp091.Delivery) bool {
+	format, ok := msg.Headers[FormatHeader].(string)
+	return ok && format == LegacyFormat
+}
+
+// Encode - converts response to the body of message, either in json envelope or in legacy string format
+func (r Response) Encode(legacy bool) string {
+	if legacy {
+		return r.Legacy()
+	}
+	encoded, err := json.Marshal(&r)
+	if err != nil {
+		encoded, _ = json.Marshal(Error(err))
+	}
+	return string(encoded)
+}
+
+// Legacy - converts response to the "SUCCESS:"/"ERROR:" string format
+func (r Response) Legacy() string {
+	if r.Status == StatusError {
+		if r.Code == Validation {
+			return "ERROR: wrong input"
+		}
+		return "ERROR: " + r.Message
+	}
+	switch data := r.Data.(type) {
+	case nil:
+		return "SUCCESS"
+	case Id:
+		return fmt.Sprintf("SUCCESS: id:%d", data.Id)
+	case json.RawMessage:
+		if len(data) > 0 && data[0] == '[' {
+			var indented bytes.Buffer
+			if err := json.Indent(&indented, data, "", ""); err == nil {
+				return "SUCCESS: " + indented.String()
+			}
+		}
+		return "SUCCESS: " + string(data)
+	}
+	var encoded []byte
+	var err error
+	if reflect.ValueOf(r.Data).Kind() == reflect.Slice {
+		encoded, err = json.MarshalIndent(r.Data, "", "")
+	} else {
+		encoded, err = json.Marshal(r.Data)
+	}
+	if err != nil {
+		return fmt.Sprintf("ERROR: %v", err)
+	}
+	return "SUCCESS: " + string(encoded)
+}
+
+// Decode - converts encoded json envelope back to response, data is kept as json.RawMessage
+// except of id of created entity
+func Decode(encoded []byte) (Response, error) {
+	var raw struct {
+		Response
+		Data json.RawMessage `json:"data,omitempty"`
+	}
+	err := json.Unmarshal(encoded, &raw)
+	if err != nil {
+		return Response{}, err
+	}
+	response := raw.Response
+	if len(raw.Data) == 0 {
+		return response, nil
+	}
+	var id Id
+	decoder := json.NewDecoder(bytes.NewReader(raw.Data))
+	decoder.DisallowUnknownFields()
+	if decoder.Decode(&id) == nil && id.Id != 0 {
+		response.Data = id
+	} else {
+		response.Data = raw.Data
+	}
+	return response, nil
+}
@@ -0,0 +1,183 @@
+package responses
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/fridrock/trainingservice/api/utils/suggest"
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/fridrock/trainingservice/errkind"
+	"github.com/lib/pq"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+func TestCodeOf(t *testing.T) {
+	var syntaxError error = &json.SyntaxError{}
+	data := []struct {
+		testName     string
+		err          error
+		expectedCode Code
+	}{
+		{"no error", nil, OK},
+		{"not deleted", stores.NotDeleted, NotFound},
+		{"not updated", stores.NotUpdated, NotFound},
+		{"no rows", sql.ErrNoRows, NotFound},
+		{"wrapped no rows", fmt.Errorf("error getting trainings: %w", sql.ErrNoRows), NotFound},
+		{"all trainings finished", fmt.Errorf("error finishing training: %w", stores.AllTrainingsFinished), Conflict},
+		{"training in progress", stores.TrainingInProgress, Conflict},
+		{"not enrolled", stores.NotEnrolled, NotFound},
+		{"program completed", fmt.Errorf("error getting today's workout: %w", stores.ProgramCompleted), Conflict},
+		{"no history", fmt.Errorf("error suggesting next set: %w", suggest.NoHistory), NotFound},
+		{"no rpe", suggest.NoRpe, Conflict},
+		{"already linked", fmt.Errorf("error linking catalogue exercise: %w", stores.AlreadyLinked), Conflict},
+		{"group has exercises", stores.DependentExercises{"Pull up"}, Conflict},
+		{"group name taken", stores.NameTaken, Conflict},
+		{"exercise name taken", stores.ExerciseNameTaken, Conflict},
+		{"ambiguous exercise name", stores.AmbiguousName, Conflict},
+		{"template in program", stores.TemplateInProgram, Conflict},
+		{"parent deleted", stores.ParentDeleted, Conflict},
+		{"json error", syntaxError, Validation},
+		{"error of kind", fmt.Errorf("error finding: %w", errkind.New(errkind.NotFound, "not found")), NotFound},
+		{"unknown error", errors.New("connection refused"), Internal},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			if code := CodeOf(d.err); code != d.expectedCode {
+				t.Errorf("got wrong code: %s", code)
+			}
+		})
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	data := []struct {
+		testName          string
+		err               error
+		expectedTransient bool
+	}{
+		{"no error", nil, false},
+		{"bad connection", fmt.Errorf("error saving group: %w", driver.ErrBadConn), true},
+		{"network error", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+		{"connection failure", &pq.Error{Code: "08006"}, true},
+		{"admin shutdown", &pq.Error{Code: "57P01"}, true},
+		{"serialization failure", &pq.Error{Code: "40001"}, true},
+		{"foreign key violation", &pq.Error{Code: "23503"}, false},
+		{"check violation", &pq.Error{Code: "23514"}, false},
+		{"no rows", sql.ErrNoRows, false},
+		{"panic", errors.New("internal server error: runtime error"), false},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			if transient := IsTransient(d.err); transient != d.expectedTransient {
+				t.Errorf("got wrong transient: %v", transient)
+			}
+		})
+	}
+}
+
+func TestLegacy(t *testing.T) {
+	data := []struct {
+		testName       string
+		response       Response
+		expectedResult string
+	}{
+		{
+			"wrong input",
+			WrongInput(errors.New("empty Field")),
+			"ERROR: wrong input",
+		},
+		{
+			"wrapped error",
+			Error(fmt.Errorf("error finishing training: %w", stores.AllTrainingsFinished)),
+			"ERROR: error finishing training: Empty non-finished trainings list",
+		},
+		{
+			"success without data",
+			Success(nil),
+			"SUCCESS",
+		},
+		{
+			"created",
+			Created(12),
+			"SUCCESS: id:12",
+		},
+		{
+			"success with object",
+			Success(stores.ExGroup{Id: 1, UserId: 2, Name: "Back"}),
+			`SUCCESS: {"id":1,"user_id":2,"name":"Back"}`,
+		},
+		{
+			"success with list",
+			Success([]stores.ExGroup{{Id: 1, UserId: 2, Name: "Back"}}),
+			"SUCCESS: [\n{\n\"id\": 1,\n\"user_id\": 2,\n\"name\": \"Back\"\n}\n]",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			if received := d.response.Legacy(); received != d.expectedResult {
+				t.Errorf("got wrong legacy response: %s", received)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	encoded := Created(12).Encode(false)
+	expected := `{"version":1,"status":"success","code":"ok","data":{"id":12}}`
+	if encoded != expected {
+		t.Errorf("got wrong envelope: %s", encoded)
+	}
+	encoded = Error(stores.NotDeleted).Encode(false)
+	expected = `{"version":1,"status":"error","code":"not_found","message":"no rows deleted"}`
+	if encoded != expected {
+		t.Errorf("got wrong envelope: %s", encoded)
+	}
+}
+
+func TestIsLegacy(t *testing.T) {
+	if IsLegacy(amqp091.Delivery{}) {
+		t.Error("request without headers is treated as legacy")
+	}
+	legacy := amqp091.Delivery{Headers: amqp091.Table{FormatHeader: LegacyFormat}}
+	if !IsLegacy(legacy) {
+		t.Error("request with legacy header is not treated as legacy")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	data := []struct {
+		testName       string
+		response       Response
+		expectedLegacy string
+	}{
+		{"created", Created(12), "SUCCESS: id:12"},
+		{"success without data", Success(nil), "SUCCESS"},
+		{"error", Error(stores.NotDeleted), "ERROR: no rows deleted"},
+		{
+			"success with object",
+			Success(stores.ExGroup{Id: 1, UserId: 2, Name: "Back"}),
+			`SUCCESS: {"id":1,"user_id":2,"name":"Back"}`,
+		},
+		{
+			"success with list",
+			Success([]stores.ExGroup{{Id: 1, UserId: 2, Name: "Back"}}),
+			"SUCCESS: [\n{\n\"id\": 1,\n\"user_id\": 2,\n\"name\": \"Back\"\n}\n]",
+		},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			decoded, err := Decode([]byte(d.response.Encode(false)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decoded.Code != d.response.Code || decoded.Legacy() != d.expectedLegacy {
+				t.Errorf("got wrong decoded response: %#v", decoded)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package suggest
+
+import "fmt"
+
+func init() {
+	Register("double", NewDoubleProgression)
+}
+
+// DoubleProgression - reps grow in range from MinReps to MaxReps with the same weight, when all working sets
+// reach MaxReps weight grows by Increment and reps go back to MinReps. Sets without weight are bodyweight ones,
+// their reps keep growing
+type DoubleProgression struct {
+	MinReps   int64
+	MaxReps   int64
+	Increment float64
+}
+
+// NewDoubleProgression - creates double progression, by default reps are in range 8-12 and increment is 2.5
+func NewDoubleProgression(config Config) Strategy {
+	return DoubleProgression{
+		MinReps:   defaultTo(config.MinReps, 8),
+		MaxReps:   defaultTo(config.MaxReps, 12),
+		Increment: defaultTo(config.Increment, 2.5),
+	}
+}
+
+func (dp DoubleProgression) Suggest(history []Session) (Suggestion, error) {
+	sets := workingSets(history[len(history)-1])
+	weight := sets[0].Weight
+	reps := minReps(sets)
+	if reps >= dp.MaxReps && weight == 0 {
+		return bodyweight(reps), nil
+	}
+	if reps >= dp.MaxReps {
+		return Suggestion{
+			Weight: weight + dp.Increment,
+			Reps:   dp.MinReps,
+			Reason: fmt.Sprintf("all working sets reached %d reps, weight is increased", dp.MaxReps),
+		}, nil
+	}
+	target := reps + 1
+	if target < dp.MinReps {
+		target = dp.MinReps
+	}
+	return Suggestion{
+		Weight: weight,
+		Reps:   target,
+		Reason: fmt.Sprintf("reps are increased towards %d with the same weight", dp.MaxReps),
+	}, nil
+}
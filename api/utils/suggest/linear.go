@@ -0,0 +1,43 @@
+package suggest
+
+import "fmt"
+
+func init() {
+	Register("linear", NewLinear)
+}
+
+// Linear - weight grows by Increment every session, while all working sets reach TargetReps,
+// otherwise weight is repeated. Sets without weight are bodyweight ones, their reps grow instead
+type Linear struct {
+	TargetReps int64
+	Increment  float64
+}
+
+// NewLinear - creates linear progression, by default target is 5 reps and increment is 2.5
+func NewLinear(config Config) Strategy {
+	return Linear{
+		TargetReps: defaultTo(config.TargetReps, 5),
+		Increment:  defaultTo(config.Increment, 2.5),
+	}
+}
+
+func (l Linear) Suggest(history []Session) (Suggestion, error) {
+	sets := workingSets(history[len(history)-1])
+	weight := sets[0].Weight
+	reps := minReps(sets)
+	if reps >= l.TargetReps && weight == 0 {
+		return bodyweight(reps), nil
+	}
+	if reps >= l.TargetReps {
+		return Suggestion{
+			Weight: weight + l.Increment,
+			Reps:   l.TargetReps,
+			Reason: fmt.Sprintf("all working sets reached %d reps, weight is increased", l.TargetReps),
+		}, nil
+	}
+	return Suggestion{
+		Weight: weight,
+		Reps:   l.TargetReps,
+		Reason: fmt.Sprintf("not all working sets reached %d reps, weight is repeated", l.TargetReps),
+	}, nil
+}
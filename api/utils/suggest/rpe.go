@@ -0,0 +1,51 @@
+package suggest
+
+import (
+	"fmt"
+
+	"github.com/fridrock/trainingservice/errkind"
+)
+
+var (
+	NoRpe = errkind.New(errkind.Conflict, "no rpe logged in the last session")
+)
+
+func init() {
+	Register("rpe", NewRpeBased)
+}
+
+// rpeStep - change of weight for one point of rpe difference
+const rpeStep = 0.04
+
+// RpeBased - weight of the heaviest set with logged rpe is changed by 4% for every point of difference
+// between its rpe and TargetRpe, reps are kept. Weight is rounded to Increment
+type RpeBased struct {
+	TargetRpe float64
+	Increment float64
+}
+
+// NewRpeBased - creates rpe based progression, by default target rpe is 8 and weight is rounded to 2.5
+func NewRpeBased(config Config) Strategy {
+	return RpeBased{
+		TargetRpe: defaultTo(config.TargetRpe, 8),
+		Increment: defaultTo(config.Increment, 2.5),
+	}
+}
+
+func (rb RpeBased) Suggest(history []Session) (Suggestion, error) {
+	var top Set
+	for _, set := range history[len(history)-1].Sets {
+		if set.Rpe > 0 && set.Weight >= top.Weight {
+			top = set
+		}
+	}
+	if top.Rpe == 0 {
+		return Suggestion{}, NoRpe
+	}
+	weight := roundTo(top.Weight*(1+rpeStep*(rb.TargetRpe-top.Rpe)), rb.Increment)
+	return Suggestion{
+		Weight: weight,
+		Reps:   top.Reps,
+		Reason: fmt.Sprintf("last set was rpe %v, weight is adjusted to rpe %v", top.Rpe, rb.TargetRpe),
+	}, nil
+}
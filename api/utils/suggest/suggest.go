@@ -0,0 +1,172 @@
+package suggest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fridrock/trainingservice/errkind"
+)
+
+var (
+	NoHistory       = errkind.New(errkind.NotFound, "no history of exercise")
+	UnknownStrategy = errors.New("unknown suggestion strategy")
+)
+
+// Set - logged set of exercise, Rpe is zero if it wasn't logged
+type Set struct {
+	Weight float64
+	Reps   int64
+	Rpe    float64
+}
+
+// Session - sets of exercise logged in one training
+type Session struct {
+	TrainingId int64
+	Date       time.Time
+	Sets       []Set
+}
+
+// Suggestion - recommended weight and reps for the next set
+type Suggestion struct {
+	Strategy string  `json:"strategy"`
+	Weight   float64 `json:"weight" unit:"weight"`
+	Reps     int64   `json:"reps"`
+	Reason   string  `json:"reason"`
+}
+
+// Strategy - rule of progressive overload. History is ordered from the oldest session to the latest one
+// and always has at least one session with sets
+type Strategy interface {
+	Suggest(history []Session) (Suggestion, error)
+}
+
+// Config - parameters of strategies, zero values are replaced by defaults of strategy
+type Config struct {
+	MinReps    int64   `json:"min_reps"`
+	MaxReps    int64   `json:"max_reps"`
+	TargetReps int64   `json:"target_reps"`
+	TargetRpe  float64 `json:"target_rpe"`
+	Increment  float64 `json:"increment" unit:"weight"`
+}
+
+// Factory - creates strategy configured by config
+type Factory func(config Config) Strategy
+
+// DefaultStrategy - name of strategy, which is used if request doesn't set it
+const DefaultStrategy = "double"
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+// Register - makes strategy available by name, new strategies register themselves in init
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	factories[name] = factory
+}
+
+// Strategies - names of registered strategies
+func Strategies() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New - creates registered strategy, empty name means DefaultStrategy
+func New(name string, config Config) (Strategy, error) {
+	if name == "" {
+		name = DefaultStrategy
+	}
+	mu.RLock()
+	factory, ok := factories[name]
+	mu.RUnlock()
+	if !ok {
+		return nil, UnknownStrategy
+	}
+	return factory(config), nil
+}
+
+// Next - suggestion of strategy with name for history, sessions without sets are ignored
+func Next(name string, config Config, history []Session) (Suggestion, error) {
+	if name == "" {
+		name = DefaultStrategy
+	}
+	strategy, err := New(name, config)
+	if err != nil {
+		return Suggestion{}, err
+	}
+	nonEmpty := make([]Session, 0, len(history))
+	for _, session := range history {
+		if len(session.Sets) > 0 {
+			nonEmpty = append(nonEmpty, session)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return Suggestion{}, NoHistory
+	}
+	suggestion, err := strategy.Suggest(nonEmpty)
+	suggestion.Strategy = name
+	return suggestion, err
+}
+
+// workingSets - sets of session with the heaviest weight
+func workingSets(session Session) []Set {
+	var top float64
+	for _, set := range session.Sets {
+		top = math.Max(top, set.Weight)
+	}
+	var sets []Set
+	for _, set := range session.Sets {
+		if set.Weight == top {
+			sets = append(sets, set)
+		}
+	}
+	return sets
+}
+
+// minReps - the least reps among sets
+func minReps(sets []Set) int64 {
+	least := sets[0].Reps
+	for _, set := range sets[1:] {
+		if set.Reps < least {
+			least = set.Reps
+		}
+	}
+	return least
+}
+
+// bodyweight - suggestion for bodyweight sets, which reached target reps, weight isn't added to them
+func bodyweight(reps int64) Suggestion {
+	return Suggestion{
+		Weight: 0,
+		Reps:   reps + 1,
+		Reason: fmt.Sprintf("bodyweight sets reached %d reps, reps are increased", reps),
+	}
+}
+
+// roundTo - rounds weight to the nearest multiple of step
+func roundTo(weight float64, step float64) float64 {
+	if step <= 0 {
+		return math.Round(weight*100) / 100
+	}
+	return math.Round(math.Round(weight/step)*step*100) / 100
+}
+
+// defaultTo - value, or defaultValue if value is zero
+func defaultTo[T int64 | float64](value T, defaultValue T) T {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,129 @@
+package suggest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// session - session with sets of the same weight and given reps
+func session(weight float64, reps ...int64) Session {
+	s := Session{}
+	for _, r := range reps {
+		s.Sets = append(s.Sets, Set{Weight: weight, Reps: r})
+	}
+	return s
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("unknown", Config{}); err != UnknownStrategy {
+		t.Errorf("created unknown strategy: %v", err)
+	}
+	strategy, err := New("", Config{})
+	if err != nil {
+		t.Fatalf("error creating default strategy: %v", err)
+	}
+	if _, ok := strategy.(DoubleProgression); !ok {
+		t.Errorf("default strategy isn't double progression: %#v", strategy)
+	}
+	if diff := cmp.Diff([]string{"double", "linear", "rpe"}, Strategies()); diff != "" {
+		t.Errorf("got wrong registered strategies: %s", diff)
+	}
+}
+
+func TestNextWithoutHistory(t *testing.T) {
+	_, err := Next("linear", Config{}, []Session{{}})
+	if err != NoHistory {
+		t.Errorf("got suggestion without history: %v", err)
+	}
+}
+
+func TestDoubleProgression(t *testing.T) {
+	config := Config{MinReps: 8, MaxReps: 12, Increment: 2.5}
+	data := []struct {
+		testName       string
+		history        []Session
+		expectedWeight float64
+		expectedReps   int64
+	}{
+		{"reps grow", []Session{session(60, 12, 10, 9)}, 60, 10},
+		{"weight grows", []Session{session(60, 10, 10, 10), session(60, 12, 12, 12)}, 62.5, 8},
+		{"reps below range", []Session{session(62.5, 6, 5)}, 62.5, 8},
+		{"warm up sets are ignored", []Session{{Sets: []Set{{Weight: 20, Reps: 5}, {Weight: 60, Reps: 12}}}}, 62.5, 8},
+		{"empty sessions are ignored", []Session{session(60, 12, 12), {}}, 62.5, 8},
+		{"bodyweight reps grow", []Session{session(0, 12, 13, 12)}, 0, 13},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			suggestion, err := Next("double", config, d.history)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if suggestion.Strategy != "double" || suggestion.Weight != d.expectedWeight || suggestion.Reps != d.expectedReps {
+				t.Errorf("got wrong suggestion: %#v", suggestion)
+			}
+		})
+	}
+}
+
+func TestLinear(t *testing.T) {
+	data := []struct {
+		testName       string
+		history        []Session
+		expectedWeight float64
+	}{
+		{"weight grows", []Session{session(100, 5, 5, 5)}, 105},
+		{"weight is repeated", []Session{session(100, 5, 5, 3)}, 100},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			suggestion, err := Next("linear", Config{Increment: 5}, d.history)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if suggestion.Weight != d.expectedWeight || suggestion.Reps != 5 {
+				t.Errorf("got wrong suggestion: %#v", suggestion)
+			}
+		})
+	}
+	//weight isn't added to bodyweight sets
+	suggestion, err := Next("linear", Config{Increment: 5}, []Session{session(0, 8, 6)})
+	if err != nil || suggestion.Weight != 0 || suggestion.Reps != 7 {
+		t.Errorf("got wrong suggestion for bodyweight sets: %#v, %v", suggestion, err)
+	}
+}
+
+func TestRpeBased(t *testing.T) {
+	data := []struct {
+		testName       string
+		sets           []Set
+		expectedWeight float64
+		expectedError  error
+	}{
+		{"too easy", []Set{{Weight: 100, Reps: 5, Rpe: 6}}, 107.5, nil},
+		{"too hard", []Set{{Weight: 100, Reps: 5, Rpe: 9.5}}, 95, nil},
+		{"on target", []Set{{Weight: 100, Reps: 5, Rpe: 8}}, 100, nil},
+		{"heaviest set with rpe", []Set{{Weight: 110, Reps: 3}, {Weight: 100, Reps: 5, Rpe: 7}, {Weight: 60, Reps: 5, Rpe: 5}}, 105, nil},
+		{"without rpe", []Set{{Weight: 100, Reps: 5}}, 0, NoRpe},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			suggestion, err := Next("rpe", Config{}, []Session{{Sets: d.sets}})
+			if err != d.expectedError {
+				t.Fatal(err)
+			}
+			if suggestion.Weight != d.expectedWeight {
+				t.Errorf("got wrong suggestion: %#v", suggestion)
+			}
+		})
+	}
+}
+
+func TestRoundTo(t *testing.T) {
+	if weight := roundTo(61.4, 2.5); weight != 62.5 {
+		t.Errorf("got wrong rounded weight: %v", weight)
+	}
+	if weight := roundTo(61.437, 0); weight != 61.44 {
+		t.Errorf("got wrong rounded weight: %v", weight)
+	}
+}
@@ -0,0 +1,145 @@
+package units
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/fridrock/trainingservice/db/stores"
+)
+
+var (
+	UnknownWeightUnit   = errors.New("weight unit must be kg or lb")
+	UnknownDistanceUnit = errors.New("distance unit must be km or mi")
+)
+
+// units of measure, weights are stored in kilograms and distances in kilometers
+const (
+	Kilograms  = "kg"
+	Pounds     = "lb"
+	Kilometers = "km"
+	Miles      = "mi"
+)
+
+const (
+	poundsInKilogram = 2.20462262
+	milesInKilometer = 0.621371192
+)
+
+// DefaultIncrement - default step of weight progression in unit of user, 2.5 kg or 5 lb
+func DefaultIncrement(settings stores.Settings) float64 {
+	if settings.WeightUnit == Pounds {
+		return 5
+	}
+	return 2.5
+}
+
+// ValidateWeightUnit - returns UnknownWeightUnit, if unit isn't supported
+func ValidateWeightUnit(unit string) error {
+	if unit != Kilograms && unit != Pounds {
+		return UnknownWeightUnit
+	}
+	return nil
+}
+
+// ValidateDistanceUnit - returns UnknownDistanceUnit, if unit isn't supported
+func ValidateDistanceUnit(unit string) error {
+	if unit != Kilometers && unit != Miles {
+		return UnknownDistanceUnit
+	}
+	return nil
+}
+
+// factors - how many units of user are in canonical one, by kind of value. Pace is time per distance, so it
+// is converted inversely to distance
+func factors(settings stores.Settings) map[string]float64 {
+	f := map[string]float64{"weight": 1, "distance": 1, "pace": 1}
+	if settings.WeightUnit == Pounds {
+		f["weight"] = poundsInKilogram
+	}
+	if settings.DistanceUnit == Miles {
+		f["distance"] = milesInKilometer
+		f["pace"] = 1 / milesInKilometer
+	}
+	return f
+}
+
+// unitSource - type of field, which defines unit of fields of the same struct tagged unit:"by:<field>"
+type unitSource interface {
+	Unit() string
+}
+
+// ToUser - returns copy of data with values of fields tagged unit:"weight", unit:"distance" and unit:"pace"
+// converted from canonical units to units of user, rounded to 2 decimals. Fields tagged unit:"by:<field>" have unit
+// returned by Unit method of the field, they aren't converted if it is empty. Data is walked through structs, slices
+// and pointers
+func ToUser[T any](data T, settings stores.Settings) T {
+	f := factors(settings)
+	return convert(data, func(kind string, value float64) float64 {
+		return math.Round(value*f[kind]*100) / 100
+	})
+}
+
+// FromUser - returns copy of data with values of tagged fields converted from units of user to canonical ones
+func FromUser[T any](data T, settings stores.Settings) T {
+	f := factors(settings)
+	return convert(data, func(kind string, value float64) float64 {
+		return value / f[kind]
+	})
+}
+
+func convert[T any](data T, f func(kind string, value float64) float64) T {
+	v := reflect.ValueOf(&data).Elem()
+	walk(v, f)
+	return data
+}
+
+// walk - converts tagged fields of addressable v in place, slices and pointers are copied before conversion,
+// so data of caller stays unchanged
+func walk(v reflect.Value, f func(kind string, value float64) float64) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		copied := reflect.New(v.Elem().Type())
+		copied.Elem().Set(v.Elem())
+		walk(copied.Elem(), f)
+		v.Set(copied)
+	case reflect.Slice:
+		if v.IsNil() {
+			return
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(copied, v)
+		for i := 0; i < copied.Len(); i++ {
+			walk(copied.Index(i), f)
+		}
+		v.Set(copied)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := v.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			kind := t.Field(i).Tag.Get("unit")
+			if source, ok := strings.CutPrefix(kind, "by:"); ok {
+				kind = ""
+				sourceField := v.FieldByName(source)
+				if !sourceField.IsValid() || !sourceField.CanInterface() {
+					continue
+				}
+				if unit, ok := sourceField.Interface().(unitSource); ok {
+					kind = unit.Unit()
+				}
+			}
+			if kind != "" && field.Kind() == reflect.Float64 {
+				field.SetFloat(f(kind, field.Float()))
+				continue
+			}
+			walk(field, f)
+		}
+	}
+}
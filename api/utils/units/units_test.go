@@ -0,0 +1,111 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/fridrock/trainingservice/db/stores"
+	"github.com/google/go-cmp/cmp"
+)
+
+var pounds = stores.Settings{WeightUnit: Pounds, DistanceUnit: Miles}
+
+type distanced struct {
+	Distance float64 `unit:"distance"`
+	Laps     int64
+}
+
+// measure - kind of measured value, which defines its unit
+type measure string
+
+func (m measure) Unit() string {
+	if m == "length" {
+		return "distance"
+	}
+	return ""
+}
+
+type measured struct {
+	Measure measure
+	Value   float64 `unit:"by:Measure"`
+	Other   float64 `unit:"by:Unknown"`
+}
+
+func TestToUserUnitOfField(t *testing.T) {
+	converted := ToUser(measured{Measure: "length", Value: 10, Other: 5}, pounds)
+	if converted.Value != 6.21 || converted.Other != 5 {
+		t.Errorf("got wrong measured value: %#v", converted)
+	}
+	if converted := ToUser(measured{Measure: "count", Value: 10}, pounds); converted.Value != 10 {
+		t.Errorf("converted value without unit: %#v", converted)
+	}
+}
+
+func TestToUser(t *testing.T) {
+	sets := []stores.ExerciseSet{{Id: 1, Weight: 100, Reps: 5}, {Id: 2, Weight: 60, Reps: 10}}
+	converted := ToUser(sets, pounds)
+	expected := []stores.ExerciseSet{{Id: 1, Weight: 220.46, Reps: 5}, {Id: 2, Weight: 132.28, Reps: 10}}
+	if diff := cmp.Diff(expected, converted); diff != "" {
+		t.Errorf("got wrong sets: %s", diff)
+	}
+	//data of caller isn't changed
+	if sets[0].Weight != 100 {
+		t.Errorf("original sets were changed: %#v", sets)
+	}
+	//nested structures
+	template := stores.Template{Id: 1, Exercises: []stores.TemplateExercise{{ExerciseId: 1, Weight: 50}}}
+	if converted := ToUser(&template, pounds); converted.Exercises[0].Weight != 110.23 || template.Exercises[0].Weight != 50 {
+		t.Errorf("got wrong template: %#v", converted)
+	}
+	if converted := ToUser(distanced{Distance: 10, Laps: 25}, pounds); converted.Distance != 6.21 || converted.Laps != 25 {
+		t.Errorf("got wrong distance: %#v", converted)
+	}
+	//pace is time per distance, so it grows in miles
+	run := ToUser(stores.ExerciseSet{Distance: 10, Duration: 3000, Pace: 300, Speed: 12}, pounds)
+	if run.Distance != 6.21 || run.Duration != 3000 || run.Pace != 482.8 || run.Speed != 7.46 {
+		t.Errorf("got wrong cardio set: %#v", run)
+	}
+	//canonical units stay the same
+	if converted := ToUser(sets, stores.DefaultSettings(1)); converted[0].Weight != 100 {
+		t.Errorf("got converted weight in kilograms: %#v", converted)
+	}
+}
+
+func TestToUserRecords(t *testing.T) {
+	records := []stores.PersonalRecord{
+		{Kind: stores.MaxWeight, Value: 100, Previous: 90},
+		{Kind: stores.MaxReps, Weight: 100, Value: 12},
+		{Kind: stores.MaxDuration, Value: 60},
+	}
+	converted := ToUser(records, pounds)
+	if converted[0].Value != 220.46 || converted[0].Previous != 198.42 {
+		t.Errorf("got wrong weight record: %#v", converted[0])
+	}
+	if converted[1].Weight != 220.46 || converted[1].Value != 12 {
+		t.Errorf("got wrong reps record: %#v", converted[1])
+	}
+	if converted[2].Value != 60 {
+		t.Errorf("got wrong duration record: %#v", converted[2])
+	}
+}
+
+func TestFromUser(t *testing.T) {
+	set := FromUser(stores.ExerciseSet{Weight: 135, Reps: 5}, pounds)
+	if back := ToUser(set, pounds); back.Weight != 135 {
+		t.Errorf("weight isn't restored after conversion: %v, canonical %v", back.Weight, set.Weight)
+	}
+}
+
+func TestDefaultIncrement(t *testing.T) {
+	if DefaultIncrement(pounds) != 5 || DefaultIncrement(stores.DefaultSettings(1)) != 2.5 {
+		t.Error("got wrong default increment")
+	}
+}
+
+func TestValidateUnits(t *testing.T) {
+	if ValidateWeightUnit("lb") != nil || ValidateWeightUnit("st") != UnknownWeightUnit {
+		t.Error("wrong validation of weight unit")
+	}
+	if ValidateDistanceUnit("mi") != nil || ValidateDistanceUnit("m") != UnknownDistanceUnit {
+		t.Error("wrong validation of distance unit")
+	}
+}
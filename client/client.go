@@ -0,0 +1,166 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+var (
+	Timeout = errors.New("timeout waiting for response")
+	Stopped = errors.New("client is stopped")
+)
+
+// channel - part of amqp091.Channel, which client uses
+type channel interface {
+	PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool,
+		msg amqp091.Publishing) error
+	Close() error
+}
+
+// Client - performs RPC-style calls against training service, responses are matched to requests by correlation_id
+type Client struct {
+	ch         channel
+	replyQueue string
+	timeout    time.Duration
+	mu         sync.Mutex
+	pending    map[string]chan amqp091.Delivery
+	stopped    bool
+}
+
+// NewClient - creates client with own channel and exclusive reply queue, timeout is used for calls,
+// which context has no deadline
+func NewClient(configurer rs.Configurer, timeout time.Duration) (*Client, error) {
+	ch, err := configurer.GetConnection().Channel()
+	if err != nil {
+		return nil, err
+	}
+	q, err := ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		ch.Close()
+		return nil, err
+	}
+	deliveries, err := ch.Consume(q.Name, "", true, true, false, false, nil)
+	if err != nil {
+		ch.Close()
+		return nil, err
+	}
+	return newClient(ch, q.Name, deliveries, timeout), nil
+}
+
+// newClient - creates client, which publishes to ch and receives responses from deliveries of replyQueue
+func newClient(ch channel, replyQueue string, deliveries <-chan amqp091.Delivery, timeout time.Duration) *Client {
+	c := &Client{
+		ch:         ch,
+		replyQueue: replyQueue,
+		timeout:    timeout,
+		pending:    make(map[string]chan amqp091.Delivery),
+	}
+	go c.dispatch(deliveries)
+	return c
+}
+
+// dispatch - passes every response to the call waiting for it, when deliveries are closed, because channel or
+// connection is closed, client is stopped
+func (c *Client) dispatch(deliveries <-chan amqp091.Delivery) {
+	for d := range deliveries {
+		c.mu.Lock()
+		waiting, ok := c.pending[d.CorrelationId]
+		delete(c.pending, d.CorrelationId)
+		c.mu.Unlock()
+		if ok {
+			waiting <- d
+		}
+	}
+	c.stop()
+}
+
+// CallRaw - publishes body with routingKey and waits for the raw response
+func (c *Client) CallRaw(ctx context.Context, routingKey string, body []byte) (amqp091.Delivery, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	correlationId := newCorrelationId()
+	waiting := make(chan amqp091.Delivery, 1)
+	c.mu.Lock()
+	if c.stopped {
+		c.mu.Unlock()
+		return amqp091.Delivery{}, Stopped
+	}
+	c.pending[correlationId] = waiting
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, correlationId)
+		c.mu.Unlock()
+	}()
+	err := c.ch.PublishWithContext(ctx, responses.Exchange, routingKey, false, false, amqp091.Publishing{
+		ContentType:   "application/json",
+		CorrelationId: correlationId,
+		ReplyTo:       c.replyQueue,
+		Body:          body,
+	})
+	if err != nil {
+		return amqp091.Delivery{}, err
+	}
+	select {
+	case d, ok := <-waiting:
+		if !ok {
+			return amqp091.Delivery{}, Stopped
+		}
+		return d, nil
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return amqp091.Delivery{}, Timeout
+		}
+		return amqp091.Delivery{}, ctx.Err()
+	}
+}
+
+// Call - encodes request to json, publishes it with routingKey and decodes response envelope
+func (c *Client) Call(ctx context.Context, routingKey string, request any) (responses.Response, error) {
+	var response responses.Response
+	body, err := json.Marshal(request)
+	if err != nil {
+		return response, err
+	}
+	d, err := c.CallRaw(ctx, routingKey, body)
+	if err != nil {
+		return response, err
+	}
+	err = json.Unmarshal(d.Body, &response)
+	return response, err
+}
+
+// Stop - closes channel of client, calls waiting for responses fail with Stopped
+func (c *Client) Stop() {
+	c.stop()
+	c.ch.Close()
+}
+
+// stop - marks client stopped and closes channels of pending calls, so they fail with Stopped
+func (c *Client) stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+	for correlationId, waiting := range c.pending {
+		close(waiting)
+		delete(c.pending, correlationId)
+	}
+}
+
+func newCorrelationId() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
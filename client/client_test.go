@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fridrock/trainingservice/api/utils/responses"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// channelStub - channel, which passes published messages to published instead of broker
+type channelStub struct {
+	published chan amqp091.Publishing
+	mu        sync.Mutex
+	closed    bool
+}
+
+func newChannelStub() *channelStub {
+	return &channelStub{published: make(chan amqp091.Publishing, 10)}
+}
+
+func (cs *channelStub) PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool,
+	msg amqp091.Publishing) error {
+	if exchange != responses.Exchange {
+		panic("published to wrong exchange " + exchange)
+	}
+	cs.published <- msg
+	return nil
+}
+
+func (cs *channelStub) Close() error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.closed = true
+	return nil
+}
+
+// callResult - result of call made in background
+type callResult struct {
+	body string
+	err  error
+}
+
+// callAsync - makes call in background, result is sent to returned channel
+func callAsync(c *Client, body string) <-chan callResult {
+	result := make(chan callResult, 1)
+	go func() {
+		d, err := c.CallRaw(context.Background(), "trainings.exgroup.find", []byte(body))
+		result <- callResult{string(d.Body), err}
+	}()
+	return result
+}
+
+func TestCallMatchesCorrelationId(t *testing.T) {
+	ch := newChannelStub()
+	deliveries := make(chan amqp091.Delivery)
+	c := newClient(ch, "reply", deliveries, time.Second)
+	defer c.Stop()
+	first := callAsync(c, "first")
+	firstMsg := <-ch.published
+	second := callAsync(c, "second")
+	secondMsg := <-ch.published
+	if firstMsg.ReplyTo != "reply" || firstMsg.CorrelationId == secondMsg.CorrelationId {
+		t.Fatalf("published wrong messages: %#v, %#v", firstMsg, secondMsg)
+	}
+	//unknown response is dropped, responses are passed to calls in any order
+	deliveries <- amqp091.Delivery{CorrelationId: "unknown", Body: []byte("unknown")}
+	deliveries <- amqp091.Delivery{CorrelationId: secondMsg.CorrelationId, Body: secondMsg.Body}
+	deliveries <- amqp091.Delivery{CorrelationId: firstMsg.CorrelationId, Body: firstMsg.Body}
+	for _, call := range []struct {
+		result   <-chan callResult
+		expected string
+	}{{first, "first"}, {second, "second"}} {
+		result := <-call.result
+		if result.err != nil || result.body != call.expected {
+			t.Errorf("got wrong response: %#v, expected: %s", result, call.expected)
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.pending) != 0 {
+		t.Errorf("calls are still pending: %v", c.pending)
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	ch := newChannelStub()
+	c := newClient(ch, "reply", make(chan amqp091.Delivery), 10*time.Millisecond)
+	defer c.Stop()
+	_, err := c.CallRaw(context.Background(), "trainings.exgroup.find", []byte("{}"))
+	if err != Timeout {
+		t.Errorf("got wrong error after timeout: %v", err)
+	}
+	//deadline of context is used instead of timeout of client
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	c.timeout = time.Hour
+	_, err = c.CallRaw(ctx, "trainings.exgroup.find", []byte("{}"))
+	if err != Timeout {
+		t.Errorf("got wrong error after deadline of context: %v", err)
+	}
+}
+
+func TestStopFailsPendingCalls(t *testing.T) {
+	ch := newChannelStub()
+	c := newClient(ch, "reply", make(chan amqp091.Delivery), time.Hour)
+	result := callAsync(c, "{}")
+	<-ch.published
+	c.Stop()
+	select {
+	case r := <-result:
+		if r.err != Stopped {
+			t.Errorf("got wrong error of pending call: %v", r.err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("pending call isn't failed by stop")
+	}
+	if !ch.closed {
+		t.Error("channel isn't closed")
+	}
+	_, err := c.CallRaw(context.Background(), "trainings.exgroup.find", []byte("{}"))
+	if err != Stopped {
+		t.Errorf("got wrong error of call after stop: %v", err)
+	}
+}
+
+func TestClosedDeliveriesFailPendingCalls(t *testing.T) {
+	ch := newChannelStub()
+	deliveries := make(chan amqp091.Delivery)
+	c := newClient(ch, "reply", deliveries, time.Hour)
+	result := callAsync(c, "{}")
+	<-ch.published
+	close(deliveries)
+	if r := <-result; r.err != Stopped {
+		t.Errorf("got wrong error of pending call after closing deliveries: %v", r.err)
+	}
+}
@@ -23,7 +23,9 @@ func CreateConnection() *sqlx.DB {
 
 func createConnectionString() string {
 	dbName, dbUser, dbPassword, dbHost, dbPort := readEnvVariables()
-	result := fmt.Sprintf("postgresql://%v:%v@%v:%v/%v?sslmode=disable", dbUser, dbPassword, dbHost, dbPort, dbName)
+	//timestamps are read in UTC, whatever timezone database has
+	result := fmt.Sprintf("postgresql://%v:%v@%v:%v/%v?sslmode=disable&timezone=UTC",
+		dbUser, dbPassword, dbHost, dbPort, dbName)
 	return result
 }
 
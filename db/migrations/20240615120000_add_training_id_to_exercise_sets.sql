@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE exercise_sets ADD COLUMN IF NOT EXISTS training_id INTEGER REFERENCES trainings(id);
+CREATE INDEX IF NOT EXISTS exercise_sets_training_id_idx ON exercise_sets(training_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS exercise_sets_training_id_idx;
+ALTER TABLE exercise_sets DROP COLUMN IF EXISTS training_id;
+-- +goose StatementEnd
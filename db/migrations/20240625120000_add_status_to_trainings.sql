@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE trainings ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL DEFAULT 'open'
+    CHECK (status IN ('open', 'paused', 'finished', 'abandoned'));
+
+-- trainings with finish equal to begins were open, only the latest of them per user stays open
+UPDATE trainings SET status='finished' WHERE finish IS NOT NULL AND finish<>begins;
+UPDATE trainings SET finish=NULL WHERE finish=begins;
+UPDATE trainings t SET status='abandoned'
+WHERE t.status='open' AND EXISTS (
+    SELECT 1 FROM trainings newer
+    WHERE newer.user_id=t.user_id AND newer.status='open'
+    AND (newer.begins>t.begins OR (newer.begins=t.begins AND newer.id>t.id))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS trainings_one_in_progress_per_user_idx
+    ON trainings(user_id) WHERE status IN ('open', 'paused');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS trainings_one_in_progress_per_user_idx;
+UPDATE trainings SET finish=begins WHERE finish IS NULL;
+ALTER TABLE trainings DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
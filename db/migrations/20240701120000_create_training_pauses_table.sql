@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS training_pauses(
+    id SERIAL PRIMARY KEY,
+    training_id INTEGER NOT NULL REFERENCES trainings(id),
+    begins timestamp NOT NULL,
+    finish timestamp
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS training_pauses_one_open_per_training_idx
+    ON training_pauses(training_id) WHERE finish IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE trainings SET status='open' WHERE status='paused';
+DROP TABLE IF EXISTS training_pauses;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE exercise_sets ADD COLUMN IF NOT EXISTS created_at timestamp NOT NULL DEFAULT now();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE exercise_sets DROP COLUMN IF EXISTS created_at;
+-- +goose StatementEnd
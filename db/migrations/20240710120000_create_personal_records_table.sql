@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS personal_records(
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    kind varchar(20) NOT NULL CHECK (kind IN ('max_weight', 'max_reps', 'best_1rm', 'max_duration')),
+    weight REAL NOT NULL DEFAULT 0,
+    value double precision NOT NULL,
+    set_id INTEGER NOT NULL REFERENCES exercise_sets(id) ON DELETE CASCADE,
+    achieved_at timestamp NOT NULL,
+    UNIQUE (user_id, exercise_id, kind, weight)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS personal_records;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS workout_templates(
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    name varchar(100) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS template_exercises(
+    id SERIAL PRIMARY KEY,
+    template_id INTEGER NOT NULL REFERENCES workout_templates(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    sets INTEGER NOT NULL CHECK (sets > 0),
+    reps INTEGER NOT NULL DEFAULT 0,
+    weight REAL NOT NULL DEFAULT 0,
+    rest interval NOT NULL DEFAULT interval '0',
+    UNIQUE (template_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS planned_sets(
+    id SERIAL PRIMARY KEY,
+    training_id INTEGER NOT NULL REFERENCES trainings(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    set_number INTEGER NOT NULL,
+    reps INTEGER NOT NULL DEFAULT 0,
+    weight REAL NOT NULL DEFAULT 0,
+    rest interval NOT NULL DEFAULT interval '0',
+    set_id INTEGER REFERENCES exercise_sets(id) ON DELETE SET NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS planned_sets;
+DROP TABLE IF EXISTS template_exercises;
+DROP TABLE IF EXISTS workout_templates;
+-- +goose StatementEnd
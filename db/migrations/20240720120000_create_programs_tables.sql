@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS programs(
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    name varchar(100) NOT NULL,
+    weeks INTEGER NOT NULL CHECK (weeks > 0),
+    deload_every INTEGER NOT NULL DEFAULT 0,
+    deload_factor REAL NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS program_days(
+    id SERIAL PRIMARY KEY,
+    program_id INTEGER NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    template_id INTEGER NOT NULL REFERENCES workout_templates(id),
+    UNIQUE (program_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS program_progressions(
+    program_id INTEGER NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    increment REAL NOT NULL,
+    PRIMARY KEY (program_id, exercise_id)
+);
+
+CREATE TABLE IF NOT EXISTS program_enrollments(
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    program_id INTEGER NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    status varchar(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'cancelled')),
+    day_index INTEGER NOT NULL DEFAULT 0,
+    skipped INTEGER NOT NULL DEFAULT 0,
+    started_at timestamp NOT NULL,
+    advanced_at timestamp NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS program_enrollments_one_active_per_user_idx
+    ON program_enrollments(user_id) WHERE status = 'active';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS program_enrollments;
+DROP TABLE IF EXISTS program_progressions;
+DROP TABLE IF EXISTS program_days;
+DROP TABLE IF EXISTS programs;
+-- +goose StatementEnd
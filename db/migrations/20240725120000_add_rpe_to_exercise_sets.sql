@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE exercise_sets ADD COLUMN IF NOT EXISTS rpe REAL CHECK (rpe BETWEEN 1 AND 10);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE exercise_sets DROP COLUMN IF EXISTS rpe;
+-- +goose StatementEnd
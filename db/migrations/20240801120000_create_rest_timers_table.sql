@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS rest_timers(
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL UNIQUE,
+    training_id INTEGER NOT NULL REFERENCES trainings(id) ON DELETE CASCADE,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    set_id INTEGER NOT NULL REFERENCES exercise_sets(id) ON DELETE CASCADE,
+    rest interval NOT NULL,
+    fires_at timestamp NOT NULL
+);
+CREATE INDEX IF NOT EXISTS rest_timers_fires_at_idx ON rest_timers(fires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rest_timers;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS training_schedules(
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    weekday varchar(3) NOT NULL CHECK (weekday IN ('mon', 'tue', 'wed', 'thu', 'fri', 'sat', 'sun')),
+    at time NOT NULL,
+    timezone varchar(64) NOT NULL DEFAULT 'UTC',
+    created_at timestamptz NOT NULL,
+    reminded_at timestamptz,
+    missed_at timestamptz,
+    UNIQUE (user_id, weekday, at)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS training_schedules;
+-- +goose StatementEnd
@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+-- existing values are wall clock time of service, which wrote them. By default it is considered to be in timezone
+-- of database session, other one can be set with trainingservice.legacy_timezone setting
+CREATE OR REPLACE FUNCTION pg_temp.legacy_timezone() RETURNS text LANGUAGE sql AS $$
+    SELECT COALESCE(NULLIF(current_setting('trainingservice.legacy_timezone', true), ''), current_setting('TimeZone'))
+$$;
+ALTER TABLE trainings
+    ALTER COLUMN begins TYPE timestamptz USING begins AT TIME ZONE pg_temp.legacy_timezone(),
+    ALTER COLUMN finish TYPE timestamptz USING finish AT TIME ZONE pg_temp.legacy_timezone();
+ALTER TABLE training_pauses
+    ALTER COLUMN begins TYPE timestamptz USING begins AT TIME ZONE pg_temp.legacy_timezone(),
+    ALTER COLUMN finish TYPE timestamptz USING finish AT TIME ZONE pg_temp.legacy_timezone();
+ALTER TABLE exercise_sets
+    ALTER COLUMN created_at TYPE timestamptz USING created_at AT TIME ZONE pg_temp.legacy_timezone();
+ALTER TABLE personal_records
+    ALTER COLUMN achieved_at TYPE timestamptz USING achieved_at AT TIME ZONE pg_temp.legacy_timezone();
+ALTER TABLE program_enrollments
+    ALTER COLUMN started_at TYPE timestamptz USING started_at AT TIME ZONE pg_temp.legacy_timezone(),
+    ALTER COLUMN advanced_at TYPE timestamptz USING advanced_at AT TIME ZONE pg_temp.legacy_timezone();
+ALTER TABLE rest_timers
+    ALTER COLUMN fires_at TYPE timestamptz USING fires_at AT TIME ZONE pg_temp.legacy_timezone();
+ALTER TABLE processed_messages
+    ALTER COLUMN created_at TYPE timestamptz USING created_at AT TIME ZONE pg_temp.legacy_timezone();
+CREATE TABLE IF NOT EXISTS user_settings(
+    user_id INTEGER PRIMARY KEY,
+    timezone varchar(64) NOT NULL DEFAULT 'UTC'
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_settings;
+CREATE OR REPLACE FUNCTION pg_temp.legacy_timezone() RETURNS text LANGUAGE sql AS $$
+    SELECT COALESCE(NULLIF(current_setting('trainingservice.legacy_timezone', true), ''), current_setting('TimeZone'))
+$$;
+ALTER TABLE trainings
+    ALTER COLUMN begins TYPE timestamp USING begins AT TIME ZONE pg_temp.legacy_timezone(),
+    ALTER COLUMN finish TYPE timestamp USING finish AT TIME ZONE pg_temp.legacy_timezone();
+ALTER TABLE training_pauses
+    ALTER COLUMN begins TYPE timestamp USING begins AT TIME ZONE pg_temp.legacy_timezone(),
+    ALTER COLUMN finish TYPE timestamp USING finish AT TIME ZONE pg_temp.legacy_timezone();
+ALTER TABLE exercise_sets
+    ALTER COLUMN created_at TYPE timestamp USING created_at AT TIME ZONE pg_temp.legacy_timezone();
+ALTER TABLE personal_records
+    ALTER COLUMN achieved_at TYPE timestamp USING achieved_at AT TIME ZONE pg_temp.legacy_timezone();
+ALTER TABLE program_enrollments
+    ALTER COLUMN started_at TYPE timestamp USING started_at AT TIME ZONE pg_temp.legacy_timezone(),
+    ALTER COLUMN advanced_at TYPE timestamp USING advanced_at AT TIME ZONE pg_temp.legacy_timezone();
+ALTER TABLE rest_timers
+    ALTER COLUMN fires_at TYPE timestamp USING fires_at AT TIME ZONE pg_temp.legacy_timezone();
+ALTER TABLE processed_messages
+    ALTER COLUMN created_at TYPE timestamp USING created_at AT TIME ZONE pg_temp.legacy_timezone();
+-- +goose StatementEnd
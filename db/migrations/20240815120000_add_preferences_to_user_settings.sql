@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS weight_unit varchar(2) NOT NULL DEFAULT 'kg' CHECK (weight_unit IN ('kg', 'lb')),
+    ADD COLUMN IF NOT EXISTS distance_unit varchar(2) NOT NULL DEFAULT 'km' CHECK (distance_unit IN ('km', 'mi')),
+    ADD COLUMN IF NOT EXISTS default_rest interval NOT NULL DEFAULT interval '0',
+    ADD COLUMN IF NOT EXISTS week_start varchar(3) NOT NULL DEFAULT 'mon'
+        CHECK (week_start IN ('mon', 'tue', 'wed', 'thu', 'fri', 'sat', 'sun'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS weight_unit,
+    DROP COLUMN IF EXISTS distance_unit,
+    DROP COLUMN IF EXISTS default_rest,
+    DROP COLUMN IF EXISTS week_start;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE exercise_sets
+    ADD COLUMN IF NOT EXISTS distance double precision CHECK (distance > 0),
+    ADD COLUMN IF NOT EXISTS elevation REAL CHECK (elevation >= 0),
+    ADD COLUMN IF NOT EXISTS avg_heart_rate INTEGER CHECK (avg_heart_rate > 0),
+    ADD COLUMN IF NOT EXISTS max_heart_rate INTEGER CHECK (max_heart_rate > 0),
+    ADD COLUMN IF NOT EXISTS calories INTEGER CHECK (calories > 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE exercise_sets
+    DROP COLUMN IF EXISTS distance,
+    DROP COLUMN IF EXISTS elevation,
+    DROP COLUMN IF EXISTS avg_heart_rate,
+    DROP COLUMN IF EXISTS max_heart_rate,
+    DROP COLUMN IF EXISTS calories;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS catalogue_exercises(
+    id INTEGER PRIMARY KEY,
+    name varchar(100) NOT NULL UNIQUE,
+    aliases text[] NOT NULL DEFAULT '{}',
+    primary_muscles text[] NOT NULL DEFAULT '{}',
+    secondary_muscles text[] NOT NULL DEFAULT '{}',
+    equipment varchar(50) NOT NULL,
+    exercise_type_id INTEGER NOT NULL REFERENCES exercise_types(id)
+);
+
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS catalogue_id INTEGER REFERENCES catalogue_exercises(id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS exercises_one_copy_per_group_idx
+    ON exercises(user_id, exercise_group_id, catalogue_id) WHERE catalogue_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS exercises_one_copy_per_group_idx;
+ALTER TABLE exercises DROP COLUMN IF EXISTS catalogue_id;
+DROP TABLE IF EXISTS catalogue_exercises;
+-- +goose StatementEnd
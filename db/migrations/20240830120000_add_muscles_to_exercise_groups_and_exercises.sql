@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE exercise_groups ADD COLUMN IF NOT EXISTS muscles text[] NOT NULL DEFAULT '{}';
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS muscles text[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE exercises DROP COLUMN IF EXISTS muscles;
+ALTER TABLE exercise_groups DROP COLUMN IF EXISTS muscles;
+-- +goose StatementEnd
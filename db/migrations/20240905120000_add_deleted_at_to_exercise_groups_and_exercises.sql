@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE exercise_groups ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE exercises DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE exercise_groups DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE trainings ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+ALTER TABLE exercise_sets ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+UPDATE exercise_sets s SET deleted_at=e.deleted_at
+FROM exercises e WHERE e.id=s.exercise_id AND e.deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS exercise_groups_deleted_at_idx ON exercise_groups(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS exercises_deleted_at_idx ON exercises(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS trainings_deleted_at_idx ON trainings(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS exercise_sets_deleted_at_idx ON exercise_sets(deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS exercise_sets_deleted_at_idx;
+DROP INDEX IF EXISTS trainings_deleted_at_idx;
+DROP INDEX IF EXISTS exercises_deleted_at_idx;
+DROP INDEX IF EXISTS exercise_groups_deleted_at_idx;
+ALTER TABLE exercise_sets DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE trainings DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- groups with the same name in any case are merged into the oldest one
+CREATE TEMPORARY TABLE duplicate_groups ON COMMIT DROP AS
+SELECT id, keeper_id FROM (
+    SELECT g.id, first_value(g.id) OVER (PARTITION BY g.user_id, lower(g.name) ORDER BY g.id) AS keeper_id
+    FROM exercise_groups g WHERE g.deleted_at IS NULL
+) groups WHERE id<>keeper_id;
+-- copies of catalogue exercise, which merged group already has, become exercises of user
+UPDATE exercises e SET catalogue_id=NULL FROM (
+    SELECT e.id, row_number() OVER (
+        PARTITION BY COALESCE(d.keeper_id, e.exercise_group_id), e.catalogue_id
+        ORDER BY d.keeper_id IS NOT NULL, e.id) AS copy
+    FROM exercises e LEFT JOIN duplicate_groups d ON d.id=e.exercise_group_id
+    WHERE e.catalogue_id IS NOT NULL
+) copies WHERE copies.id=e.id AND copies.copy>1;
+UPDATE exercises e SET exercise_group_id=d.keeper_id FROM duplicate_groups d WHERE e.exercise_group_id=d.id;
+DELETE FROM exercise_groups g USING duplicate_groups d WHERE g.id=d.id;
+CREATE UNIQUE INDEX IF NOT EXISTS exercise_groups_one_name_per_user_idx
+    ON exercise_groups(user_id, lower(name)) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS exercise_groups_one_name_per_user_idx;
+-- +goose StatementEnd
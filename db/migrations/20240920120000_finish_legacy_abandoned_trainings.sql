@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- trainings abandoned by migration of statuses have no finish, they are closed with zero duration
+-- the same way as stale trainings
+UPDATE training_pauses p SET finish=p.begins FROM trainings t
+WHERE p.training_id=t.id AND t.status='abandoned' AND t.finish IS NULL AND p.finish IS NULL;
+UPDATE trainings SET finish=begins WHERE status='abandoned' AND finish IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- finish of abandoned trainings is kept, as it can't be told apart from finish set by sweeper
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- new schedules without own timezone follow timezone of user settings, it is resolved only where timezone is NULL.
+-- Timezones of existing schedules are kept as they are, even if they are equal to timezone of settings, because
+-- there is no way to tell copied timezone from the chosen one
+ALTER TABLE training_schedules ALTER COLUMN timezone DROP NOT NULL, ALTER COLUMN timezone DROP DEFAULT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE training_schedules s SET timezone=COALESCE(
+    (SELECT us.timezone FROM user_settings us WHERE us.user_id=s.user_id), 'UTC')
+WHERE s.timezone IS NULL;
+ALTER TABLE training_schedules ALTER COLUMN timezone SET DEFAULT 'UTC', ALTER COLUMN timezone SET NOT NULL;
+-- +goose StatementEnd
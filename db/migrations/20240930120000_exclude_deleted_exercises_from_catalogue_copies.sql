@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- deleted copy of catalogue exercise doesn't prevent linking it to the group again
+DROP INDEX IF EXISTS exercises_one_copy_per_group_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS exercises_one_copy_per_group_idx
+    ON exercises(user_id, exercise_group_id, catalogue_id) WHERE catalogue_id IS NOT NULL AND deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- deleted copies, which group has other copy of, become exercises of user
+UPDATE exercises e SET catalogue_id=NULL FROM (
+    SELECT e.id, row_number() OVER (
+        PARTITION BY e.user_id, e.exercise_group_id, e.catalogue_id
+        ORDER BY e.deleted_at IS NOT NULL, e.id) AS copy
+    FROM exercises e WHERE e.catalogue_id IS NOT NULL
+) copies WHERE copies.id=e.id AND copies.copy>1;
+DROP INDEX IF EXISTS exercises_one_copy_per_group_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS exercises_one_copy_per_group_idx
+    ON exercises(user_id, exercise_group_id, catalogue_id) WHERE catalogue_id IS NOT NULL;
+-- +goose StatementEnd
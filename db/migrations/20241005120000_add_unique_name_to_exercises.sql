@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- exercises with the same name in any case in one group get number of the duplicate, the oldest one keeps its name
+UPDATE exercises e SET name=left(e.name, 94) || ' (' || duplicates.number || ')' FROM (
+    SELECT e.id, row_number() OVER (
+        PARTITION BY e.user_id, e.exercise_group_id, lower(e.name) ORDER BY e.id) AS number
+    FROM exercises e WHERE e.deleted_at IS NULL
+) duplicates WHERE duplicates.id=e.id AND duplicates.number>1;
+CREATE UNIQUE INDEX IF NOT EXISTS exercises_one_name_per_group_idx
+    ON exercises(user_id, exercise_group_id, lower(name)) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS exercises_one_name_per_group_idx;
+-- +goose StatementEnd
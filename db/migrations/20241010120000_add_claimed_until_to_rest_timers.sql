@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- due timer is claimed by one instance of service until claimed_until, so other instances don't fire it
+ALTER TABLE rest_timers ADD COLUMN IF NOT EXISTS claimed_until timestamptz;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE rest_timers DROP COLUMN IF EXISTS claimed_until;
+-- +goose StatementEnd
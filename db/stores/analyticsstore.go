@@ -0,0 +1,111 @@
+package stores
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// AnalyzedSet - set together with its training, exercise and exercise group, which is used for computing analytics
+type AnalyzedSet struct {
+	ExerciseSet
+	TrainingBegins time.Time `db:"training_begins" json:"training_begins"`
+	ExerciseName   string    `db:"exercise_name" json:"exercise_name"`
+	GroupId        int64     `db:"group_id" json:"group_id"`
+	GroupName      string    `db:"group_name" json:"group_name"`
+}
+
+// MuscleSet - set together with muscles trained by it. Primary muscles are muscles of exercise, if exercise is
+// mapped, else primary muscles of catalogue exercise it is copy of, else muscles of its group. Secondary muscles are
+// taken only from catalogue. Set of unmapped exercise has no muscles
+type MuscleSet struct {
+	AnalyzedSet
+	PrimaryMuscles   pq.StringArray `db:"primary_muscles" json:"primary_muscles"`
+	SecondaryMuscles pq.StringArray `db:"secondary_muscles" json:"secondary_muscles"`
+}
+
+// AnalyticsStore - interface which contains all methods for reading raw data for analytics
+type AnalyticsStore interface {
+	FindSets(userId int64, from time.Time, to time.Time) ([]AnalyzedSet, error)
+	FindExerciseSets(userId int64, exerciseId int64, from time.Time, to time.Time) ([]AnalyzedSet, error)
+	FindMuscleSets(userId int64, from time.Time, to time.Time) ([]MuscleSet, error)
+}
+
+// analyzedSetColumns - columns of sets with their trainings, exercises and groups
+const analyzedSetColumns = exerciseSetColumns + `, t.begins AS training_begins,
+		e.name AS exercise_name, g.id AS group_id, g.name AS group_name`
+
+// analyzedSetSource - joins of sets with their trainings, exercises and groups
+const analyzedSetSource = ` FROM exercise_sets s
+	JOIN trainings t ON t.id=s.training_id
+	JOIN exercises e ON e.id=s.exercise_id
+	JOIN exercise_groups g ON g.id=e.exercise_group_id`
+
+// analyzedSetQuery - sets of user with their trainings, exercises and groups, for trainings which began in [$2, $3)
+const analyzedSetQuery = `SELECT ` + analyzedSetColumns + analyzedSetSource + `
+	WHERE s.user_id=$1 AND t.begins>=$2 AND t.begins<$3 AND s.deleted_at IS NULL`
+
+// muscleSetQuery - sets of user with muscles of their exercises, for trainings which began in [$2, $3)
+const muscleSetQuery = `SELECT ` + analyzedSetColumns + `,
+		CASE WHEN cardinality(e.muscles)>0 THEN e.muscles
+			WHEN c.id IS NOT NULL THEN c.primary_muscles
+			ELSE g.muscles END AS primary_muscles,
+		CASE WHEN cardinality(e.muscles)=0 AND c.id IS NOT NULL THEN c.secondary_muscles
+			ELSE '{}'::text[] END AS secondary_muscles` + analyzedSetSource + `
+	LEFT JOIN catalogue_exercises c ON c.id=e.catalogue_id
+	WHERE s.user_id=$1 AND t.begins>=$2 AND t.begins<$3 AND s.deleted_at IS NULL`
+
+// ANS - standard realization of AnalyticsStore
+type ANS struct {
+	conn *sqlx.DB
+}
+
+// NewANS - function that creates realization for AnalyticsStore interface
+func NewANS(conn *sqlx.DB) *ANS {
+	return &ANS{
+		conn: conn,
+	}
+}
+
+// FindSets - sets of trainings, which began in [from, to), times are returned in location of from
+func (ans ANS) FindSets(userId int64, from time.Time, to time.Time) ([]AnalyzedSet, error) {
+	var sets []AnalyzedSet
+	q := analyzedSetQuery + ` ORDER BY t.begins, s.id`
+	err := ans.conn.Select(&sets, q, userId, from, to)
+	return inLocation(sets, from.Location()), err
+}
+
+// FindExerciseSets - sets of exercise in trainings, which began in [from, to), times are returned in location of from
+func (ans ANS) FindExerciseSets(userId int64, exerciseId int64, from time.Time, to time.Time) ([]AnalyzedSet, error) {
+	var sets []AnalyzedSet
+	q := analyzedSetQuery + ` AND s.exercise_id=$4 ORDER BY t.begins, s.id`
+	err := ans.conn.Select(&sets, q, userId, from, to, exerciseId)
+	return inLocation(sets, from.Location()), err
+}
+
+// FindMuscleSets - sets with their muscles of trainings, which began in [from, to), times are returned in location
+// of from
+func (ans ANS) FindMuscleSets(userId int64, from time.Time, to time.Time) ([]MuscleSet, error) {
+	var sets []MuscleSet
+	q := muscleSetQuery + ` ORDER BY t.begins, s.id`
+	err := ans.conn.Select(&sets, q, userId, from, to)
+	for i := range sets {
+		sets[i].in(from.Location())
+	}
+	return sets, err
+}
+
+// inLocation - converts times of sets to loc, so they are grouped by days and weeks of user
+func inLocation(sets []AnalyzedSet, loc *time.Location) []AnalyzedSet {
+	for i := range sets {
+		sets[i].in(loc)
+	}
+	return sets
+}
+
+// in - converts times of set to loc
+func (set *AnalyzedSet) in(loc *time.Location) {
+	set.TrainingBegins = set.TrainingBegins.In(loc)
+	set.CreatedAt = set.CreatedAt.In(loc)
+}
@@ -0,0 +1,71 @@
+package stores
+
+import (
+	"testing"
+	"time"
+)
+
+func TestANSFindSets(t *testing.T) {
+	ans := NewANS(conn)
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	ts.StartTraining(1)
+	ess.AddSet(ExerciseSet{UserId: 1, ExerciseId: exercise.Id, Weight: 60, Reps: 10})
+	ess.AddSet(ExerciseSet{UserId: 1, ExerciseId: exercise.Id, Weight: 65, Reps: 8})
+	from := time.Now().Add(-time.Hour)
+	to := time.Now().Add(time.Hour)
+	sets, err := ans.FindSets(1, from, to)
+	if err != nil {
+		t.Fatalf("error finding sets: %v", err)
+	}
+	if len(sets) != 2 || sets[0].Weight != 60 || sets[0].ExerciseName != exercise.Name ||
+		sets[0].GroupId != exercise.ExerciseGroupId || sets[0].GroupName != defaultExGroup.Name {
+		t.Errorf("got wrong sets: %#v", sets)
+	}
+	sets, _ = ans.FindExerciseSets(1, exercise.Id+1, from, to)
+	if len(sets) != 0 {
+		t.Errorf("got sets of other exercise: %#v", sets)
+	}
+	//sets of trainings out of range aren't found
+	sets, _ = ans.FindSets(1, to, to.Add(time.Hour))
+	if len(sets) != 0 {
+		t.Errorf("got sets out of range: %#v", sets)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestANSFindMuscleSets(t *testing.T) {
+	ans := NewANS(conn)
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	cts.Seed([]CatalogueExercise{{Id: 1, Name: "Bench press", PrimaryMuscles: []string{"chest"},
+		SecondaryMuscles: []string{"triceps"}, Equipment: "barbell", Type: Gym}})
+	benchId, _ := cts.Link(1, 1, exercise.ExerciseGroupId)
+	ts.StartTraining(1)
+	ess.AddSet(ExerciseSet{UserId: 1, ExerciseId: exercise.Id, Reps: 10})
+	ess.AddSet(ExerciseSet{UserId: 1, ExerciseId: benchId, Weight: 60, Reps: 10})
+	from := time.Now().Add(-time.Hour)
+	to := time.Now().Add(time.Hour)
+	//unmapped exercise has no muscles, copy of catalogue exercise has muscles of catalogue
+	sets, err := ans.FindMuscleSets(1, from, to)
+	if err != nil {
+		t.Fatalf("error finding sets: %v", err)
+	}
+	if len(sets) != 2 || len(sets[0].PrimaryMuscles) != 0 ||
+		sets[1].PrimaryMuscles[0] != "chest" || sets[1].SecondaryMuscles[0] != "triceps" {
+		t.Errorf("got wrong sets: %#v", sets)
+	}
+	//exercise falls back to muscles of group, mapping of exercise overrides catalogue
+	egs.SetMuscles(1, defaultExGroup.Name, []string{"lats"})
+	ex.SetMuscles(1, "", "Bench press", []string{"shoulders"})
+	sets, _ = ans.FindMuscleSets(1, from, to)
+	if len(sets) != 2 || sets[0].PrimaryMuscles[0] != "lats" ||
+		sets[1].PrimaryMuscles[0] != "shoulders" || len(sets[1].SecondaryMuscles) != 0 {
+		t.Errorf("got wrong mapped sets: %#v", sets)
+	}
+	t.Cleanup(clearTables)
+}
@@ -0,0 +1,73 @@
+package stores
+
+import (
+	"time"
+)
+
+type AnalyticsStoreStub struct{}
+
+// FindSets - two trainings of user in the first week of range, user 1 has no sets
+func (anss AnalyticsStoreStub) FindSets(userId int64, from time.Time, to time.Time) ([]AnalyzedSet, error) {
+	if userId == 1 {
+		return nil, nil
+	}
+	first := from.Add(10 * time.Hour)
+	second := from.AddDate(0, 0, 2).Add(10 * time.Hour)
+	set := func(id, trainingId int64, begins time.Time, exerciseId int64, weight float64, reps int64) AnalyzedSet {
+		groupId, groupName, exerciseName := int64(1), "Back", "Pull up"
+		if exerciseId == 2 {
+			groupId, groupName, exerciseName = 2, "Legs", "Squat"
+		}
+		return AnalyzedSet{
+			ExerciseSet: ExerciseSet{
+				Id:         id,
+				UserId:     userId,
+				ExerciseId: exerciseId,
+				TrainingId: trainingId,
+				Weight:     weight,
+				Reps:       reps,
+				CreatedAt:  begins,
+			},
+			TrainingBegins: begins,
+			ExerciseName:   exerciseName,
+			GroupId:        groupId,
+			GroupName:      groupName,
+		}
+	}
+	return []AnalyzedSet{
+		set(1, 1, first, 1, 60, 10),
+		set(2, 1, first, 2, 100, 5),
+		set(3, 2, second, 1, 65, 8),
+	}, nil
+}
+
+func (anss AnalyticsStoreStub) FindExerciseSets(userId int64, exerciseId int64, from time.Time, to time.Time) ([]AnalyzedSet, error) {
+	sets, err := anss.FindSets(userId, from, to)
+	var found []AnalyzedSet
+	for _, set := range sets {
+		if set.ExerciseId == exerciseId {
+			found = append(found, set)
+		}
+	}
+	return found, err
+}
+
+// FindMuscleSets - sets of FindSets, pull ups train lats and biceps, squats train quads and glutes,
+// user 3 has no mapped exercises
+func (anss AnalyticsStoreStub) FindMuscleSets(userId int64, from time.Time, to time.Time) ([]MuscleSet, error) {
+	sets, err := anss.FindSets(userId, from, to)
+	var muscleSets []MuscleSet
+	for _, set := range sets {
+		muscleSet := MuscleSet{AnalyzedSet: set}
+		if userId != 3 {
+			muscleSet.PrimaryMuscles = []string{"lats"}
+			muscleSet.SecondaryMuscles = []string{"biceps"}
+			if set.ExerciseId == 2 {
+				muscleSet.PrimaryMuscles = []string{"quads", "glutes"}
+				muscleSet.SecondaryMuscles = []string{}
+			}
+		}
+		muscleSets = append(muscleSets, muscleSet)
+	}
+	return muscleSets, err
+}
@@ -0,0 +1,125 @@
+package stores
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fridrock/trainingservice/errkind"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// CatalogueExercise struct that is entity for catalogue_exercises table, catalogue is global and is seeded from
+// data file on start. Muscles are names of muscle groups, type is name of exercise type
+type CatalogueExercise struct {
+	Id               int64          `db:"id" json:"id"`
+	Name             string         `db:"name" json:"name"`
+	Aliases          pq.StringArray `db:"aliases" json:"aliases"`
+	PrimaryMuscles   pq.StringArray `db:"primary_muscles" json:"primary_muscles"`
+	SecondaryMuscles pq.StringArray `db:"secondary_muscles" json:"secondary_muscles"`
+	Equipment        string         `db:"equipment" json:"equipment"`
+	Type             string         `db:"type" json:"type"`
+}
+
+// CatalogueStore - interface which contains all methods for working with catalogue_exercises table
+type CatalogueStore interface {
+	Seed(exercises []CatalogueExercise) error
+	FindAll() ([]CatalogueExercise, error)
+	FindById(id int64) (CatalogueExercise, error)
+	Link(userId int64, catalogueId int64, groupId int64) (int64, error)
+}
+
+var (
+	AlreadyLinked = errkind.New(errkind.Conflict, "catalogue exercise is already in the group")
+)
+
+// copyIndex - unique index on copies of catalogue exercise in not deleted exercises of group
+const copyIndex = "exercises_one_copy_per_group_idx"
+
+// checkCopy - converts violation of unique copy of catalogue exercise to AlreadyLinked
+func checkCopy(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == copyIndex {
+		return AlreadyLinked
+	}
+	return err
+}
+
+// catalogueColumns - columns of catalogue_exercises table with name of exercise type
+const catalogueColumns = `c.id, c.name, c.aliases, c.primary_muscles, c.secondary_muscles, c.equipment, t.name AS type`
+
+// CTS - standard realization of CatalogueStore
+type CTS struct {
+	conn *sqlx.DB
+}
+
+// NewCTS - function that creates realization for CatalogueStore interface
+func NewCTS(conn *sqlx.DB) *CTS {
+	return &CTS{
+		conn: conn,
+	}
+}
+
+// Seed - inserts catalogue exercises or updates existing ones with the same id. Exercises removed from data aren't
+// deleted, because users may have linked copies of them
+func (cts CTS) Seed(exercises []CatalogueExercise) error {
+	tx, err := cts.conn.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := `INSERT INTO catalogue_exercises(id, name, aliases, primary_muscles, secondary_muscles, equipment,
+			exercise_type_id)
+		SELECT $1, $2, $3, $4, $5, $6, t.id FROM exercise_types t WHERE t.name=$7
+		ON CONFLICT (id) DO UPDATE SET name=EXCLUDED.name, aliases=EXCLUDED.aliases,
+			primary_muscles=EXCLUDED.primary_muscles, secondary_muscles=EXCLUDED.secondary_muscles,
+			equipment=EXCLUDED.equipment, exercise_type_id=EXCLUDED.exercise_type_id`
+	for _, exercise := range exercises {
+		res, err := tx.Exec(q, exercise.Id, exercise.Name, exercise.Aliases, exercise.PrimaryMuscles,
+			exercise.SecondaryMuscles, exercise.Equipment, exercise.Type)
+		if err != nil {
+			return err
+		}
+		r, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if r == 0 {
+			return fmt.Errorf("catalogue exercise %d has unknown type %s", exercise.Id, exercise.Type)
+		}
+	}
+	return tx.Commit()
+}
+
+func (cts CTS) FindAll() ([]CatalogueExercise, error) {
+	var exercises []CatalogueExercise
+	q := `SELECT ` + catalogueColumns + ` FROM catalogue_exercises c
+		JOIN exercise_types t ON t.id=c.exercise_type_id ORDER BY c.id`
+	err := cts.conn.Select(&exercises, q)
+	return exercises, err
+}
+
+func (cts CTS) FindById(id int64) (CatalogueExercise, error) {
+	var exercise CatalogueExercise
+	q := `SELECT ` + catalogueColumns + ` FROM catalogue_exercises c
+		JOIN exercise_types t ON t.id=c.exercise_type_id WHERE c.id=$1`
+	err := cts.conn.Get(&exercise, q, id)
+	return exercise, err
+}
+
+// Link - creates personal copy of catalogue exercise in exercise group of user, copy keeps reference to catalogue
+// and has no own rest. Returns sql.ErrNoRows if there is no such catalogue exercise or user has no such group, and
+// AlreadyLinked if the group already has copy of it or ExerciseNameTaken if the group has exercise with its name
+func (cts CTS) Link(userId int64, catalogueId int64, groupId int64) (int64, error) {
+	var exerciseId int64
+	q := `INSERT INTO exercises(name, description, rest, exercise_type_id, user_id, exercise_group_id, catalogue_id)
+		SELECT c.name, '', interval '0', c.exercise_type_id, $1, g.id, c.id
+		FROM catalogue_exercises c, exercise_groups g
+		WHERE c.id=$2 AND g.id=$3 AND g.user_id=$1 AND g.deleted_at IS NULL
+		RETURNING id`
+	err := cts.conn.Get(&exerciseId, q, userId, catalogueId, groupId)
+	if err != nil {
+		return 0, checkExercise(err)
+	}
+	return exerciseId, nil
+}
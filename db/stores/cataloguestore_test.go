@@ -0,0 +1,106 @@
+package stores
+
+import (
+	"database/sql"
+	"testing"
+)
+
+var testCatalogue = []CatalogueExercise{
+	{
+		Id:               1,
+		Name:             "Bench press",
+		Aliases:          []string{"flat bench"},
+		PrimaryMuscles:   []string{"chest"},
+		SecondaryMuscles: []string{"triceps", "shoulders"},
+		Equipment:        "barbell",
+		Type:             Gym,
+	},
+	{
+		Id:               2,
+		Name:             "Running",
+		Aliases:          []string{"jogging"},
+		PrimaryMuscles:   []string{"quads", "hamstrings"},
+		SecondaryMuscles: []string{"calves"},
+		Equipment:        "none",
+		Type:             Cardio,
+	},
+}
+
+func TestCTSSeed(t *testing.T) {
+	err := cts.Seed(testCatalogue)
+	if err != nil {
+		t.Fatalf("error seeding catalogue: %v", err)
+	}
+	//seeding twice updates exercises
+	renamed := append([]CatalogueExercise{}, testCatalogue...)
+	renamed[1].Name = "Treadmill running"
+	err = cts.Seed(renamed)
+	if err != nil {
+		t.Fatalf("error reseeding catalogue: %v", err)
+	}
+	exercises, err := cts.FindAll()
+	if err != nil {
+		t.Fatalf("error finding catalogue: %v", err)
+	}
+	if len(exercises) != 2 || exercises[1].Name != "Treadmill running" || exercises[1].Type != Cardio {
+		t.Errorf("got wrong catalogue: %#v", exercises)
+	}
+	exercise, err := cts.FindById(1)
+	if err != nil || exercise.Equipment != "barbell" || len(exercise.SecondaryMuscles) != 2 {
+		t.Errorf("got wrong catalogue exercise: %#v, %v", exercise, err)
+	}
+	_, err = cts.FindById(404)
+	if err != sql.ErrNoRows {
+		t.Errorf("found unknown catalogue exercise: %v", err)
+	}
+	//unknown type
+	err = cts.Seed([]CatalogueExercise{{Id: 3, Name: "Yoga", Type: "STRETCHING"}})
+	if err == nil {
+		t.Errorf("seeded exercise with unknown type")
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestCTSLink(t *testing.T) {
+	cts.Seed(testCatalogue)
+	groupId, err := createDefaultExGroup()
+	if err != nil {
+		t.Fatalf("error saving group: %v", err)
+	}
+	exerciseId, err := cts.Link(defaultExGroup.UserId, 1, groupId)
+	if err != nil {
+		t.Fatalf("error linking catalogue exercise: %v", err)
+	}
+	exercise, err := ex.FindById(exerciseId)
+	if err != nil {
+		t.Fatalf("error finding linked exercise: %v", err)
+	}
+	if exercise.Name != "Bench press" || exercise.CatalogueId != 1 || exercise.ExerciseGroupId != groupId {
+		t.Errorf("got wrong linked exercise: %#v", exercise)
+	}
+	//only one copy per group
+	_, err = cts.Link(defaultExGroup.UserId, 1, groupId)
+	if err != AlreadyLinked {
+		t.Errorf("linked exercise twice: %v", err)
+	}
+	//group of other user
+	_, err = cts.Link(defaultExGroup.UserId+1, 2, groupId)
+	if err != sql.ErrNoRows {
+		t.Errorf("linked exercise to group of other user: %v", err)
+	}
+	_, err = cts.Link(defaultExGroup.UserId, 404, groupId)
+	if err != sql.ErrNoRows {
+		t.Errorf("linked unknown catalogue exercise: %v", err)
+	}
+	//deleted copy doesn't prevent linking again, but can't be restored then
+	ex.DeleteById(exerciseId)
+	_, err = cts.Link(defaultExGroup.UserId, 1, groupId)
+	if err != nil {
+		t.Fatalf("error linking catalogue exercise after deleting copy: %v", err)
+	}
+	err = trs.Restore(defaultExGroup.UserId, TrashExercise, exerciseId)
+	if err != AlreadyLinked {
+		t.Errorf("restored second copy of catalogue exercise: %v", err)
+	}
+	t.Cleanup(clearTables)
+}
@@ -0,0 +1,64 @@
+package stores
+
+import (
+	"database/sql"
+)
+
+type CatalogueStoreStub struct{}
+
+var stubCatalogue = []CatalogueExercise{
+	{
+		Id:               1,
+		Name:             "Bench press",
+		Aliases:          []string{"flat bench"},
+		PrimaryMuscles:   []string{"chest"},
+		SecondaryMuscles: []string{"triceps", "shoulders"},
+		Equipment:        "barbell",
+		Type:             Gym,
+	},
+	{
+		Id:               2,
+		Name:             "Incline bench press",
+		Aliases:          []string{},
+		PrimaryMuscles:   []string{"chest"},
+		SecondaryMuscles: []string{"shoulders", "triceps"},
+		Equipment:        "barbell",
+		Type:             Gym,
+	},
+	{
+		Id:               3,
+		Name:             "Running",
+		Aliases:          []string{"jogging"},
+		PrimaryMuscles:   []string{"quads", "hamstrings"},
+		SecondaryMuscles: []string{"calves"},
+		Equipment:        "none",
+		Type:             Cardio,
+	},
+}
+
+func (ctss CatalogueStoreStub) Seed(exercises []CatalogueExercise) error {
+	return nil
+}
+
+func (ctss CatalogueStoreStub) FindAll() ([]CatalogueExercise, error) {
+	return stubCatalogue, nil
+}
+
+func (ctss CatalogueStoreStub) FindById(id int64) (CatalogueExercise, error) {
+	if id < 1 || id > int64(len(stubCatalogue)) {
+		return CatalogueExercise{}, sql.ErrNoRows
+	}
+	return stubCatalogue[id-1], nil
+}
+
+// Link - user 1 has no groups, group 2 already has copy of every exercise
+func (ctss CatalogueStoreStub) Link(userId int64, catalogueId int64, groupId int64) (int64, error) {
+	_, err := ctss.FindById(catalogueId)
+	if err != nil || userId == 1 {
+		return 0, sql.ErrNoRows
+	}
+	if groupId == 2 {
+		return 0, AlreadyLinked
+	}
+	return 7, nil
+}
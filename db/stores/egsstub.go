@@ -2,11 +2,26 @@ package stores
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"strings"
+
+	"github.com/lib/pq"
 )
 
 type EGSStub struct{}
 
+// Save - user already has group Front, group Internal fails with lost connection, group Invalid violates
+// constraint of database
 func (egss EGSStub) Save(group ExGroup) (int64, error) {
+	if group.Name == "Internal" {
+		return 0, driver.ErrBadConn
+	}
+	if group.Name == "Invalid" {
+		return 0, &pq.Error{Code: "23514", Message: "violates check constraint"}
+	}
+	if strings.EqualFold(group.Name, "Front") {
+		return 0, NameTaken
+	}
 	return 1, nil
 }
 func (egss EGSStub) FindById(id int64) (ExGroup, error) {
@@ -29,12 +44,44 @@ func (egss EGSStub) FindByName(userId int64, name string) (group ExGroup, err er
 func (egss EGSStub) DeleteById(id int64) error {
 	return nil
 }
+
+// DeleteByName - group Chest has exercises
 func (egss EGSStub) DeleteByName(userId int64, name string) error {
+	switch name {
+	case "Unexisting":
+		return NotDeleted
+	case "Chest":
+		return DependentExercises{"Bench press", "Push up"}
+	}
+	return nil
+}
+
+func (egss EGSStub) MoveByName(userId int64, name string, targetName string) error {
 	if name == "Unexisting" {
 		return NotDeleted
-	} else {
-		return nil
 	}
+	if targetName == "Unexisting" {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (egss EGSStub) SoftDeleteByName(userId int64, name string) error {
+	if name == "Unexisting" {
+		return NotDeleted
+	}
+	return nil
+}
+
+// RestoreByName - user already has other group Back
+func (egss EGSStub) RestoreByName(userId int64, name string) error {
+	switch name {
+	case "Unexisting":
+		return NotUpdated
+	case "Back":
+		return NameTaken
+	}
+	return nil
 }
 func (egss EGSStub) Update(group ExGroup) error {
 	return nil
@@ -43,6 +90,9 @@ func (egss EGSStub) UpdateByName(userId int64, name string, newName string) erro
 	if name == "Unexisting" {
 		return NotUpdated
 	}
+	if strings.EqualFold(newName, "Front") {
+		return NameTaken
+	}
 	return nil
 }
 
@@ -69,3 +119,10 @@ func (egss EGSStub) FindByUserId(userId int64) ([]ExGroup, error) {
 	}
 	return groups, nil
 }
+
+func (egss EGSStub) SetMuscles(userId int64, name string, muscles []string) error {
+	if name == "Unexisting" {
+		return NotUpdated
+	}
+	return nil
+}
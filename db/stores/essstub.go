@@ -0,0 +1,128 @@
+package stores
+
+import (
+	"database/sql"
+)
+
+type ExerciseSetStoreStub struct{}
+
+func (esss ExerciseSetStoreStub) AddSet(set ExerciseSet) (ExerciseSet, error) {
+	if set.UserId == 1 {
+		return set, AllTrainingsFinished
+	}
+	set.Id = 1
+	set.TrainingId = 12
+	return set, nil
+}
+
+func (esss ExerciseSetStoreStub) UndoSet(userId int64) error {
+	if userId == 1 {
+		return NotDeleted
+	}
+	return nil
+}
+
+func (esss ExerciseSetStoreStub) FindCurrent(userId int64) ([]ExerciseSet, error) {
+	if userId == 1 {
+		return nil, sql.ErrNoRows
+	}
+	return esss.FindByTraining(userId, 12)
+}
+
+func (esss ExerciseSetStoreStub) FindByTraining(userId int64, trainingId int64) ([]ExerciseSet, error) {
+	sets := []ExerciseSet{
+		{
+			Id:         1,
+			UserId:     userId,
+			ExerciseId: 1,
+			TrainingId: trainingId,
+			Weight:     60,
+			Reps:       10,
+		},
+		{
+			Id:         2,
+			UserId:     userId,
+			ExerciseId: 1,
+			TrainingId: trainingId,
+			Weight:     62.5,
+			Reps:       8,
+		},
+	}
+	return sets, nil
+}
+
+// FindLastSessions - exercise 1 has two sessions, others have no history
+func (esss ExerciseSetStoreStub) FindLastSessions(userId int64, exerciseId int64, sessions int64) ([]ExerciseSet, error) {
+	if exerciseId != 1 {
+		return nil, nil
+	}
+	sets, _ := esss.FindByTraining(userId, 11)
+	last := []ExerciseSet{
+		{Id: 3, UserId: userId, ExerciseId: 1, TrainingId: 12, Weight: 62.5, Reps: 12, Rpe: 7},
+		{Id: 4, UserId: userId, ExerciseId: 1, TrainingId: 12, Weight: 62.5, Reps: 12, Rpe: 8},
+	}
+	return append(sets, last...), nil
+}
+
+func (esss ExerciseSetStoreStub) FindPlanned(userId int64) ([]PlannedSet, error) {
+	if userId == 1 {
+		return nil, sql.ErrNoRows
+	}
+	planned := []PlannedSet{
+		{
+			Id:         1,
+			TrainingId: 12,
+			Position:   1,
+			ExerciseId: 1,
+			SetNumber:  1,
+			Reps:       10,
+			Weight:     60,
+			Rest:       90,
+			SetId:      1,
+		},
+		{
+			Id:         2,
+			TrainingId: 12,
+			Position:   1,
+			ExerciseId: 1,
+			SetNumber:  2,
+			Reps:       10,
+			Weight:     60,
+			Rest:       90,
+		},
+		{
+			Id:         3,
+			TrainingId: 12,
+			Position:   2,
+			ExerciseId: 3,
+			SetNumber:  1,
+			Rest:       60,
+		},
+	}
+	return planned, nil
+}
+
+// FindPlannedById - planned sets of FindPlanned by id
+func (esss ExerciseSetStoreStub) FindPlannedById(userId int64, plannedId int64) (PlannedSet, error) {
+	planned, err := esss.FindPlanned(userId)
+	if err != nil {
+		return PlannedSet{}, err
+	}
+	for _, p := range planned {
+		if p.Id == plannedId {
+			return p, nil
+		}
+	}
+	return PlannedSet{}, sql.ErrNoRows
+}
+
+// ConfirmSet - planned set 1 is already confirmed
+func (esss ExerciseSetStoreStub) ConfirmSet(plannedId int64, set ExerciseSet) (ExerciseSet, error) {
+	if plannedId == 1 {
+		return set, NotUpdated
+	}
+	set.Id = 2
+	set.ExerciseId = 1
+	set.TrainingId = 12
+	return set, nil
+}
@@ -0,0 +1,34 @@
+package stores
+
+import (
+	"database/sql"
+)
+
+type ExerciseTypeStoreStub struct{}
+
+var stubTypes = []ExerciseType{{Id: 1, Name: Cardio}, {Id: 2, Name: Workout}, {Id: 3, Name: Gym}}
+
+func (etss ExerciseTypeStoreStub) FindAll() ([]ExerciseType, error) {
+	return stubTypes, nil
+}
+
+// FindById - seeded types have ids 1-3
+func (etss ExerciseTypeStoreStub) FindById(id int64) (ExerciseType, error) {
+	if id < 1 || id > int64(len(stubTypes)) {
+		return ExerciseType{}, sql.ErrNoRows
+	}
+	return stubTypes[id-1], nil
+}
+
+// FindByExercise - exercise 1 is workout, 2 is gym, 3 is cardio, others don't exist
+func (etss ExerciseTypeStoreStub) FindByExercise(userId int64, exerciseId int64) (ExerciseType, error) {
+	switch exerciseId {
+	case 1:
+		return etss.FindById(2)
+	case 2:
+		return etss.FindById(3)
+	case 3:
+		return etss.FindById(1)
+	}
+	return ExerciseType{}, sql.ErrNoRows
+}
@@ -0,0 +1,269 @@
+package stores
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ExerciseSet struct that is entity for exercise_sets table, duration is stored in seconds,
+// rpe (rate of perceived exertion, 1-10) is optional. Cardio metrics are optional too: distance is stored in
+// kilometers, elevation in meters, heart rates in beats per minute. Pace (seconds per kilometer) and speed
+// (kilometers per hour) are derived from distance and duration and aren't stored
+type ExerciseSet struct {
+	Id           int64     `db:"id" json:"id"`
+	UserId       int64     `db:"user_id" json:"user_id"`
+	ExerciseId   int64     `db:"exercise_id" json:"exercise_id"`
+	TrainingId   int64     `db:"training_id" json:"training_id"`
+	Weight       float64   `db:"weight" json:"weight" unit:"weight"`
+	Reps         int64     `db:"reps" json:"reps"`
+	Duration     int64     `db:"duration" json:"duration"`
+	Rpe          float64   `db:"rpe" json:"rpe,omitempty"`
+	Distance     float64   `db:"distance" json:"distance,omitempty" unit:"distance"`
+	Elevation    float64   `db:"elevation" json:"elevation,omitempty"`
+	AvgHeartRate int64     `db:"avg_heart_rate" json:"avg_heart_rate,omitempty"`
+	MaxHeartRate int64     `db:"max_heart_rate" json:"max_heart_rate,omitempty"`
+	Calories     int64     `db:"calories" json:"calories,omitempty"`
+	Pace         float64   `db:"pace" json:"pace,omitempty" unit:"pace"`
+	Speed        float64   `db:"speed" json:"speed,omitempty" unit:"distance"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+}
+
+// PlannedSet struct that is entity for planned_sets table, set of template planned in training.
+// SetId is id of set, which confirmed planned one, it is zero until planned set is confirmed
+type PlannedSet struct {
+	Id         int64   `db:"id" json:"id"`
+	TrainingId int64   `db:"training_id" json:"training_id"`
+	Position   int64   `db:"position" json:"position"`
+	ExerciseId int64   `db:"exercise_id" json:"exercise_id"`
+	SetNumber  int64   `db:"set_number" json:"set_number"`
+	Reps       int64   `db:"reps" json:"reps"`
+	Weight     float64 `db:"weight" json:"weight" unit:"weight"`
+	Rest       int64   `db:"rest" json:"rest"`
+	SetId      int64   `db:"set_id" json:"set_id"`
+}
+
+// ExerciseSetStore - interface which contains all methods for working with exercise_sets table
+type ExerciseSetStore interface {
+	AddSet(ExerciseSet) (ExerciseSet, error)
+	UndoSet(userId int64) error
+	FindCurrent(userId int64) ([]ExerciseSet, error)
+	FindByTraining(userId int64, trainingId int64) ([]ExerciseSet, error)
+	FindLastSessions(userId int64, exerciseId int64, sessions int64) ([]ExerciseSet, error)
+	FindPlanned(userId int64) ([]PlannedSet, error)
+	FindPlannedById(userId int64, plannedId int64) (PlannedSet, error)
+	ConfirmSet(plannedId int64, set ExerciseSet) (ExerciseSet, error)
+}
+
+// exerciseSetColumns - columns of exercise_sets table, with empty metrics replaced by zero values and derived
+// pace and speed, which are zero unless set has both distance and duration
+const exerciseSetColumns = `s.id, s.user_id, s.exercise_id, COALESCE(s.training_id, 0) AS training_id,
+	COALESCE(s.weight, 0) AS weight, COALESCE(s.reps, 0) AS reps,
+	COALESCE(EXTRACT(EPOCH FROM s.duration)::bigint, 0) AS duration, COALESCE(s.rpe, 0) AS rpe,
+	COALESCE(s.distance, 0) AS distance, COALESCE(s.elevation, 0) AS elevation,
+	COALESCE(s.avg_heart_rate, 0) AS avg_heart_rate, COALESCE(s.max_heart_rate, 0) AS max_heart_rate,
+	COALESCE(s.calories, 0) AS calories,
+	COALESCE(EXTRACT(EPOCH FROM s.duration)::float8 / s.distance, 0) AS pace,
+	COALESCE(s.distance * 3600 / NULLIF(EXTRACT(EPOCH FROM s.duration)::float8, 0), 0) AS speed, s.created_at`
+
+// cardioValues - cardio metrics of inserted set from parameters $8-$12, zero metrics are stored as empty
+const cardioValues = `NULLIF($8::float8, 0), NULLIF($9::real, 0), NULLIF($10::integer, 0), NULLIF($11::integer, 0),
+	NULLIF($12::integer, 0)`
+
+// openTrainingQuery - subquery selecting the training, that FinishTraining would close
+const openTrainingQuery = `SELECT t.id FROM trainings t
+	WHERE t.user_id=$1 AND t.` + inProgress + ` ORDER BY t.begins DESC LIMIT 1`
+
+// ESS - standard realization of ExerciseSetStore
+type ESS struct {
+	conn *sqlx.DB
+}
+
+// NewESS - function that creates realization for ExerciseSetStore interface
+func NewESS(conn *sqlx.DB) *ESS {
+	return &ESS{
+		conn: conn,
+	}
+}
+
+// AddSet - attaches set to the currently open training of user, returns AllTrainingsFinished if there is no such
+func (ess ESS) AddSet(set ExerciseSet) (ExerciseSet, error) {
+	set.CreatedAt = time.Now()
+	q := `INSERT INTO exercise_sets(user_id, exercise_id, training_id, weight, reps, duration, rpe, created_at,
+			distance, elevation, avg_heart_rate, max_heart_rate, calories)
+		SELECT $1, $2, (` + openTrainingQuery + `), NULLIF($3::real, 0), NULLIF($4::integer, 0),
+			NULLIF(make_interval(secs => $5), interval '0'), NULLIF($7::real, 0), $6,
+			` + cardioValues + `
+		WHERE EXISTS (` + openTrainingQuery + `)
+		RETURNING id, training_id`
+	err := ess.conn.QueryRowx(q, set.UserId, set.ExerciseId, set.Weight, set.Reps, set.Duration, set.CreatedAt, set.Rpe,
+		set.Distance, set.Elevation, set.AvgHeartRate, set.MaxHeartRate, set.Calories).
+		Scan(&set.Id, &set.TrainingId)
+	if err == sql.ErrNoRows {
+		return set, AllTrainingsFinished
+	}
+	return set, err
+}
+
+// UndoSet - soft-deletes the last set of the currently open training of user
+func (ess ESS) UndoSet(userId int64) error {
+	tx, err := ess.conn.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	exerciseIds, err := deleteSets(tx, `s.id=(
+		SELECT id FROM exercise_sets WHERE training_id=(`+openTrainingQuery+`) AND deleted_at IS NULL
+		ORDER BY id DESC LIMIT 1)`, userId)
+	if err != nil {
+		return err
+	}
+	if len(exerciseIds) == 0 {
+		return NotDeleted
+	}
+	return tx.Commit()
+}
+
+// deleteSets - soft-deletes sets s matching condition at the time of transaction. Like hard delete did, it drops
+// rest timers, planned confirmations and records of the sets, records of their exercises are rebuilt from
+// remaining sets. Returns ids of exercises of deleted sets
+func deleteSets(tx *sqlx.Tx, condition string, args ...any) ([]int64, error) {
+	q := `WITH deleted AS (
+			UPDATE exercise_sets s SET deleted_at=now() WHERE s.deleted_at IS NULL AND ` + condition + `
+			RETURNING s.id, s.exercise_id
+		), records AS (
+			DELETE FROM personal_records WHERE set_id IN (SELECT id FROM deleted)
+		), timers AS (
+			DELETE FROM rest_timers WHERE set_id IN (SELECT id FROM deleted)
+		), planned AS (
+			UPDATE planned_sets SET set_id=NULL WHERE set_id IN (SELECT id FROM deleted)
+		)
+		SELECT DISTINCT exercise_id FROM deleted`
+	var exerciseIds []int64
+	err := tx.Select(&exerciseIds, q, args...)
+	if err != nil || len(exerciseIds) == 0 {
+		return exerciseIds, err
+	}
+	return exerciseIds, rebuildRecords(tx, exerciseIds)
+}
+
+// restoreSets - restores deleted sets s matching condition, records of their exercises are recomputed, because
+// restored sets can beat current ones
+func restoreSets(tx *sqlx.Tx, condition string, args ...any) error {
+	q := `UPDATE exercise_sets s SET deleted_at=NULL WHERE s.deleted_at IS NOT NULL AND ` + condition + `
+		RETURNING s.exercise_id`
+	var exerciseIds []int64
+	err := tx.Select(&exerciseIds, q, args...)
+	if err != nil || len(exerciseIds) == 0 {
+		return err
+	}
+	_, err = tx.Exec(`DELETE FROM personal_records WHERE exercise_id=ANY($1)`, pq.Array(exerciseIds))
+	if err != nil {
+		return err
+	}
+	return rebuildRecords(tx, exerciseIds)
+}
+
+func (ess ESS) FindCurrent(userId int64) ([]ExerciseSet, error) {
+	var sets []ExerciseSet
+	q := `SELECT ` + exerciseSetColumns + ` FROM exercise_sets s
+		WHERE s.training_id=(` + openTrainingQuery + `) AND s.deleted_at IS NULL ORDER BY s.id`
+	err := ess.conn.Select(&sets, q, userId)
+	return sets, err
+}
+
+func (ess ESS) FindByTraining(userId int64, trainingId int64) ([]ExerciseSet, error) {
+	var sets []ExerciseSet
+	q := `SELECT ` + exerciseSetColumns + ` FROM exercise_sets s
+		WHERE s.user_id=$1 AND s.training_id=$2 AND s.deleted_at IS NULL ORDER BY s.id`
+	err := ess.conn.Select(&sets, q, userId, trainingId)
+	return sets, err
+}
+
+// FindLastSessions - sets of exercise from the last trainings, which have it, ordered by training begins
+func (ess ESS) FindLastSessions(userId int64, exerciseId int64, sessions int64) ([]ExerciseSet, error) {
+	var sets []ExerciseSet
+	q := `SELECT ` + exerciseSetColumns + ` FROM exercise_sets s
+		JOIN trainings t ON t.id=s.training_id
+		WHERE s.user_id=$1 AND s.exercise_id=$2 AND s.deleted_at IS NULL AND s.training_id IN (
+			SELECT t.id FROM trainings t WHERE t.user_id=$1 AND t.deleted_at IS NULL
+				AND EXISTS (SELECT 1 FROM exercise_sets x
+					WHERE x.training_id=t.id AND x.exercise_id=$2 AND x.deleted_at IS NULL)
+			ORDER BY t.begins DESC LIMIT $3)
+		ORDER BY t.begins, s.id`
+	err := ess.conn.Select(&sets, q, userId, exerciseId, sessions)
+	return sets, err
+}
+
+// plannedSetColumns - columns of planned_sets table, with rest in seconds and zero set_id of unconfirmed sets
+const plannedSetColumns = `p.id, p.training_id, p.position, p.exercise_id, p.set_number, p.reps, p.weight,
+	EXTRACT(EPOCH FROM p.rest)::bigint AS rest, COALESCE(p.set_id, 0) AS set_id`
+
+// FindPlanned - planned sets of the currently open training of user
+func (ess ESS) FindPlanned(userId int64) ([]PlannedSet, error) {
+	var planned []PlannedSet
+	q := `SELECT ` + plannedSetColumns + `
+		FROM planned_sets p WHERE p.training_id=(` + openTrainingQuery + `) ORDER BY p.position, p.set_number`
+	err := ess.conn.Select(&planned, q, userId)
+	return planned, err
+}
+
+// FindPlannedById - planned set of the currently open training of user, confirmed or not
+func (ess ESS) FindPlannedById(userId int64, plannedId int64) (PlannedSet, error) {
+	var planned PlannedSet
+	q := `SELECT ` + plannedSetColumns + `
+		FROM planned_sets p WHERE p.id=$2 AND p.training_id=(` + openTrainingQuery + `)`
+	err := ess.conn.Get(&planned, q, userId, plannedId)
+	return planned, err
+}
+
+// ConfirmSet - adds set planned in the currently open training of user, non-zero metrics of set replace planned ones.
+// Planned set is locked until it is confirmed, so concurrent confirmations add only one set.
+// Returns NotUpdated if there is no such unconfirmed planned set
+func (ess ESS) ConfirmSet(plannedId int64, set ExerciseSet) (ExerciseSet, error) {
+	set.CreatedAt = time.Now()
+	tx, err := ess.conn.Beginx()
+	if err != nil {
+		return set, err
+	}
+	defer tx.Rollback()
+	//locked row is checked again after concurrent confirmation commits, so it isn't selected then
+	var planned PlannedSet
+	q := `SELECT p.id, p.training_id, p.exercise_id, p.reps, p.weight FROM planned_sets p
+		WHERE p.id=$2 AND p.set_id IS NULL AND p.training_id=(` + openTrainingQuery + `)
+		FOR UPDATE OF p`
+	err = tx.Get(&planned, q, set.UserId, plannedId)
+	if err == sql.ErrNoRows {
+		return set, NotUpdated
+	}
+	if err != nil {
+		return set, err
+	}
+	set.ExerciseId = planned.ExerciseId
+	set.TrainingId = planned.TrainingId
+	if set.Weight == 0 {
+		set.Weight = planned.Weight
+	}
+	if set.Reps == 0 {
+		set.Reps = planned.Reps
+	}
+	q = `INSERT INTO exercise_sets(user_id, exercise_id, training_id, weight, reps, duration, rpe, created_at,
+			distance, elevation, avg_heart_rate, max_heart_rate, calories)
+		VALUES ($1, $2, $13, NULLIF($3::real, 0), NULLIF($4::integer, 0),
+			NULLIF(make_interval(secs => $5), interval '0'), NULLIF($7::real, 0), $6,
+			` + cardioValues + `)
+		RETURNING id`
+	err = tx.QueryRowx(q, set.UserId, set.ExerciseId, set.Weight, set.Reps, set.Duration, set.CreatedAt, set.Rpe,
+		set.Distance, set.Elevation, set.AvgHeartRate, set.MaxHeartRate, set.Calories, set.TrainingId).
+		Scan(&set.Id)
+	if err != nil {
+		return set, err
+	}
+	_, err = tx.Exec(`UPDATE planned_sets SET set_id=$1 WHERE id=$2`, set.Id, plannedId)
+	if err != nil {
+		return set, err
+	}
+	return set, tx.Commit()
+}
@@ -0,0 +1,145 @@
+package stores
+
+import (
+	"testing"
+)
+
+func TestESSAddSet(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	set := ExerciseSet{
+		UserId:     exercise.UserId,
+		ExerciseId: exercise.Id,
+		Weight:     60,
+		Reps:       10,
+	}
+	//negative case
+	_, err = ess.AddSet(set)
+	if err != AllTrainingsFinished {
+		t.Errorf("error adding set without open training: %v", err)
+	}
+	//positive case
+	trainingId, _ := ts.StartTraining(exercise.UserId)
+	added, err := ess.AddSet(set)
+	if err != nil {
+		t.Fatalf("error adding set: %v", err)
+	}
+	if added.Id == 0 || added.TrainingId != trainingId {
+		t.Errorf("set attached to wrong training: %#v", added)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestESSCardioMetrics(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	ts.StartTraining(exercise.UserId)
+	set := ExerciseSet{
+		UserId:       exercise.UserId,
+		ExerciseId:   exercise.Id,
+		Duration:     1500,
+		Distance:     5,
+		Elevation:    40,
+		AvgHeartRate: 150,
+		MaxHeartRate: 172,
+		Calories:     400,
+	}
+	added, err := ess.AddSet(set)
+	if err != nil {
+		t.Fatalf("error adding set: %v", err)
+	}
+	current, err := ess.FindCurrent(exercise.UserId)
+	if err != nil || len(current) != 1 {
+		t.Fatalf("error finding sets: %#v, %v", current, err)
+	}
+	found := current[0]
+	if found.Id != added.Id || found.Distance != 5 || found.Elevation != 40 || found.AvgHeartRate != 150 ||
+		found.MaxHeartRate != 172 || found.Calories != 400 {
+		t.Errorf("got wrong cardio metrics: %#v", found)
+	}
+	//pace and speed are derived from distance and duration
+	if found.Pace != 300 || found.Speed != 12 {
+		t.Errorf("got wrong pace and speed: %#v", found)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestESSUndoSet(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	ts.StartTraining(exercise.UserId)
+	//negative case
+	err = ess.UndoSet(exercise.UserId)
+	if err != NotDeleted {
+		t.Errorf("error undoing set of empty training: %v", err)
+	}
+	//positive case
+	first, _ := ess.AddSet(ExerciseSet{UserId: exercise.UserId, ExerciseId: exercise.Id, Reps: 10})
+	ess.AddSet(ExerciseSet{UserId: exercise.UserId, ExerciseId: exercise.Id, Reps: 8})
+	err = ess.UndoSet(exercise.UserId)
+	if err != nil {
+		t.Fatalf("error undoing set: %v", err)
+	}
+	sets, err := ess.FindCurrent(exercise.UserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sets) != 1 || sets[0].Id != first.Id {
+		t.Errorf("undo removed wrong set: %#v", sets)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestESSFindByTraining(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	trainingId, _ := ts.StartTraining(exercise.UserId)
+	for i := 0; i < 3; i++ {
+		ess.AddSet(ExerciseSet{UserId: exercise.UserId, ExerciseId: exercise.Id, Duration: 60})
+	}
+	ts.FinishTraining(exercise.UserId)
+	current, err := ess.FindCurrent(exercise.UserId)
+	if err != nil || len(current) != 0 {
+		t.Errorf("found sets of finished training: %v", err)
+	}
+	sets, err := ess.FindByTraining(exercise.UserId, trainingId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sets) != 3 || sets[0].Duration != 60 {
+		t.Errorf("found wrong sets: %#v", sets)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestESSFindLastSessions(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	for i := 1; i <= 3; i++ {
+		ts.StartTraining(exercise.UserId)
+		ess.AddSet(ExerciseSet{UserId: exercise.UserId, ExerciseId: exercise.Id, Weight: float64(50 + i*5), Reps: 10, Rpe: 8})
+		ess.AddSet(ExerciseSet{UserId: exercise.UserId, ExerciseId: exercise.Id, Weight: float64(50 + i*5), Reps: 9})
+		ts.FinishTraining(exercise.UserId)
+	}
+	//training without exercise isn't a session
+	ts.StartTraining(exercise.UserId)
+	ts.FinishTraining(exercise.UserId)
+	sets, err := ess.FindLastSessions(exercise.UserId, exercise.Id, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sets) != 4 || sets[0].Weight != 60 || sets[0].Rpe != 8 || sets[1].Rpe != 0 || sets[3].Weight != 65 {
+		t.Errorf("found wrong sessions: %#v", sets)
+	}
+	t.Cleanup(clearTables)
+}
@@ -0,0 +1,237 @@
+package stores
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/fridrock/trainingservice/errkind"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var (
+	ExerciseNameTaken = errkind.New(errkind.Conflict, "exercise with this name already exists in the group")
+	AmbiguousName     = errkind.New(errkind.Conflict, "user has exercises with this name in several groups, group is required")
+)
+
+// Exercise struct that is entity for exercises table, rest is stored in seconds. CatalogueId is id of catalogue
+// exercise, which exercise is personal copy of, it is zero for exercises created by user. Muscles are optional
+// mapping of exercise to muscle taxonomy, which overrides muscles of its group and catalogue exercise
+type Exercise struct {
+	Id              int64          `db:"id" json:"id"`
+	Name            string         `db:"name" json:"name"`
+	Description     string         `db:"description" json:"description"`
+	Rest            int64          `db:"rest" json:"rest"`
+	ExerciseTypeId  int64          `db:"exercise_type_id" json:"exercise_type_id"`
+	UserId          int64          `db:"user_id" json:"user_id"`
+	ExerciseGroupId int64          `db:"exercise_group_id" json:"exercise_group_id"`
+	CatalogueId     int64          `db:"catalogue_id" json:"catalogue_id,omitempty"`
+	Muscles         pq.StringArray `db:"muscles" json:"muscles,omitempty"`
+}
+
+// ExerciseStore - interface which contains all methods for working with exercises table
+type ExerciseStore interface {
+	Save(Exercise) (int64, error)
+	FindById(int64) (Exercise, error)
+	FindByName(userId int64, group string, name string) (Exercise, error)
+	DeleteById(int64) error
+	DeleteByName(userId int64, group string, name string) error
+	Update(Exercise) error
+	FindByGroup(userId int64, groupName string) ([]Exercise, error)
+	SetMuscles(userId int64, group string, name string, muscles []string) error
+}
+
+// exerciseColumns - columns of exercises table, with rest converted to seconds
+const exerciseColumns = `e.id, e.name, COALESCE(e.description, '') AS description,
+	EXTRACT(EPOCH FROM e.rest)::bigint AS rest, e.exercise_type_id, e.user_id, e.exercise_group_id,
+	COALESCE(e.catalogue_id, 0) AS catalogue_id, e.muscles`
+
+// exerciseNameIndex - unique index on case-insensitive names of not deleted exercises of group
+const exerciseNameIndex = "exercises_one_name_per_group_idx"
+
+// checkExercise - converts violations of unique indexes on not deleted exercises of group to ExerciseNameTaken
+// or AlreadyLinked
+func checkExercise(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == exerciseNameIndex {
+		return ExerciseNameTaken
+	}
+	return checkCopy(err)
+}
+
+// EX - standard realization of ExerciseStore
+type EX struct {
+	conn *sqlx.DB
+}
+
+// NewEX - function that creates realization for ExerciseStore interface
+func NewEX(conn *sqlx.DB) *EX {
+	return &EX{
+		conn: conn,
+	}
+}
+
+// Save - saves exercise to group of the same user, returns sql.ErrNoRows if user has no such group and
+// ExerciseNameTaken if group has other exercise with the same name in any case
+func (ex EX) Save(exercise Exercise) (int64, error) {
+	var exerciseId int64
+	q := `INSERT INTO exercises(name, description, rest, exercise_type_id, user_id, exercise_group_id)
+		SELECT $1, $2, make_interval(secs => $3), $4, g.user_id, g.id FROM exercise_groups g
+		WHERE g.id=$6 AND g.user_id=$5 AND g.deleted_at IS NULL RETURNING id`
+	err := ex.conn.Get(&exerciseId, q,
+		exercise.Name,
+		exercise.Description,
+		exercise.Rest,
+		exercise.ExerciseTypeId,
+		exercise.UserId,
+		exercise.ExerciseGroupId)
+	return exerciseId, checkExercise(err)
+}
+
+func (ex EX) FindById(id int64) (Exercise, error) {
+	var exercise Exercise
+	q := `SELECT ` + exerciseColumns + ` FROM exercises e WHERE e.id=$1 AND e.deleted_at IS NULL`
+	err := ex.conn.Get(&exercise, q, id)
+	return exercise, err
+}
+
+// FindByName - finds exercise of user by name in any case, group is optional and narrows search to group with name
+// in any case. Returns AmbiguousName if group is empty and user has exercises with the name in several groups
+func (ex EX) FindByName(userId int64, group string, name string) (Exercise, error) {
+	return findExercise(ex.conn, userId, group, name, "")
+}
+
+// findExercise - finds the only not deleted exercise of user with name in not deleted group, lock is appended to
+// query to lock found exercise in transaction
+func findExercise(q sqlx.Queryer, userId int64, group string, name string, lock string) (Exercise, error) {
+	var exercises []Exercise
+	query := `SELECT ` + exerciseColumns + ` FROM exercises e
+		JOIN exercise_groups g ON g.id=e.exercise_group_id AND g.deleted_at IS NULL
+		WHERE e.user_id=$1 AND lower(e.name)=lower($2) AND ($3='' OR lower(g.name)=lower($3))
+		AND e.deleted_at IS NULL LIMIT 2` + lock
+	err := sqlx.Select(q, &exercises, query, userId, name, group)
+	if err != nil {
+		return Exercise{}, err
+	}
+	switch len(exercises) {
+	case 0:
+		return Exercise{}, sql.ErrNoRows
+	case 1:
+		return exercises[0], nil
+	default:
+		return Exercise{}, AmbiguousName
+	}
+}
+
+// DeleteById - soft-deletes exercise together with its sets
+func (ex EX) DeleteById(id int64) error {
+	tx, err := ex.conn.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	err = deleteExercise(tx, id)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteByName - soft-deletes exercise of user with name together with its sets, group is optional like in
+// FindByName
+func (ex EX) DeleteByName(userId int64, group string, name string) error {
+	tx, err := ex.conn.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	exercise, err := findExercise(tx, userId, group, name, " FOR UPDATE OF e")
+	if err == sql.ErrNoRows {
+		return NotDeleted
+	}
+	if err != nil {
+		return err
+	}
+	err = deleteExercise(tx, exercise.Id)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// deleteExercise - soft-deletes exercise and its sets at the same time, so they can be restored together. Returns
+// NotDeleted if there is no such exercise
+func deleteExercise(tx *sqlx.Tx, id int64) error {
+	q := `UPDATE exercises SET deleted_at=now() WHERE id=$1 AND deleted_at IS NULL`
+	res, err := tx.Exec(q, id)
+	if err != nil {
+		return err
+	}
+	r, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if r == 0 {
+		return NotDeleted
+	}
+	_, err = deleteSets(tx, `s.exercise_id=$1`, id)
+	return err
+}
+
+// Update - updates exercise of user, group is changed only to group of the same user. Returns NotUpdated if user
+// has no such exercise or group and ExerciseNameTaken if group has other exercise with the same name in any case
+func (ex EX) Update(updated Exercise) error {
+	q := `UPDATE exercises e SET name=$1, description=$2, rest=make_interval(secs => $3),
+		exercise_type_id=$4, exercise_group_id=g.id FROM exercise_groups g
+		WHERE e.id=$6 AND e.user_id=$7 AND e.deleted_at IS NULL
+		AND g.id=$5 AND g.user_id=e.user_id AND g.deleted_at IS NULL`
+	res, err := ex.conn.Exec(q,
+		updated.Name,
+		updated.Description,
+		updated.Rest,
+		updated.ExerciseTypeId,
+		updated.ExerciseGroupId,
+		updated.Id,
+		updated.UserId)
+	if err != nil {
+		return checkExercise(err)
+	}
+	r, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if r == 0 {
+		return NotUpdated
+	}
+	return nil
+}
+
+func (ex EX) FindByGroup(userId int64, groupName string) ([]Exercise, error) {
+	var exercises []Exercise
+	q := `SELECT ` + exerciseColumns + ` FROM exercises e
+		JOIN exercise_groups g ON g.id=e.exercise_group_id
+		WHERE e.user_id=$1 AND lower(g.name)=lower($2) AND e.deleted_at IS NULL AND g.deleted_at IS NULL`
+	err := ex.conn.Select(&exercises, q, userId, groupName)
+	return exercises, err
+}
+
+// SetMuscles - replaces muscles of exercise, empty muscles remove mapping. Group is optional like in FindByName
+func (ex EX) SetMuscles(userId int64, group string, name string, muscles []string) error {
+	tx, err := ex.conn.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	exercise, err := findExercise(tx, userId, group, name, " FOR UPDATE OF e")
+	if err == sql.ErrNoRows {
+		return NotUpdated
+	}
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`UPDATE exercises SET muscles=$1 WHERE id=$2`, pq.StringArray(muscles), exercise.Id)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,238 @@
+package stores
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func createDefaultExercise() (Exercise, error) {
+	groupId, err := createDefaultExGroup()
+	if err != nil {
+		return Exercise{}, err
+	}
+	exercise := Exercise{
+		Name:            "Pull up",
+		Description:     "wide grip",
+		Rest:            90,
+		ExerciseTypeId:  2,
+		UserId:          defaultExGroup.UserId,
+		ExerciseGroupId: groupId,
+	}
+	exercise.Id, err = ex.Save(exercise)
+	return exercise, err
+}
+
+func TestEXSaveFindById(t *testing.T) {
+	//negative case
+	_, err := ex.FindById(100)
+	if err != sql.ErrNoRows {
+		t.Errorf("error finding unexisting exercise: %v", err)
+	}
+	//positive case
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	found, err := ex.FindById(exercise.Id)
+	if err != nil {
+		t.Fatalf("error finding exercise: %v", err)
+	}
+	if diff := cmp.Diff(exercise, found); diff != "" {
+		t.Error(diff)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestEXSaveToGroupOfOtherUser(t *testing.T) {
+	groupId, err := createDefaultExGroup()
+	if err != nil {
+		t.Fatalf("error saving group: %v", err)
+	}
+	exercise := Exercise{Name: "Pull up", Rest: 90, ExerciseTypeId: 2, UserId: 100, ExerciseGroupId: groupId}
+	_, err = ex.Save(exercise)
+	if err != sql.ErrNoRows {
+		t.Errorf("saved exercise to group of other user: %v", err)
+	}
+	egs.SoftDeleteByName(defaultExGroup.UserId, defaultExGroup.Name)
+	exercise.UserId = defaultExGroup.UserId
+	_, err = ex.Save(exercise)
+	if err != sql.ErrNoRows {
+		t.Errorf("saved exercise to deleted group: %v", err)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestEXSaveNameTaken(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	exercise.Name = "PULL UP"
+	_, err = ex.Save(exercise)
+	if err != ExerciseNameTaken {
+		t.Errorf("saved exercise with taken name: %v", err)
+	}
+	//name is free after deletion
+	ex.DeleteById(exercise.Id)
+	_, err = ex.Save(exercise)
+	if err != nil {
+		t.Errorf("error saving exercise with name of deleted one: %v", err)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestEXFindByName(t *testing.T) {
+	//negative case
+	_, err := ex.FindByName(defaultExGroup.UserId, "", "Nosuchname")
+	if err != sql.ErrNoRows {
+		t.Errorf("error finding unexisting exercise: %v", err)
+	}
+	//positive case
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	found, err := ex.FindByName(exercise.UserId, "", exercise.Name)
+	if err != nil {
+		t.Fatalf("error finding exercise by name: %v", err)
+	}
+	if found.Id != exercise.Id {
+		t.Errorf("found wrong exercise: %#v", found)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestEXFindByNameInSeveralGroups(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	legs := ExGroup{UserId: exercise.UserId, Name: "Legs"}
+	other := exercise
+	other.ExerciseGroupId, _ = egs.Save(legs)
+	other.Id, err = ex.Save(other)
+	if err != nil {
+		t.Fatalf("error saving exercise to other group: %v", err)
+	}
+	_, err = ex.FindByName(exercise.UserId, "", exercise.Name)
+	if err != AmbiguousName {
+		t.Errorf("found exercise with name in several groups: %v", err)
+	}
+	found, err := ex.FindByName(exercise.UserId, "legs", "pull UP")
+	if err != nil || found.Id != other.Id {
+		t.Errorf("found wrong exercise in group: %#v, %v", found, err)
+	}
+	err = ex.DeleteByName(exercise.UserId, "", exercise.Name)
+	if err != AmbiguousName {
+		t.Errorf("deleted exercise with name in several groups: %v", err)
+	}
+	err = ex.DeleteByName(exercise.UserId, legs.Name, exercise.Name)
+	if err != nil {
+		t.Fatalf("error deleting exercise in group: %v", err)
+	}
+	_, err = ex.FindById(exercise.Id)
+	if err != nil {
+		t.Errorf("deleted exercise of other group: %v", err)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestEXDeleteByName(t *testing.T) {
+	//negative case
+	err := ex.DeleteByName(1, "", "NoSuchExercise")
+	if err != NotDeleted {
+		t.Errorf("error deleting unexisting exercise: %v", err)
+	}
+	//positive case
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	err = ex.DeleteByName(exercise.UserId, "", exercise.Name)
+	if err != nil {
+		t.Fatalf("error deleting exercise: %v", err)
+	}
+	_, err = ex.FindById(exercise.Id)
+	if err != sql.ErrNoRows {
+		t.Errorf("found exercise after deletion")
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestEXUpdate(t *testing.T) {
+	//negative case
+	err := ex.Update(Exercise{Id: 100, UserId: 1, Name: "Updated", ExerciseTypeId: 1, ExerciseGroupId: 1})
+	if err != NotUpdated {
+		t.Errorf("error updating unexisting exercise: %v", err)
+	}
+	//positive case
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	exercise.Name = "Chin up"
+	exercise.Rest = 120
+	exercise.ExerciseTypeId = 3
+	err = ex.Update(exercise)
+	if err != nil {
+		t.Fatalf("error updating exercise: %v", err)
+	}
+	found, err := ex.FindById(exercise.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(exercise, found); diff != "" {
+		t.Error(diff)
+	}
+	//group of other user
+	other, _ := egs.Save(ExGroup{UserId: 100, Name: "Legs"})
+	exercise.ExerciseGroupId = other
+	err = ex.Update(exercise)
+	if err != NotUpdated {
+		t.Errorf("moved exercise to group of other user: %v", err)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestEXFindByGroup(t *testing.T) {
+	//negative case
+	exercises, err := ex.FindByGroup(1, "NoSuchGroup")
+	if err != nil || len(exercises) != 0 {
+		t.Errorf("error finding exercises of unexisting group: %v", err)
+	}
+	//positive case
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	exercises, err = ex.FindByGroup(exercise.UserId, defaultExGroup.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exercises) != 1 || exercises[0].Id != exercise.Id {
+		t.Errorf("found wrong exercises: %#v", exercises)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestEXSetMuscles(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	err = ex.SetMuscles(exercise.UserId, "", "Nosuchname", []string{"lats"})
+	if err != NotUpdated {
+		t.Errorf("mapped unexisting exercise: %v", err)
+	}
+	err = ex.SetMuscles(exercise.UserId, "", exercise.Name, []string{"lats", "biceps"})
+	if err != nil {
+		t.Fatalf("error mapping exercise: %v", err)
+	}
+	found, _ := ex.FindById(exercise.Id)
+	if len(found.Muscles) != 2 || found.Muscles[1] != "biceps" {
+		t.Errorf("got wrong muscles: %#v", found)
+	}
+	t.Cleanup(clearTables)
+}
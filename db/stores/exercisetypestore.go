@@ -0,0 +1,59 @@
+package stores
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+// names of exercise types, which are seeded by migration
+const (
+	Cardio  = "CARDIO"
+	Workout = "WORKOUT"
+	Gym     = "GYM"
+)
+
+// ExerciseType struct that is entity for exercise_types table, types are global and read-only
+type ExerciseType struct {
+	Id   int64  `db:"id" json:"id"`
+	Name string `db:"name" json:"name"`
+}
+
+// ExerciseTypeStore - interface which contains all methods for working with exercise_types table
+type ExerciseTypeStore interface {
+	FindAll() ([]ExerciseType, error)
+	FindById(id int64) (ExerciseType, error)
+	FindByExercise(userId int64, exerciseId int64) (ExerciseType, error)
+}
+
+// ETS - standard realization of ExerciseTypeStore
+type ETS struct {
+	conn *sqlx.DB
+}
+
+// NewETS - function that creates realization for ExerciseTypeStore interface
+func NewETS(conn *sqlx.DB) *ETS {
+	return &ETS{
+		conn: conn,
+	}
+}
+
+func (ets ETS) FindAll() ([]ExerciseType, error) {
+	var types []ExerciseType
+	err := ets.conn.Select(&types, `SELECT id, name FROM exercise_types ORDER BY id`)
+	return types, err
+}
+
+func (ets ETS) FindById(id int64) (ExerciseType, error) {
+	var exerciseType ExerciseType
+	err := ets.conn.Get(&exerciseType, `SELECT id, name FROM exercise_types WHERE id=$1`, id)
+	return exerciseType, err
+}
+
+// FindByExercise - type of exercise of user, returns sql.ErrNoRows if user has no such exercise
+func (ets ETS) FindByExercise(userId int64, exerciseId int64) (ExerciseType, error) {
+	var exerciseType ExerciseType
+	q := `SELECT t.id, t.name FROM exercise_types t
+		JOIN exercises e ON e.exercise_type_id=t.id
+		WHERE e.id=$1 AND e.user_id=$2 AND e.deleted_at IS NULL`
+	err := ets.conn.Get(&exerciseType, q, exerciseId, userId)
+	return exerciseType, err
+}
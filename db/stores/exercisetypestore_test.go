@@ -0,0 +1,43 @@
+package stores
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestETSFindAll(t *testing.T) {
+	types, err := ets.FindAll()
+	if err != nil {
+		t.Fatalf("error finding exercise types: %v", err)
+	}
+	expected := []ExerciseType{{Id: 1, Name: Cardio}, {Id: 2, Name: Workout}, {Id: 3, Name: Gym}}
+	if diff := cmp.Diff(expected, types); diff != "" {
+		t.Error(diff)
+	}
+	_, err = ets.FindById(100)
+	if err != sql.ErrNoRows {
+		t.Errorf("error finding unexisting type: %v", err)
+	}
+}
+
+func TestETSFindByExercise(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	found, err := ets.FindByExercise(exercise.UserId, exercise.Id)
+	if err != nil {
+		t.Fatalf("error finding type of exercise: %v", err)
+	}
+	if found.Id != exercise.ExerciseTypeId || found.Name != Workout {
+		t.Errorf("found wrong type: %#v", found)
+	}
+	//exercise of other user
+	_, err = ets.FindByExercise(exercise.UserId+1, exercise.Id)
+	if err != sql.ErrNoRows {
+		t.Errorf("error finding type of exercise of other user: %v", err)
+	}
+	t.Cleanup(clearTables)
+}
@@ -1,21 +1,42 @@
 package stores
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/fridrock/trainingservice/errkind"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 var (
-	NotDeleted = errors.New("no rows deleted")
-	NotUpdated = errors.New("no rows updated")
+	NotDeleted   = errkind.New(errkind.NotFound, "no rows deleted")
+	NotUpdated   = errkind.New(errkind.NotFound, "no rows updated")
+	HasExercises = errkind.New(errkind.Conflict, "exercise group has exercises")
+	NameTaken    = errkind.New(errkind.Conflict, "exercise group with this name already exists")
 )
 
-// ExGroup struct that is entity for exercise_groups table
+// DependentExercises - error of deleting exercise group, which still has exercises, contains names of exercises
+type DependentExercises []string
+
+func (de DependentExercises) Error() string {
+	return fmt.Sprintf("%v: %s", HasExercises, strings.Join(de, ", "))
+}
+
+// Unwrap - makes DependentExercises HasExercises for errors.Is and errors.As
+func (de DependentExercises) Unwrap() error {
+	return HasExercises
+}
+
+// ExGroup struct that is entity for exercise_groups table, muscles are optional mapping of group to muscle taxonomy
 type ExGroup struct {
-	Id     int64  `db:"id" json:"id"`
-	UserId int64  `db:"user_id" json:"user_id"`
-	Name   string `db:"name" json:"name"`
+	Id      int64          `db:"id" json:"id"`
+	UserId  int64          `db:"user_id" json:"user_id"`
+	Name    string         `db:"name" json:"name"`
+	Muscles pq.StringArray `db:"muscles" json:"muscles,omitempty"`
 }
 
 // ExGroupStore - interface which contains all methods for working with exercise_groups table
@@ -25,9 +46,28 @@ type ExGroupStore interface {
 	FindByName(userId int64, groupName string) (ExGroup, error)
 	DeleteById(int64) error
 	DeleteByName(userId int64, groupName string) error
+	MoveByName(userId int64, groupName string, targetName string) error
+	SoftDeleteByName(userId int64, groupName string) error
+	RestoreByName(userId int64, groupName string) error
 	Update(ExGroup) error
 	UpdateByName(userId int64, name string, newName string) error
 	FindByUserId(int64) ([]ExGroup, error)
+	SetMuscles(userId int64, name string, muscles []string) error
+}
+
+// exGroupColumns - columns of exercise_groups table, soft-deleted groups are filtered by deleted_at
+const exGroupColumns = `id, user_id, name, muscles`
+
+// groupNameIndex - unique index on case-insensitive names of not deleted groups of user
+const groupNameIndex = "exercise_groups_one_name_per_user_idx"
+
+// checkName - converts violation of unique name of group to NameTaken
+func checkName(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == groupNameIndex {
+		return NameTaken
+	}
+	return err
 }
 
 // EGS - standard realization of ExGroupInterface
@@ -42,29 +82,34 @@ func NewEGS(conn *sqlx.DB) *EGS {
 	}
 }
 
+// Save - saves group, returns NameTaken if user has other group with the same name in any case
 func (egs EGS) Save(exGroup ExGroup) (int64, error) {
 	var exGroupId int64
 	q := `INSERT INTO exercise_groups(user_id, name) VALUES($1, $2) RETURNING id`
 	err := egs.conn.Get(&exGroupId, q, exGroup.UserId, exGroup.Name)
-	return exGroupId, err
+	return exGroupId, checkName(err)
 }
 
 func (egs EGS) FindById(id int64) (ExGroup, error) {
 	var exGroup ExGroup
-	q := `SELECT * FROM exercise_groups WHERE id=$1`
+	q := `SELECT ` + exGroupColumns + ` FROM exercise_groups WHERE id=$1 AND deleted_at IS NULL`
 	err := egs.conn.Get(&exGroup, q, id)
 	return exGroup, err
 }
 
+// FindByName - finds group by name in any case
 func (egs EGS) FindByName(userId int64, name string) (ExGroup, error) {
 	var exGroup ExGroup
-	q := `SELECT * FROM exercise_groups WHERE name=$1 and user_id=$2`
+	q := `SELECT ` + exGroupColumns + ` FROM exercise_groups
+		WHERE lower(name)=lower($1) and user_id=$2 AND deleted_at IS NULL`
 	err := egs.conn.Get(&exGroup, q, name, userId)
 	return exGroup, err
 }
 
+// DeleteById - soft-deletes group without exercises, returns NotDeleted if there is no such
 func (egs EGS) DeleteById(id int64) error {
-	q := `DELETE FROM exercise_groups WHERE id=$1`
+	q := `UPDATE exercise_groups g SET deleted_at=now() WHERE g.id=$1 AND g.deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM exercises e WHERE e.exercise_group_id=g.id AND e.deleted_at IS NULL)`
 	res, err := egs.conn.Exec(q, id)
 	if err != nil {
 		return err
@@ -79,27 +124,169 @@ func (egs EGS) DeleteById(id int64) error {
 	return nil
 }
 
+// DeleteByName - soft-deletes group without exercises, returns DependentExercises if group has exercises
 func (egs EGS) DeleteByName(userId int64, name string) error {
-	q := `DELETE FROM exercise_groups WHERE user_id=$1 AND name=$2`
-	res, err := egs.conn.Exec(q, userId, name)
+	tx, err := egs.conn.Beginx()
 	if err != nil {
 		return err
 	}
-	r, err := res.RowsAffected()
+	defer tx.Rollback()
+	groupId, err := lockGroup(tx, userId, name)
 	if err != nil {
 		return err
 	}
-	if r == 0 {
-		return NotDeleted
+	var exercises []string
+	q := `SELECT name FROM exercises WHERE exercise_group_id=$1 AND deleted_at IS NULL ORDER BY name`
+	err = tx.Select(&exercises, q, groupId)
+	if err != nil {
+		return err
 	}
-	return nil
+	if len(exercises) > 0 {
+		return DependentExercises(exercises)
+	}
+	_, err = tx.Exec(`UPDATE exercise_groups SET deleted_at=now() WHERE id=$1`, groupId)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// MoveByName - moves exercises of group to target group of the same user and soft-deletes group. Returns sql.ErrNoRows
+// if there is no target group, AlreadyLinked if target group has copy of the same catalogue exercise and
+// ExerciseNameTaken if target group has exercise with the same name
+func (egs EGS) MoveByName(userId int64, name string, targetName string) error {
+	tx, err := egs.conn.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	groupId, err := lockGroup(tx, userId, name)
+	if err != nil {
+		return err
+	}
+	var targetId int64
+	q := `SELECT id FROM exercise_groups
+		WHERE user_id=$1 AND lower(name)=lower($2) AND deleted_at IS NULL AND id<>$3 FOR UPDATE`
+	err = tx.Get(&targetId, q, userId, targetName, groupId)
+	if err != nil {
+		return err
+	}
+	q = `UPDATE exercises SET exercise_group_id=$1 WHERE exercise_group_id=$2 AND deleted_at IS NULL`
+	_, err = tx.Exec(q, targetId, groupId)
+	if err != nil {
+		return checkExercise(err)
+	}
+	_, err = tx.Exec(`UPDATE exercise_groups SET deleted_at=now() WHERE id=$1`, groupId)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SoftDeleteByName - marks group, its exercises and their sets deleted at the same time, so they can be restored
+// together
+func (egs EGS) SoftDeleteByName(userId int64, name string) error {
+	tx, err := egs.conn.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	groupId, err := lockGroup(tx, userId, name)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`UPDATE exercise_groups SET deleted_at=now() WHERE id=$1`, groupId)
+	if err != nil {
+		return err
+	}
+	_, err = deleteSets(tx, `s.exercise_id IN (
+		SELECT id FROM exercises WHERE exercise_group_id=$1 AND deleted_at IS NULL)`, groupId)
+	if err != nil {
+		return err
+	}
+	q := `UPDATE exercises SET deleted_at=now() WHERE exercise_group_id=$1 AND deleted_at IS NULL`
+	_, err = tx.Exec(q, groupId)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// deletedGroup - soft-deleted group with time of deletion
+type deletedGroup struct {
+	Id        int64     `db:"id"`
+	UserId    int64     `db:"user_id"`
+	Name      string    `db:"name"`
+	DeletedAt time.Time `db:"deleted_at"`
+}
+
+// RestoreByName - restores the last soft-deleted group with name together with exercises deleted with it. Returns
+// NotUpdated if there is no such deleted group and NameTaken if user has other group with the same name
+func (egs EGS) RestoreByName(userId int64, name string) error {
+	tx, err := egs.conn.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var deleted deletedGroup
+	q := `SELECT id, user_id, name, deleted_at FROM exercise_groups
+		WHERE user_id=$1 AND lower(name)=lower($2) AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC LIMIT 1 FOR UPDATE`
+	err = tx.Get(&deleted, q, userId, name)
+	if err == sql.ErrNoRows {
+		return NotUpdated
+	}
+	if err != nil {
+		return err
+	}
+	err = restoreGroup(tx, deleted)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// restoreGroup - restores group together with exercises and sets deleted with it, returns NameTaken if user has
+// other group with the same name
+func restoreGroup(tx *sqlx.Tx, deleted deletedGroup) error {
+	_, err := lockGroup(tx, deleted.UserId, deleted.Name)
+	if err == nil {
+		return NameTaken
+	}
+	if err != NotDeleted {
+		return err
+	}
+	err = restoreSets(tx, `s.deleted_at=$2 AND s.exercise_id IN (
+		SELECT id FROM exercises WHERE exercise_group_id=$1 AND deleted_at=$2)`, deleted.Id, deleted.DeletedAt)
+	if err != nil {
+		return err
+	}
+	q := `UPDATE exercises SET deleted_at=NULL WHERE exercise_group_id=$1 AND deleted_at=$2`
+	_, err = tx.Exec(q, deleted.Id, deleted.DeletedAt)
+	if err != nil {
+		return checkExercise(err)
+	}
+	_, err = tx.Exec(`UPDATE exercise_groups SET deleted_at=NULL WHERE id=$1`, deleted.Id)
+	return err
 }
 
+// lockGroup - locks group of user with name in any case in transaction, returns NotDeleted if there is no such group
+func lockGroup(tx *sqlx.Tx, userId int64, name string) (int64, error) {
+	var groupId int64
+	q := `SELECT id FROM exercise_groups WHERE user_id=$1 AND lower(name)=lower($2) AND deleted_at IS NULL FOR UPDATE`
+	err := tx.Get(&groupId, q, userId, name)
+	if err == sql.ErrNoRows {
+		return 0, NotDeleted
+	}
+	return groupId, err
+}
+
+// Update - updates group, returns NameTaken if user has other group with the new name in any case
 func (egs EGS) Update(updated ExGroup) error {
-	q := `UPDATE exercise_groups SET name=$1, user_id=$2 WHERE id=$3`
+	q := `UPDATE exercise_groups SET name=$1, user_id=$2 WHERE id=$3 AND deleted_at IS NULL`
 	res, err := egs.conn.Exec(q, updated.Name, updated.UserId, updated.Id)
 	if err != nil {
-		return err
+		return checkName(err)
 	}
 	r, err := res.RowsAffected()
 	if err != nil {
@@ -112,11 +299,12 @@ func (egs EGS) Update(updated ExGroup) error {
 
 }
 
+// UpdateByName - renames group, returns NameTaken if user has other group with the new name in any case
 func (egs EGS) UpdateByName(userId int64, name string, newName string) error {
-	q := `UPDATE exercise_groups SET name=$1 WHERE user_id=$2 AND name=$3`
+	q := `UPDATE exercise_groups SET name=$1 WHERE user_id=$2 AND lower(name)=lower($3) AND deleted_at IS NULL`
 	res, err := egs.conn.Exec(q, newName, userId, name)
 	if err != nil {
-		return err
+		return checkName(err)
 	}
 	r, err := res.RowsAffected()
 	if err != nil {
@@ -130,7 +318,24 @@ func (egs EGS) UpdateByName(userId int64, name string, newName string) error {
 
 func (egs EGS) FindByUserId(userId int64) ([]ExGroup, error) {
 	var exGroups []ExGroup
-	q := `SELECT * FROM exercise_groups WHERE user_id=$1`
+	q := `SELECT ` + exGroupColumns + ` FROM exercise_groups WHERE user_id=$1 AND deleted_at IS NULL`
 	err := egs.conn.Select(&exGroups, q, userId)
 	return exGroups, err
 }
+
+// SetMuscles - replaces muscles of group, empty muscles remove mapping
+func (egs EGS) SetMuscles(userId int64, name string, muscles []string) error {
+	q := `UPDATE exercise_groups SET muscles=$1 WHERE user_id=$2 AND lower(name)=lower($3) AND deleted_at IS NULL`
+	res, err := egs.conn.Exec(q, pq.StringArray(muscles), userId, name)
+	if err != nil {
+		return err
+	}
+	r, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if r == 0 {
+		return NotUpdated
+	}
+	return nil
+}
@@ -3,9 +3,11 @@ package stores
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
+	"strings"
 	"testing"
 
 	"github.com/fridrock/trainingservice/test"
@@ -17,6 +19,17 @@ import (
 var (
 	ts             *TS
 	egs            *EGS
+	ex             *EX
+	ess            *ESS
+	pms            *PMS
+	prs            *PRS
+	tps            *TPS
+	pgs            *PGS
+	rts            *RTS
+	scs            *SCS
+	cts            *CTS
+	ets            *ETS
+	trs            *TRS
 	conn           *sqlx.DB
 	defaultExGroup = ExGroup{
 		Name:   "BodyBack",
@@ -29,6 +42,17 @@ func TestMain(m *testing.M) {
 	createConnection()
 	ts = NewTs(conn)
 	egs = NewEGS(conn)
+	ex = NewEX(conn)
+	ess = NewESS(conn)
+	pms = NewPMS(conn)
+	prs = NewPRS(conn)
+	tps = NewTPS(conn)
+	pgs = NewPGS(conn)
+	rts = NewRTS(conn)
+	scs = NewSCS(conn)
+	cts = NewCTS(conn)
+	ets = NewETS(conn)
+	trs = NewTRS(conn)
 	m.Run()
 	//tearing down
 	defer conn.Close()
@@ -52,8 +76,24 @@ func createDefaultExGroup() (int64, error) {
 }
 
 func clearTables() {
+	conn.Exec("DELETE FROM user_settings")
+	conn.Exec("DELETE FROM training_schedules")
+	conn.Exec("DELETE FROM rest_timers")
+	conn.Exec("DELETE FROM program_enrollments")
+	conn.Exec("DELETE FROM program_progressions")
+	conn.Exec("DELETE FROM program_days")
+	conn.Exec("DELETE FROM programs")
+	conn.Exec("DELETE FROM planned_sets")
+	conn.Exec("DELETE FROM template_exercises")
+	conn.Exec("DELETE FROM workout_templates")
+	conn.Exec("DELETE FROM personal_records")
+	conn.Exec("DELETE FROM exercise_sets")
+	conn.Exec("DELETE FROM exercises")
+	conn.Exec("DELETE FROM catalogue_exercises")
 	conn.Exec("DELETE FROM exercise_groups")
+	conn.Exec("DELETE FROM training_pauses")
 	conn.Exec("DELETE FROM trainings")
+	conn.Exec("DELETE FROM processed_messages")
 }
 func TestEGSSaveMethod(t *testing.T) {
 	result, err := createDefaultExGroup()
@@ -222,7 +262,7 @@ func TestFindByUserId(t *testing.T) {
 	}
 	//positive case
 	for i := 0; i < 3; i++ {
-		createDefaultExGroup()
+		egs.Save(ExGroup{UserId: defaultExGroup.UserId, Name: fmt.Sprintf("%s%d", defaultExGroup.Name, i)})
 	}
 	res, err := egs.FindByUserId(defaultExGroup.UserId)
 	if err != nil {
@@ -231,4 +271,139 @@ func TestFindByUserId(t *testing.T) {
 	if len(res) != 3 {
 		t.Errorf("error finding exgroups of user")
 	}
+	t.Cleanup(clearTables)
+}
+
+func TestEGSUniqueName(t *testing.T) {
+	id, err := createDefaultExGroup()
+	if err != nil {
+		t.Fatalf("error saving exgroup: %v", err)
+	}
+	//negative cases
+	_, err = egs.Save(ExGroup{UserId: defaultExGroup.UserId, Name: strings.ToLower(defaultExGroup.Name)})
+	if err != NameTaken {
+		t.Errorf("saved exgroup with taken name: %v", err)
+	}
+	egs.Save(ExGroup{UserId: defaultExGroup.UserId, Name: "Pull"})
+	err = egs.UpdateByName(defaultExGroup.UserId, "Pull", strings.ToUpper(defaultExGroup.Name))
+	if err != NameTaken {
+		t.Errorf("renamed exgroup to taken name: %v", err)
+	}
+	//positive cases
+	found, err := egs.FindByName(defaultExGroup.UserId, strings.ToUpper(defaultExGroup.Name))
+	if err != nil || found.Id != id {
+		t.Errorf("exgroup isn't found by name in other case: %#v, %v", found, err)
+	}
+	_, err = egs.Save(ExGroup{UserId: defaultExGroup.UserId + 1, Name: defaultExGroup.Name})
+	if err != nil {
+		t.Errorf("error saving exgroup with the same name for other user: %v", err)
+	}
+	egs.SoftDeleteByName(defaultExGroup.UserId, defaultExGroup.Name)
+	_, err = createDefaultExGroup()
+	if err != nil {
+		t.Errorf("error saving exgroup with name of deleted one: %v", err)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestEGSSetMuscles(t *testing.T) {
+	//negative case
+	err := egs.SetMuscles(defaultExGroup.UserId, "Nosuchname", []string{"lats"})
+	if err != NotUpdated {
+		t.Errorf("mapped unexisting exgroup: %v", err)
+	}
+	//positive case
+	id, err := createDefaultExGroup()
+	if err != nil {
+		t.Fatal("error saving exgroup")
+	}
+	err = egs.SetMuscles(defaultExGroup.UserId, defaultExGroup.Name, []string{"lats", "traps"})
+	if err != nil {
+		t.Fatalf("error mapping exgroup: %v", err)
+	}
+	found, _ := egs.FindById(id)
+	if len(found.Muscles) != 2 || found.Muscles[0] != "lats" {
+		t.Errorf("got wrong muscles: %#v", found)
+	}
+	//removing mapping
+	egs.SetMuscles(defaultExGroup.UserId, defaultExGroup.Name, []string{})
+	found, _ = egs.FindById(id)
+	if len(found.Muscles) != 0 {
+		t.Errorf("mapping wasn't removed: %#v", found)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestEGSDeleteModes(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	//group with exercises isn't deleted
+	err = egs.DeleteByName(defaultExGroup.UserId, defaultExGroup.Name)
+	if !errors.Is(err, HasExercises) || err.Error() != "exercise group has exercises: "+exercise.Name {
+		t.Errorf("deleted exgroup with exercises: %v", err)
+	}
+	//exercises are moved to target group
+	targetId, _ := egs.Save(ExGroup{UserId: defaultExGroup.UserId, Name: "Pull"})
+	err = egs.MoveByName(defaultExGroup.UserId, defaultExGroup.Name, "Nosuchname")
+	if err != sql.ErrNoRows {
+		t.Errorf("moved exercises to unexisting exgroup: %v", err)
+	}
+	err = egs.MoveByName(defaultExGroup.UserId, defaultExGroup.Name, "Pull")
+	if err != nil {
+		t.Fatalf("error moving exercises: %v", err)
+	}
+	moved, _ := ex.FindById(exercise.Id)
+	if moved.ExerciseGroupId != targetId {
+		t.Errorf("exercise wasn't moved: %#v", moved)
+	}
+	_, err = egs.FindByName(defaultExGroup.UserId, defaultExGroup.Name)
+	if err != sql.ErrNoRows {
+		t.Errorf("exgroup wasn't deleted after moving: %v", err)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestEGSSoftDeleteRestore(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	err = egs.SoftDeleteByName(defaultExGroup.UserId, defaultExGroup.Name)
+	if err != nil {
+		t.Fatalf("error soft deleting exgroup: %v", err)
+	}
+	_, err = egs.FindById(exercise.ExerciseGroupId)
+	if err != sql.ErrNoRows {
+		t.Errorf("found soft deleted exgroup: %v", err)
+	}
+	_, err = ex.FindById(exercise.Id)
+	if err != sql.ErrNoRows {
+		t.Errorf("found exercise of soft deleted exgroup: %v", err)
+	}
+	err = egs.SoftDeleteByName(defaultExGroup.UserId, defaultExGroup.Name)
+	if err != NotDeleted {
+		t.Errorf("soft deleted exgroup twice: %v", err)
+	}
+	//group isn't restored, while other group has its name
+	createDefaultExGroup()
+	err = egs.RestoreByName(defaultExGroup.UserId, defaultExGroup.Name)
+	if err != NameTaken {
+		t.Errorf("restored exgroup with taken name: %v", err)
+	}
+	egs.UpdateByName(defaultExGroup.UserId, defaultExGroup.Name, "OtherBack")
+	err = egs.RestoreByName(defaultExGroup.UserId, defaultExGroup.Name)
+	if err != nil {
+		t.Fatalf("error restoring exgroup: %v", err)
+	}
+	restored, err := ex.FindById(exercise.Id)
+	if err != nil || restored.ExerciseGroupId != exercise.ExerciseGroupId {
+		t.Errorf("exercise wasn't restored: %#v, %v", restored, err)
+	}
+	err = egs.RestoreByName(defaultExGroup.UserId, defaultExGroup.Name)
+	if err != NotUpdated {
+		t.Errorf("restored exgroup twice: %v", err)
+	}
+	t.Cleanup(clearTables)
 }
@@ -0,0 +1,88 @@
+package stores
+
+import (
+	"database/sql"
+)
+
+type ExerciseStoreStub struct{}
+
+func (exs ExerciseStoreStub) Save(exercise Exercise) (int64, error) {
+	return 1, nil
+}
+
+func (exs ExerciseStoreStub) FindById(id int64) (Exercise, error) {
+	var exercise Exercise
+	return exercise, nil
+}
+
+// FindByName - user has exercise Squat in several groups
+func (exs ExerciseStoreStub) FindByName(userId int64, group string, name string) (exercise Exercise, err error) {
+	if name == "Unexisting" {
+		err = sql.ErrNoRows
+	} else if name == "Squat" && group == "" {
+		err = AmbiguousName
+	} else {
+		exercise = Exercise{
+			Id:              1,
+			Name:            "Pull up",
+			Rest:            90,
+			ExerciseTypeId:  2,
+			UserId:          2,
+			ExerciseGroupId: 1,
+		}
+	}
+	return exercise, err
+}
+
+func (exs ExerciseStoreStub) DeleteById(id int64) error {
+	return nil
+}
+
+func (exs ExerciseStoreStub) DeleteByName(userId int64, group string, name string) error {
+	if name == "Unexisting" {
+		return NotDeleted
+	}
+	if name == "Squat" && group == "" {
+		return AmbiguousName
+	}
+	return nil
+}
+
+func (exs ExerciseStoreStub) Update(exercise Exercise) error {
+	if exercise.Name == "Unexisting" {
+		return NotUpdated
+	}
+	return nil
+}
+
+func (exs ExerciseStoreStub) FindByGroup(userId int64, groupName string) ([]Exercise, error) {
+	if userId == 1 {
+		return nil, sql.ErrNoRows
+	}
+	exercises := []Exercise{
+		{
+			Id:              1,
+			Name:            "Pull up",
+			Rest:            90,
+			ExerciseTypeId:  2,
+			UserId:          userId,
+			ExerciseGroupId: 1,
+		},
+		{
+			Id:              2,
+			Name:            "Deadlift",
+			Rest:            180,
+			ExerciseTypeId:  3,
+			UserId:          userId,
+			ExerciseGroupId: 1,
+		},
+	}
+	return exercises, nil
+}
+
+func (exs ExerciseStoreStub) SetMuscles(userId int64, group string, name string, muscles []string) error {
+	if name == "Unexisting" {
+		return NotUpdated
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package stores
+
+import (
+	"database/sql"
+	"time"
+)
+
+type ProgramStoreStub struct{}
+
+func (pgss ProgramStoreStub) Save(program Program) (int64, error) {
+	if program.Days[0].TemplateId != 1 {
+		return 0, sql.ErrNoRows
+	}
+	return 1, nil
+}
+
+// FindById - program 1 with single workout day, which is planned by template 1
+func (pgss ProgramStoreStub) FindById(userId int64, programId int64) (Program, error) {
+	if programId != 1 {
+		return Program{}, sql.ErrNoRows
+	}
+	return Program{
+		Id:           1,
+		UserId:       userId,
+		Name:         "Strength",
+		Weeks:        8,
+		DeloadEvery:  4,
+		DeloadFactor: 0.9,
+		Days:         []ProgramDay{{Position: 1, TemplateId: 1}},
+		Progressions: []Progression{{ExerciseId: 1, Increment: 2.5}},
+	}, nil
+}
+
+func (pgss ProgramStoreStub) Enroll(userId int64, programId int64) (int64, error) {
+	if programId != 1 {
+		return 0, sql.ErrNoRows
+	}
+	return 1, nil
+}
+
+// FindEnrollment - user 1 isn't enrolled, user 4 completed program, others are in the third week
+func (pgss ProgramStoreStub) FindEnrollment(userId int64) (Enrollment, error) {
+	if userId == 1 {
+		return Enrollment{}, NotEnrolled
+	}
+	enrollment := Enrollment{
+		Id:         1,
+		UserId:     userId,
+		ProgramId:  1,
+		Status:     Active,
+		DayIndex:   2,
+		StartedAt:  time.Now(),
+		AdvancedAt: time.Now(),
+	}
+	if userId == 4 {
+		enrollment.DayIndex = 8
+	}
+	return enrollment, nil
+}
+
+func (pgss ProgramStoreStub) Skip(userId int64) error {
+	if userId == 1 {
+		return NotEnrolled
+	}
+	return nil
+}
+
+func (pgss ProgramStoreStub) Advance(userId int64) error {
+	if userId == 1 {
+		return NotEnrolled
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package stores
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+)
+
+// PMSStub - in-memory realization of ProcessedMessageStore
+type PMSStub struct {
+	mu        sync.Mutex
+	responses map[string][]byte
+}
+
+func NewPMSStub() *PMSStub {
+	return &PMSStub{
+		responses: make(map[string][]byte),
+	}
+}
+
+func (pmss *PMSStub) Reserve(key string) (bool, error) {
+	pmss.mu.Lock()
+	defer pmss.mu.Unlock()
+	if _, ok := pmss.responses[key]; ok {
+		return false, nil
+	}
+	pmss.responses[key] = nil
+	return true, nil
+}
+
+func (pmss *PMSStub) Complete(key string, response []byte) error {
+	pmss.mu.Lock()
+	defer pmss.mu.Unlock()
+	if _, ok := pmss.responses[key]; !ok {
+		return NotUpdated
+	}
+	pmss.responses[key] = response
+	return nil
+}
+
+func (pmss *PMSStub) Release(key string) error {
+	pmss.mu.Lock()
+	defer pmss.mu.Unlock()
+	if pmss.responses[key] == nil {
+		delete(pmss.responses, key)
+	}
+	return nil
+}
+
+func (pmss *PMSStub) FindResponse(key string) ([]byte, error) {
+	pmss.mu.Lock()
+	defer pmss.mu.Unlock()
+	response, ok := pmss.responses[key]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	if response == nil {
+		return nil, InProgress
+	}
+	return response, nil
+}
+
+func (pmss *PMSStub) Purge(before time.Time) (int64, error) {
+	return 0, nil
+}
@@ -0,0 +1,104 @@
+package stores
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/fridrock/trainingservice/errkind"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	InProgress = errkind.New(errkind.Conflict, "message is already being processed")
+)
+
+// ReservationLease - time, after which reservation without response is considered abandoned by crashed worker
+// and can be taken over
+const ReservationLease = 5 * time.Minute
+
+// ProcessedMessageStore - interface which contains all methods for working with processed_messages table,
+// which keeps responses of already handled messages by their idempotency key
+type ProcessedMessageStore interface {
+	Reserve(key string) (bool, error)
+	Complete(key string, response []byte) error
+	Release(key string) error
+	FindResponse(key string) ([]byte, error)
+	Purge(before time.Time) (int64, error)
+}
+
+// PMS - standard realization of ProcessedMessageStore
+type PMS struct {
+	conn *sqlx.DB
+}
+
+// NewPMS - function that creates realization for ProcessedMessageStore interface
+func NewPMS(conn *sqlx.DB) *PMS {
+	return &PMS{
+		conn: conn,
+	}
+}
+
+// Reserve - marks key as being processed, returns false if key was already reserved. Reservation without
+// response, that is older than ReservationLease, is taken over
+func (pms PMS) Reserve(key string) (bool, error) {
+	q := `INSERT INTO processed_messages(key) VALUES($1)
+		ON CONFLICT (key) DO UPDATE SET created_at=now()
+		WHERE processed_messages.response IS NULL
+			AND processed_messages.created_at<now()-make_interval(secs => $2)`
+	res, err := pms.conn.Exec(q, key, ReservationLease.Seconds())
+	if err != nil {
+		return false, err
+	}
+	r, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return r == 1, nil
+}
+
+// Complete - saves response for reserved key
+func (pms PMS) Complete(key string, response []byte) error {
+	q := `UPDATE processed_messages SET response=$1 WHERE key=$2`
+	res, err := pms.conn.Exec(q, string(response), key)
+	if err != nil {
+		return err
+	}
+	r, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if r == 0 {
+		return NotUpdated
+	}
+	return nil
+}
+
+// Release - removes reservation of key, so message can be processed again
+func (pms PMS) Release(key string) error {
+	q := `DELETE FROM processed_messages WHERE key=$1 AND response IS NULL`
+	_, err := pms.conn.Exec(q, key)
+	return err
+}
+
+// FindResponse - returns saved response of key, InProgress if key is reserved, but has no response yet
+func (pms PMS) FindResponse(key string) ([]byte, error) {
+	var response sql.NullString
+	q := `SELECT response FROM processed_messages WHERE key=$1`
+	err := pms.conn.Get(&response, q, key)
+	if err != nil {
+		return nil, err
+	}
+	if !response.Valid {
+		return nil, InProgress
+	}
+	return []byte(response.String), nil
+}
+
+// Purge - deletes keys reserved before the time, returns number of deleted keys
+func (pms PMS) Purge(before time.Time) (int64, error) {
+	res, err := pms.conn.Exec(`DELETE FROM processed_messages WHERE created_at<$1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,103 @@
+package stores
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestPMSReserve(t *testing.T) {
+	reserved, err := pms.Reserve("trainings.training.start:1")
+	if err != nil || !reserved {
+		t.Fatalf("error reserving new key: %v", err)
+	}
+	reserved, err = pms.Reserve("trainings.training.start:1")
+	if err != nil || reserved {
+		t.Errorf("reserved key twice: %v", err)
+	}
+	//reservation of crashed worker is taken over after lease
+	conn.MustExec(`UPDATE processed_messages SET created_at=now()-interval '1 hour'`)
+	reserved, err = pms.Reserve("trainings.training.start:1")
+	if err != nil || !reserved {
+		t.Errorf("expired reservation wasn't taken over: %v", err)
+	}
+	reserved, _ = pms.Reserve("trainings.training.start:1")
+	if reserved {
+		t.Errorf("taken over key was reserved again")
+	}
+	//completed key isn't taken over
+	pms.Complete("trainings.training.start:1", []byte("response"))
+	conn.MustExec(`UPDATE processed_messages SET created_at=now()-interval '1 hour'`)
+	reserved, _ = pms.Reserve("trainings.training.start:1")
+	if reserved {
+		t.Errorf("completed key was taken over")
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestPMSCompleteFindResponse(t *testing.T) {
+	//negative cases
+	_, err := pms.FindResponse("trainings.training.start:1")
+	if err != sql.ErrNoRows {
+		t.Errorf("found response of unexisting key: %v", err)
+	}
+	err = pms.Complete("trainings.training.start:1", []byte("response"))
+	if err != NotUpdated {
+		t.Errorf("completed unreserved key: %v", err)
+	}
+	pms.Reserve("trainings.training.start:1")
+	_, err = pms.FindResponse("trainings.training.start:1")
+	if err != InProgress {
+		t.Errorf("error finding response of key in progress: %v", err)
+	}
+	//positive case
+	err = pms.Complete("trainings.training.start:1", []byte("response"))
+	if err != nil {
+		t.Fatalf("error completing key: %v", err)
+	}
+	response, err := pms.FindResponse("trainings.training.start:1")
+	if err != nil || string(response) != "response" {
+		t.Errorf("found wrong response: %s, %v", response, err)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestPMSRelease(t *testing.T) {
+	pms.Reserve("trainings.training.start:1")
+	err := pms.Release("trainings.training.start:1")
+	if err != nil {
+		t.Fatalf("error releasing key: %v", err)
+	}
+	reserved, _ := pms.Reserve("trainings.training.start:1")
+	if !reserved {
+		t.Errorf("released key wasn't reserved again")
+	}
+	//completed key isn't released
+	pms.Complete("trainings.training.start:1", []byte("response"))
+	pms.Release("trainings.training.start:1")
+	reserved, _ = pms.Reserve("trainings.training.start:1")
+	if reserved {
+		t.Errorf("completed key was released")
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestPMSPurge(t *testing.T) {
+	pms.Reserve("trainings.training.start:1")
+	pms.Complete("trainings.training.start:1", []byte("response"))
+	pms.Reserve("trainings.training.start:2")
+	conn.MustExec(`UPDATE processed_messages SET created_at=now()-interval '8 days' WHERE key='trainings.training.start:1'`)
+	purged, err := pms.Purge(time.Now().Add(-7 * 24 * time.Hour))
+	if err != nil || purged != 1 {
+		t.Fatalf("purged wrong amount of keys: %d, %v", purged, err)
+	}
+	_, err = pms.FindResponse("trainings.training.start:1")
+	if err != sql.ErrNoRows {
+		t.Errorf("old key wasn't purged: %v", err)
+	}
+	_, err = pms.FindResponse("trainings.training.start:2")
+	if err != InProgress {
+		t.Errorf("recent key was purged: %v", err)
+	}
+	t.Cleanup(clearTables)
+}
@@ -0,0 +1,220 @@
+package stores
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fridrock/trainingservice/errkind"
+	"github.com/jmoiron/sqlx"
+)
+
+// ProgramDay struct that is entity for program_days table, workout day of program, which is planned by template
+type ProgramDay struct {
+	Position   int64 `db:"position" json:"position"`
+	TemplateId int64 `db:"template_id" json:"template_id"`
+}
+
+// Progression struct that is entity for program_progressions table, weight increment of exercise per week
+type Progression struct {
+	ExerciseId int64   `db:"exercise_id" json:"exercise_id"`
+	Increment  float64 `db:"increment" json:"increment" unit:"weight"`
+}
+
+// Program struct that is entity for programs table. Days are repeated every week during Weeks,
+// every DeloadEvery week (if it isn't zero) weights are multiplied by DeloadFactor
+type Program struct {
+	Id           int64         `db:"id" json:"id"`
+	UserId       int64         `db:"user_id" json:"user_id"`
+	Name         string        `db:"name" json:"name"`
+	Weeks        int64         `db:"weeks" json:"weeks"`
+	DeloadEvery  int64         `db:"deload_every" json:"deload_every"`
+	DeloadFactor float64       `db:"deload_factor" json:"deload_factor"`
+	Days         []ProgramDay  `db:"-" json:"days"`
+	Progressions []Progression `db:"-" json:"progressions"`
+}
+
+// EnrollmentStatus - state of enrollment, user has only one active enrollment
+type EnrollmentStatus string
+
+const (
+	Active    EnrollmentStatus = "active"
+	Cancelled EnrollmentStatus = "cancelled"
+)
+
+// Enrollment struct that is entity for program_enrollments table. DayIndex is index of today's workout
+// in program, it counts trainings finished since the last skip or advance
+type Enrollment struct {
+	Id         int64            `db:"id" json:"id"`
+	UserId     int64            `db:"user_id" json:"user_id"`
+	ProgramId  int64            `db:"program_id" json:"program_id"`
+	Status     EnrollmentStatus `db:"status" json:"status"`
+	DayIndex   int64            `db:"day_index" json:"day_index"`
+	Skipped    int64            `db:"skipped" json:"skipped"`
+	StartedAt  time.Time        `db:"started_at" json:"started_at"`
+	AdvancedAt time.Time        `db:"advanced_at" json:"advanced_at"`
+}
+
+// ProgramStore - interface which contains all methods for working with programs and program_enrollments tables
+type ProgramStore interface {
+	Save(Program) (int64, error)
+	FindById(userId int64, programId int64) (Program, error)
+	Enroll(userId int64, programId int64) (int64, error)
+	FindEnrollment(userId int64) (Enrollment, error)
+	Skip(userId int64) error
+	Advance(userId int64) error
+}
+
+var (
+	NotEnrolled      = errkind.New(errkind.NotFound, "no active program enrollment")
+	ProgramCompleted = errkind.New(errkind.Conflict, "program is completed")
+)
+
+// dayIndex - index of today's workout of enrollment en, $1 must be status of finished training
+const dayIndex = `en.day_index + (SELECT count(*) FROM trainings t
+	WHERE t.user_id=en.user_id AND t.status=$1 AND t.begins>=en.advanced_at AND t.deleted_at IS NULL)`
+
+// PGS - standard realization of ProgramStore
+type PGS struct {
+	conn *sqlx.DB
+}
+
+// NewPGS - function that creates realization for ProgramStore interface
+func NewPGS(conn *sqlx.DB) *PGS {
+	return &PGS{
+		conn: conn,
+	}
+}
+
+// Save - saves program with its days and progressions, returns wrapped sql.ErrNoRows if template
+// of day or exercise of progression doesn't belong to user or exercise is deleted
+func (pgs PGS) Save(program Program) (int64, error) {
+	tx, err := pgs.conn.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var programId int64
+	q := `INSERT INTO programs(user_id, name, weeks, deload_every, deload_factor)
+		VALUES($1, $2, $3, $4, $5) RETURNING id`
+	err = tx.Get(&programId, q, program.UserId, program.Name, program.Weeks, program.DeloadEvery, program.DeloadFactor)
+	if err != nil {
+		return 0, err
+	}
+	q = `INSERT INTO program_days(program_id, position, template_id)
+		SELECT $1, $2, w.id FROM workout_templates w WHERE w.id=$3 AND w.user_id=$4`
+	for i, day := range program.Days {
+		res, err := tx.Exec(q, programId, i+1, day.TemplateId, program.UserId)
+		if err != nil {
+			return 0, err
+		}
+		r, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		if r == 0 {
+			return 0, fmt.Errorf("template %d: %w", day.TemplateId, sql.ErrNoRows)
+		}
+	}
+	q = `INSERT INTO program_progressions(program_id, exercise_id, increment)
+		SELECT $1, e.id, $2 FROM exercises e WHERE e.id=$3 AND e.user_id=$4 AND e.deleted_at IS NULL`
+	for _, progression := range program.Progressions {
+		res, err := tx.Exec(q, programId, progression.Increment, progression.ExerciseId, program.UserId)
+		if err != nil {
+			return 0, err
+		}
+		r, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		if r == 0 {
+			return 0, fmt.Errorf("exercise %d: %w", progression.ExerciseId, sql.ErrNoRows)
+		}
+	}
+	return programId, tx.Commit()
+}
+
+func (pgs PGS) FindById(userId int64, programId int64) (Program, error) {
+	var program Program
+	q := `SELECT id, user_id, name, weeks, deload_every, deload_factor FROM programs WHERE id=$1 AND user_id=$2`
+	err := pgs.conn.Get(&program, q, programId, userId)
+	if err != nil {
+		return program, err
+	}
+	q = `SELECT position, template_id FROM program_days WHERE program_id=$1 ORDER BY position`
+	err = pgs.conn.Select(&program.Days, q, programId)
+	if err != nil {
+		return program, err
+	}
+	q = `SELECT exercise_id, increment FROM program_progressions WHERE program_id=$1 ORDER BY exercise_id`
+	err = pgs.conn.Select(&program.Progressions, q, programId)
+	return program, err
+}
+
+// Enroll - enrolls user to program from its first day, previous active enrollment is cancelled
+func (pgs PGS) Enroll(userId int64, programId int64) (int64, error) {
+	tx, err := pgs.conn.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(`UPDATE program_enrollments SET status=$1 WHERE user_id=$2 AND status=$3`,
+		Cancelled, userId, Active)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	var enrollmentId int64
+	q := `INSERT INTO program_enrollments(user_id, program_id, status, started_at, advanced_at)
+		SELECT $1, p.id, $2, $3, $3 FROM programs p WHERE p.id=$4 AND p.user_id=$1 RETURNING id`
+	err = tx.Get(&enrollmentId, q, userId, Active, now, programId)
+	if err != nil {
+		return 0, err
+	}
+	return enrollmentId, tx.Commit()
+}
+
+// FindEnrollment - active enrollment of user, returns NotEnrolled if user has no such
+func (pgs PGS) FindEnrollment(userId int64) (Enrollment, error) {
+	var enrollment Enrollment
+	q := `SELECT en.id, en.user_id, en.program_id, en.status, ` + dayIndex + ` AS day_index,
+			en.skipped, en.started_at, en.advanced_at
+		FROM program_enrollments en WHERE en.user_id=$2 AND en.status=$3`
+	err := pgs.conn.Get(&enrollment, q, Finished, userId, Active)
+	if err == sql.ErrNoRows {
+		return enrollment, NotEnrolled
+	}
+	return enrollment, err
+}
+
+// Skip - moves active enrollment of user to the next workout day without training
+func (pgs PGS) Skip(userId int64) error {
+	q := `UPDATE program_enrollments en SET day_index=` + dayIndex + ` + 1, skipped=en.skipped + 1, advanced_at=$2
+		WHERE en.user_id=$3 AND en.status=$4`
+	return pgs.move(q, Finished, time.Now(), userId, Active)
+}
+
+// Advance - moves active enrollment of user to the first workout day of the next week
+func (pgs PGS) Advance(userId int64) error {
+	q := `UPDATE program_enrollments en SET day_index=(
+			SELECT (` + dayIndex + `) / count(*) * count(*) + count(*) FROM program_days d WHERE d.program_id=en.program_id
+		), advanced_at=$2
+		WHERE en.user_id=$3 AND en.status=$4`
+	return pgs.move(q, Finished, time.Now(), userId, Active)
+}
+
+// move - executes update of enrollment, returns NotEnrolled if nothing was updated
+func (pgs PGS) move(q string, args ...any) error {
+	res, err := pgs.conn.Exec(q, args...)
+	if err != nil {
+		return err
+	}
+	r, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if r == 0 {
+		return NotEnrolled
+	}
+	return nil
+}
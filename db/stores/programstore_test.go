@@ -0,0 +1,126 @@
+package stores
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func createDefaultProgram() (Program, error) {
+	template, err := createDefaultTemplate()
+	if err != nil {
+		return Program{}, err
+	}
+	program := Program{
+		UserId:       template.UserId,
+		Name:         "Strength",
+		Weeks:        4,
+		DeloadEvery:  4,
+		DeloadFactor: 0.9,
+		Days:         []ProgramDay{{TemplateId: template.Id}, {TemplateId: template.Id}},
+		Progressions: []Progression{{ExerciseId: template.Exercises[0].ExerciseId, Increment: 2.5}},
+	}
+	program.Id, err = pgs.Save(program)
+	return program, err
+}
+
+func TestPGSSaveFindById(t *testing.T) {
+	program, err := createDefaultProgram()
+	if err != nil {
+		t.Fatalf("error saving program: %v", err)
+	}
+	found, err := pgs.FindById(program.UserId, program.Id)
+	if err != nil {
+		t.Fatalf("error finding program: %v", err)
+	}
+	program.Days[0].Position = 1
+	program.Days[1].Position = 2
+	if diff := cmp.Diff(program, found); diff != "" {
+		t.Errorf("got wrong program: %s", diff)
+	}
+	//template of other user
+	_, err = pgs.Save(Program{UserId: program.UserId + 1, Name: "Foreign", Weeks: 1,
+		Days: []ProgramDay{{TemplateId: program.Days[0].TemplateId}}})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("saved program with template of other user: %v", err)
+	}
+	//progression of exercise of other user
+	_, err = pgs.Save(Program{UserId: program.UserId + 1, Name: "Foreign", Weeks: 1,
+		Progressions: []Progression{{ExerciseId: program.Progressions[0].ExerciseId, Increment: 2.5}}})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("saved program with progression of exercise of other user: %v", err)
+	}
+	//progression of deleted exercise
+	ex.DeleteById(program.Progressions[0].ExerciseId)
+	program.Days = nil
+	_, err = pgs.Save(program)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("saved program with progression of deleted exercise: %v", err)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestPGSEnrollment(t *testing.T) {
+	program, err := createDefaultProgram()
+	if err != nil {
+		t.Fatalf("error saving program: %v", err)
+	}
+	userId := program.UserId
+	_, err = pgs.FindEnrollment(userId)
+	if err != NotEnrolled {
+		t.Errorf("found enrollment before enrolling: %v", err)
+	}
+	_, err = pgs.Enroll(userId, program.Id+1)
+	if err != sql.ErrNoRows {
+		t.Errorf("enrolled to unexisting program: %v", err)
+	}
+	firstId, _ := pgs.Enroll(userId, program.Id)
+	enrollmentId, err := pgs.Enroll(userId, program.Id)
+	if err != nil {
+		t.Fatalf("error enrolling to program: %v", err)
+	}
+	enrollment, err := pgs.FindEnrollment(userId)
+	if err != nil {
+		t.Fatalf("error finding enrollment: %v", err)
+	}
+	if enrollment.Id != enrollmentId || enrollment.Id == firstId || enrollment.DayIndex != 0 {
+		t.Errorf("got wrong enrollment: %#v", enrollment)
+	}
+	//finished training moves program to the next day
+	ts.StartTraining(userId)
+	ts.FinishTraining(userId)
+	enrollment, _ = pgs.FindEnrollment(userId)
+	if enrollment.DayIndex != 1 {
+		t.Errorf("finished training didn't move program: %#v", enrollment)
+	}
+	//skipping moves to the next day
+	err = pgs.Skip(userId)
+	if err != nil {
+		t.Fatalf("error skipping workout: %v", err)
+	}
+	enrollment, _ = pgs.FindEnrollment(userId)
+	if enrollment.DayIndex != 2 || enrollment.Skipped != 1 {
+		t.Errorf("got wrong enrollment after skip: %#v", enrollment)
+	}
+	//advancing moves to the first day of the next week
+	ts.StartTraining(userId)
+	ts.FinishTraining(userId)
+	err = pgs.Advance(userId)
+	if err != nil {
+		t.Fatalf("error advancing program: %v", err)
+	}
+	enrollment, _ = pgs.FindEnrollment(userId)
+	if enrollment.DayIndex != 4 {
+		t.Errorf("got wrong enrollment after advance: %#v", enrollment)
+	}
+	//negative cases
+	if err = pgs.Skip(userId + 1); err != NotEnrolled {
+		t.Errorf("skipped workout without enrollment: %v", err)
+	}
+	if err = pgs.Advance(userId + 1); err != NotEnrolled {
+		t.Errorf("advanced program without enrollment: %v", err)
+	}
+	t.Cleanup(clearTables)
+}
@@ -0,0 +1,68 @@
+package stores
+
+import (
+	"database/sql"
+)
+
+type RecordStoreStub struct{}
+
+// Detect - set of exercise 7 beats max weight record, others beat nothing
+func (prss RecordStoreStub) Detect(set ExerciseSet) ([]PersonalRecord, error) {
+	if set.ExerciseId != 7 {
+		return nil, nil
+	}
+	return []PersonalRecord{
+		{
+			Id:         1,
+			UserId:     set.UserId,
+			ExerciseId: set.ExerciseId,
+			Kind:       MaxWeight,
+			Value:      set.Weight,
+			SetId:      set.Id,
+			Previous:   60,
+		},
+	}, nil
+}
+
+func (prss RecordStoreStub) Rebuild(userId int64) error {
+	return nil
+}
+
+func (prss RecordStoreStub) FindByUser(userId int64) ([]PersonalRecord, error) {
+	if userId == 1 {
+		return nil, sql.ErrNoRows
+	}
+	return []PersonalRecord{
+		{
+			Id:         1,
+			UserId:     userId,
+			ExerciseId: 1,
+			Kind:       MaxWeight,
+			Value:      62.5,
+			SetId:      2,
+		},
+		{
+			Id:         2,
+			UserId:     userId,
+			ExerciseId: 1,
+			Kind:       MaxReps,
+			Weight:     60,
+			Value:      10,
+			SetId:      1,
+		},
+	}, nil
+}
+
+func (prss RecordStoreStub) FindByExercise(userId int64, exerciseId int64) ([]PersonalRecord, error) {
+	records, err := prss.FindByUser(userId)
+	if err != nil {
+		return nil, err
+	}
+	var found []PersonalRecord
+	for _, record := range records {
+		if record.ExerciseId == exerciseId {
+			found = append(found, record)
+		}
+	}
+	return found, nil
+}
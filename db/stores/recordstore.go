@@ -0,0 +1,139 @@
+package stores
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// RecordKind - kind of personal record
+type RecordKind string
+
+const (
+	MaxWeight     RecordKind = "max_weight"
+	MaxReps       RecordKind = "max_reps"
+	BestOneRepMax RecordKind = "best_1rm"
+	MaxDuration   RecordKind = "max_duration"
+)
+
+// Unit - unit of value of record with the kind, values of weight records are weights, reps and durations have
+// no unit
+func (rk RecordKind) Unit() string {
+	if rk == MaxWeight || rk == BestOneRepMax {
+		return "weight"
+	}
+	return ""
+}
+
+// PersonalRecord struct that is entity for personal_records table. Weight is the weight of set for MaxReps records
+// and zero for others, Value is weight, reps, estimated one-rep max or duration in seconds depending on Kind.
+// Previous is the value of beaten record, it is zero if there was no record before
+type PersonalRecord struct {
+	Id         int64      `db:"id" json:"id"`
+	UserId     int64      `db:"user_id" json:"user_id"`
+	ExerciseId int64      `db:"exercise_id" json:"exercise_id"`
+	Kind       RecordKind `db:"kind" json:"kind"`
+	Weight     float64    `db:"weight" json:"weight" unit:"weight"`
+	Value      float64    `db:"value" json:"value" unit:"by:Kind"`
+	SetId      int64      `db:"set_id" json:"set_id"`
+	AchievedAt time.Time  `db:"achieved_at" json:"achieved_at"`
+	Previous   float64    `db:"previous" json:"previous,omitempty" unit:"by:Kind"`
+}
+
+// RecordStore - interface which contains all methods for working with personal_records table
+type RecordStore interface {
+	Detect(set ExerciseSet) ([]PersonalRecord, error)
+	Rebuild(userId int64) error
+	FindByUser(userId int64) ([]PersonalRecord, error)
+	FindByExercise(userId int64, exerciseId int64) ([]PersonalRecord, error)
+}
+
+// recordCandidates - records, that sets from exercise_sets s would set, one-rep max is estimated with Epley formula
+const recordCandidates = `
+	SELECT s.id AS set_id, s.user_id, s.exercise_id, 'max_weight' AS kind, 0::real AS weight,
+		s.weight::float8 AS value, s.created_at FROM exercise_sets s WHERE s.weight IS NOT NULL AND %[1]s
+	UNION ALL
+	SELECT s.id, s.user_id, s.exercise_id, 'max_reps', COALESCE(s.weight, 0), s.reps::float8, s.created_at
+		FROM exercise_sets s WHERE s.reps IS NOT NULL AND %[1]s
+	UNION ALL
+	SELECT s.id, s.user_id, s.exercise_id, 'best_1rm', 0, s.weight::float8 * (1 + s.reps::float8 / 30), s.created_at
+		FROM exercise_sets s WHERE s.weight IS NOT NULL AND s.reps IS NOT NULL AND %[1]s
+	UNION ALL
+	SELECT s.id, s.user_id, s.exercise_id, 'max_duration', 0, EXTRACT(EPOCH FROM s.duration)::float8, s.created_at
+		FROM exercise_sets s WHERE s.duration IS NOT NULL AND %[1]s`
+
+const recordColumns = `id, user_id, exercise_id, kind, weight, value, set_id, achieved_at`
+
+// PRS - standard realization of RecordStore
+type PRS struct {
+	conn *sqlx.DB
+}
+
+// NewPRS - function that creates realization for RecordStore interface
+func NewPRS(conn *sqlx.DB) *PRS {
+	return &PRS{
+		conn: conn,
+	}
+}
+
+// Detect - saves records, which are set by stored set, returns only records that beat existing ones
+func (prs PRS) Detect(set ExerciseSet) ([]PersonalRecord, error) {
+	q := `WITH candidates AS (` + fmt.Sprintf(recordCandidates, "s.id=$1") + `),
+		old AS (SELECT kind, weight, value FROM personal_records WHERE user_id=$2 AND exercise_id=$3)
+		INSERT INTO personal_records(user_id, exercise_id, kind, weight, value, set_id, achieved_at)
+		SELECT user_id, exercise_id, kind, weight, value, set_id, created_at FROM candidates
+		ON CONFLICT (user_id, exercise_id, kind, weight) DO UPDATE
+		SET value=EXCLUDED.value, set_id=EXCLUDED.set_id, achieved_at=EXCLUDED.achieved_at
+		WHERE personal_records.value < EXCLUDED.value
+		RETURNING ` + recordColumns + `, COALESCE((SELECT o.value FROM old o
+			WHERE o.kind=personal_records.kind AND o.weight=personal_records.weight), 0) AS previous`
+	var records []PersonalRecord
+	err := prs.conn.Select(&records, q, set.Id, set.UserId, set.ExerciseId)
+	if err != nil {
+		return nil, err
+	}
+	beaten := make([]PersonalRecord, 0, len(records))
+	for _, record := range records {
+		if record.Previous != 0 {
+			beaten = append(beaten, record)
+		}
+	}
+	return beaten, nil
+}
+
+// rebuildQuery - inserts missing records from stored sets, which match condition. Deleted sets don't set records
+const rebuildQuery = `INSERT INTO personal_records(user_id, exercise_id, kind, weight, value, set_id, achieved_at)
+	SELECT DISTINCT ON (exercise_id, kind, weight) user_id, exercise_id, kind, weight, value, set_id, created_at
+	FROM (` + recordCandidates + `) candidates
+	ORDER BY exercise_id, kind, weight, value DESC, set_id
+	ON CONFLICT (user_id, exercise_id, kind, weight) DO NOTHING`
+
+// Rebuild - restores missing records of user from stored sets, is used after records were deleted with their set
+func (prs PRS) Rebuild(userId int64) error {
+	q := fmt.Sprintf(rebuildQuery, "s.user_id=$1 AND s.deleted_at IS NULL")
+	_, err := prs.conn.Exec(q, userId)
+	return err
+}
+
+// rebuildRecords - restores missing records of exercises from stored sets, e is either connection or transaction
+func rebuildRecords(e sqlx.Execer, exerciseIds []int64) error {
+	q := fmt.Sprintf(rebuildQuery, "s.exercise_id=ANY($1) AND s.deleted_at IS NULL")
+	_, err := e.Exec(q, pq.Array(exerciseIds))
+	return err
+}
+
+func (prs PRS) FindByUser(userId int64) ([]PersonalRecord, error) {
+	var records []PersonalRecord
+	q := `SELECT ` + recordColumns + ` FROM personal_records WHERE user_id=$1 ORDER BY exercise_id, kind, weight`
+	err := prs.conn.Select(&records, q, userId)
+	return records, err
+}
+
+func (prs PRS) FindByExercise(userId int64, exerciseId int64) ([]PersonalRecord, error) {
+	var records []PersonalRecord
+	q := `SELECT ` + recordColumns + ` FROM personal_records WHERE user_id=$1 AND exercise_id=$2 ORDER BY kind, weight`
+	err := prs.conn.Select(&records, q, userId, exerciseId)
+	return records, err
+}
@@ -0,0 +1,76 @@
+package stores
+
+import (
+	"testing"
+)
+
+func TestPRSDetect(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	ts.StartTraining(1)
+	//first set only establishes records
+	first, _ := ess.AddSet(ExerciseSet{UserId: 1, ExerciseId: exercise.Id, Weight: 60, Reps: 10})
+	beaten, err := prs.Detect(first)
+	if err != nil {
+		t.Fatalf("error detecting records: %v", err)
+	}
+	if len(beaten) != 0 {
+		t.Errorf("first set beat records: %#v", beaten)
+	}
+	records, _ := prs.FindByUser(1)
+	if len(records) != 3 {
+		t.Errorf("first set didn't establish records: %#v", records)
+	}
+	//weaker set beats nothing
+	weaker, _ := ess.AddSet(ExerciseSet{UserId: 1, ExerciseId: exercise.Id, Weight: 50, Reps: 5})
+	beaten, _ = prs.Detect(weaker)
+	if len(beaten) != 0 {
+		t.Errorf("weaker set beat records: %#v", beaten)
+	}
+	//heavier set beats max weight and one-rep max
+	heavier, _ := ess.AddSet(ExerciseSet{UserId: 1, ExerciseId: exercise.Id, Weight: 70, Reps: 8})
+	beaten, _ = prs.Detect(heavier)
+	kinds := map[RecordKind]PersonalRecord{}
+	for _, record := range beaten {
+		kinds[record.Kind] = record
+	}
+	if len(beaten) != 2 || kinds[MaxWeight].Value != 70 || kinds[MaxWeight].Previous != 60 ||
+		kinds[BestOneRepMax].Previous != 80 {
+		t.Errorf("heavier set beat wrong records: %#v", beaten)
+	}
+	//more reps at the same weight beats max reps
+	moreReps, _ := ess.AddSet(ExerciseSet{UserId: 1, ExerciseId: exercise.Id, Weight: 60, Reps: 12})
+	beaten, _ = prs.Detect(moreReps)
+	if len(beaten) != 1 || beaten[0].Kind != MaxReps || beaten[0].Weight != 60 || beaten[0].Previous != 10 {
+		t.Errorf("set with more reps beat wrong records: %#v", beaten)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestPRSRebuild(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	ts.StartTraining(1)
+	first, _ := ess.AddSet(ExerciseSet{UserId: 1, ExerciseId: exercise.Id, Duration: 60})
+	prs.Detect(first)
+	second, _ := ess.AddSet(ExerciseSet{UserId: 1, ExerciseId: exercise.Id, Duration: 90})
+	prs.Detect(second)
+	//undoing set deletes its records and restores them from remaining sets, rebuild keeps them
+	ess.UndoSet(1)
+	err = prs.Rebuild(1)
+	if err != nil {
+		t.Fatalf("error rebuilding records: %v", err)
+	}
+	records, err := prs.FindByExercise(1, exercise.Id)
+	if err != nil {
+		t.Fatalf("error finding records: %v", err)
+	}
+	if len(records) != 1 || records[0].Kind != MaxDuration || records[0].Value != 60 || records[0].SetId != first.Id {
+		t.Errorf("got wrong rebuilt records: %#v", records)
+	}
+	t.Cleanup(clearTables)
+}
@@ -0,0 +1,102 @@
+package stores
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// RestTimer struct that is entity for rest_timers table, timer of rest after set, which fires when rest interval
+// of exercise elapses. Rest is stored in seconds, user has at most one pending timer
+type RestTimer struct {
+	Id         int64     `db:"id" json:"id"`
+	UserId     int64     `db:"user_id" json:"user_id"`
+	TrainingId int64     `db:"training_id" json:"training_id"`
+	ExerciseId int64     `db:"exercise_id" json:"exercise_id"`
+	SetId      int64     `db:"set_id" json:"set_id"`
+	Rest       int64     `db:"rest" json:"rest"`
+	FiresAt    time.Time `db:"fires_at" json:"fires_at"`
+}
+
+// restTimerColumns - columns of rest_timers table, with rest converted to seconds
+const restTimerColumns = `id, user_id, training_id, exercise_id, set_id,
+	EXTRACT(EPOCH FROM rest)::bigint AS rest, fires_at`
+
+// RestTimerStore - interface which contains all methods for working with rest_timers table
+type RestTimerStore interface {
+	Schedule(set ExerciseSet) (RestTimer, error)
+	ClaimDue(now time.Time, lease time.Duration) ([]RestTimer, error)
+	Remove(timer RestTimer) error
+}
+
+// RTS - standard realization of RestTimerStore
+type RTS struct {
+	conn *sqlx.DB
+}
+
+// NewRTS - function that creates realization for RestTimerStore interface
+func NewRTS(conn *sqlx.DB) *RTS {
+	return &RTS{
+		conn: conn,
+	}
+}
+
+// Schedule - replaces pending timer of user with timer of set, which fires after rest planned for confirmed set,
+// rest interval of its exercise or default rest of user, the first one that isn't empty. Returns sql.ErrNoRows
+// if all are empty, pending timer is cancelled anyway
+func (rts RTS) Schedule(set ExerciseSet) (RestTimer, error) {
+	var timer RestTimer
+	q := `INSERT INTO rest_timers(user_id, training_id, exercise_id, set_id, rest, fires_at)
+		SELECT $1, $2, r.id, $4, r.rest, $5::timestamptz + r.rest FROM (
+			SELECT e.id, COALESCE(
+				(SELECT NULLIF(p.rest, interval '0') FROM planned_sets p WHERE p.set_id=$4),
+				NULLIF(e.rest, interval '0'),
+				(SELECT s.default_rest FROM user_settings s WHERE s.user_id=$1), interval '0') AS rest
+			FROM exercises e WHERE e.id=$3
+		) r WHERE r.rest > interval '0'
+		ON CONFLICT (user_id) DO UPDATE SET training_id=EXCLUDED.training_id, exercise_id=EXCLUDED.exercise_id,
+			set_id=EXCLUDED.set_id, rest=EXCLUDED.rest, fires_at=EXCLUDED.fires_at, claimed_until=NULL
+		RETURNING ` + restTimerColumns
+	err := rts.conn.Get(&timer, q, set.UserId, set.TrainingId, set.ExerciseId, set.Id, set.CreatedAt)
+	if err != sql.ErrNoRows {
+		return timer, err
+	}
+	_, deleteErr := rts.conn.Exec(`DELETE FROM rest_timers WHERE user_id=$1`, set.UserId)
+	if deleteErr != nil {
+		return timer, deleteErr
+	}
+	return timer, err
+}
+
+// ClaimDue - claims timers of trainings still in progress, which fire not later than now, for lease. Claimed timer
+// isn't returned to other callers until lease expires, so several instances of service don't fire it twice, and it is
+// returned again after lease, if it wasn't removed. Due timers of trainings finished in the meantime are dropped
+func (rts RTS) ClaimDue(now time.Time, lease time.Duration) ([]RestTimer, error) {
+	var timers []RestTimer
+	q := `DELETE FROM rest_timers WHERE fires_at<=$1
+		AND training_id IN (SELECT id FROM trainings WHERE NOT ` + inProgress + `)`
+	_, err := rts.conn.Exec(q, now)
+	if err != nil {
+		return nil, err
+	}
+	//timers locked by concurrent claim are skipped instead of waiting for it
+	q = `WITH claimed AS (
+			UPDATE rest_timers SET claimed_until=$1::timestamptz + make_interval(secs => $2)
+			WHERE id IN (
+				SELECT id FROM rest_timers WHERE fires_at<=$1 AND (claimed_until IS NULL OR claimed_until<=$1)
+				AND training_id IN (SELECT id FROM trainings WHERE ` + inProgress + `)
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + restTimerColumns + `
+		)
+		SELECT * FROM claimed ORDER BY fires_at`
+	err = rts.conn.Select(&timers, q, now, lease.Seconds())
+	return timers, err
+}
+
+// Remove - removes fired timer, timer replaced by the next set in the meantime is kept
+func (rts RTS) Remove(timer RestTimer) error {
+	_, err := rts.conn.Exec(`DELETE FROM rest_timers WHERE id=$1 AND set_id=$2`, timer.Id, timer.SetId)
+	return err
+}
@@ -0,0 +1,190 @@
+package stores
+
+import (
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRTSSchedule(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	ts.StartTraining(exercise.UserId)
+	first, _ := ess.AddSet(ExerciseSet{UserId: exercise.UserId, ExerciseId: exercise.Id, Reps: 10})
+	_, err = rts.Schedule(first)
+	if err != nil {
+		t.Fatalf("error scheduling timer: %v", err)
+	}
+	//next set replaces pending timer
+	second, _ := ess.AddSet(ExerciseSet{UserId: exercise.UserId, ExerciseId: exercise.Id, Reps: 8})
+	timer, err := rts.Schedule(second)
+	if err != nil {
+		t.Fatalf("error scheduling timer: %v", err)
+	}
+	if timer.SetId != second.Id || timer.Rest != exercise.Rest ||
+		!timer.FiresAt.Truncate(time.Second).Equal(second.CreatedAt.Add(90*time.Second).Truncate(time.Second)) {
+		t.Errorf("scheduled wrong timer: %#v", timer)
+	}
+	var count int
+	conn.Get(&count, "SELECT count(*) FROM rest_timers")
+	if count != 1 {
+		t.Errorf("pending timer wasn't replaced, timers: %d", count)
+	}
+	//exercise without rest cancels pending timer
+	conn.Exec("UPDATE exercises SET rest=interval '0' WHERE id=$1", exercise.Id)
+	third, _ := ess.AddSet(ExerciseSet{UserId: exercise.UserId, ExerciseId: exercise.Id, Reps: 6})
+	_, err = rts.Schedule(third)
+	if err != sql.ErrNoRows {
+		t.Errorf("error scheduling timer without rest: %v", err)
+	}
+	conn.Get(&count, "SELECT count(*) FROM rest_timers")
+	if count != 0 {
+		t.Errorf("pending timer wasn't cancelled, timers: %d", count)
+	}
+	//exercise without rest falls back to default rest of user
+	uss := NewUSS(conn)
+	settings := DefaultSettings(exercise.UserId)
+	settings.DefaultRest = 60
+	uss.Save(settings)
+	fourth, _ := ess.AddSet(ExerciseSet{UserId: exercise.UserId, ExerciseId: exercise.Id, Reps: 5})
+	timer, err = rts.Schedule(fourth)
+	if err != nil {
+		t.Fatalf("error scheduling timer with default rest: %v", err)
+	}
+	if timer.Rest != 60 {
+		t.Errorf("scheduled timer without default rest: %#v", timer)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestRTSSchedulePlannedRest(t *testing.T) {
+	template, err := createDefaultTemplate()
+	if err != nil {
+		t.Fatalf("error saving template: %v", err)
+	}
+	ts.StartFromTemplate(template.UserId, template.Id)
+	planned, _ := ess.FindPlanned(template.UserId)
+	conn.Exec("UPDATE planned_sets SET rest=interval '45 seconds' WHERE id=$1", planned[0].Id)
+	set, _ := ess.ConfirmSet(planned[0].Id, ExerciseSet{UserId: template.UserId})
+	timer, err := rts.Schedule(set)
+	if err != nil {
+		t.Fatalf("error scheduling timer: %v", err)
+	}
+	if timer.Rest != 45 {
+		t.Errorf("scheduled timer without planned rest: %#v", timer)
+	}
+	//planned set without rest falls back to rest of exercise
+	conn.Exec("UPDATE planned_sets SET rest=interval '0' WHERE id=$1", planned[1].Id)
+	set, _ = ess.ConfirmSet(planned[1].Id, ExerciseSet{UserId: template.UserId})
+	timer, _ = rts.Schedule(set)
+	if timer.Rest != 90 {
+		t.Errorf("scheduled timer without rest of exercise: %#v", timer)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestRTSClaimDueRemove(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	ts.StartTraining(exercise.UserId)
+	set, _ := ess.AddSet(ExerciseSet{UserId: exercise.UserId, ExerciseId: exercise.Id, Reps: 10})
+	timer, _ := rts.Schedule(set)
+	due, err := rts.ClaimDue(timer.FiresAt.Add(-time.Second), time.Minute)
+	if err != nil || len(due) != 0 {
+		t.Errorf("claimed timer before it fired: %#v, %v", due, err)
+	}
+	due, err = rts.ClaimDue(timer.FiresAt, time.Minute)
+	if err != nil {
+		t.Fatalf("error claiming due timers: %v", err)
+	}
+	if len(due) != 1 || due[0].Id != timer.Id {
+		t.Errorf("claimed wrong timers: %#v", due)
+	}
+	//claimed timer isn't claimed again until lease expires
+	due, _ = rts.ClaimDue(timer.FiresAt.Add(time.Second), time.Minute)
+	if len(due) != 0 {
+		t.Errorf("claimed timer twice: %#v", due)
+	}
+	//timer isn't removed until it is fired
+	due, _ = rts.ClaimDue(timer.FiresAt.Add(time.Minute), time.Minute)
+	if len(due) != 1 {
+		t.Errorf("timer was removed before firing: %#v", due)
+	}
+	//timer replaced by the next set isn't removed, its claim is reset
+	next, _ := ess.AddSet(ExerciseSet{UserId: exercise.UserId, ExerciseId: exercise.Id, Reps: 9})
+	nextTimer, _ := rts.Schedule(next)
+	err = rts.Remove(timer)
+	if err != nil {
+		t.Fatalf("error removing timer: %v", err)
+	}
+	due, _ = rts.ClaimDue(nextTimer.FiresAt, time.Minute)
+	if len(due) != 1 || due[0].SetId != next.Id {
+		t.Errorf("replaced timer was removed: %#v", due)
+	}
+	rts.Remove(nextTimer)
+	due, _ = rts.ClaimDue(nextTimer.FiresAt.Add(time.Hour), time.Minute)
+	if len(due) != 0 {
+		t.Errorf("fired timer wasn't removed: %#v", due)
+	}
+	//timer of finished training is dropped
+	set, _ = ess.AddSet(ExerciseSet{UserId: exercise.UserId, ExerciseId: exercise.Id, Reps: 8})
+	timer, _ = rts.Schedule(set)
+	ts.FinishTraining(exercise.UserId)
+	due, _ = rts.ClaimDue(timer.FiresAt, time.Minute)
+	if len(due) != 0 {
+		t.Errorf("claimed timer of finished training: %#v", due)
+	}
+	var count int
+	conn.Get(&count, "SELECT count(*) FROM rest_timers")
+	if count != 0 {
+		t.Errorf("timer of finished training wasn't dropped, timers: %d", count)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestRTSClaimDueConcurrently(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	var firesAt time.Time
+	for userId := exercise.UserId; userId < exercise.UserId+5; userId++ {
+		ts.StartTraining(userId)
+		set, _ := ess.AddSet(ExerciseSet{UserId: userId, ExerciseId: exercise.Id, Reps: 10})
+		timer, _ := rts.Schedule(set)
+		firesAt = timer.FiresAt
+	}
+	var wg sync.WaitGroup
+	claimed := make(chan RestTimer, 25)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			due, err := rts.ClaimDue(firesAt, time.Minute)
+			if err != nil {
+				t.Errorf("error claiming due timers: %v", err)
+			}
+			for _, timer := range due {
+				claimed <- timer
+			}
+		}()
+	}
+	wg.Wait()
+	close(claimed)
+	ids := map[int64]bool{}
+	for timer := range claimed {
+		if ids[timer.Id] {
+			t.Errorf("timer claimed twice: %#v", timer)
+		}
+		ids[timer.Id] = true
+	}
+	if len(ids) != 5 {
+		t.Errorf("claimed wrong amount of timers: %v", ids)
+	}
+	t.Cleanup(clearTables)
+}
@@ -0,0 +1,32 @@
+package stores
+
+import (
+	"database/sql"
+	"time"
+)
+
+type RestTimerStoreStub struct{}
+
+// Schedule - exercise 2 has no rest interval, others rest for 90 seconds
+func (rtss RestTimerStoreStub) Schedule(set ExerciseSet) (RestTimer, error) {
+	if set.ExerciseId == 2 {
+		return RestTimer{}, sql.ErrNoRows
+	}
+	return RestTimer{
+		Id:         1,
+		UserId:     set.UserId,
+		TrainingId: set.TrainingId,
+		ExerciseId: set.ExerciseId,
+		SetId:      set.Id,
+		Rest:       90,
+		FiresAt:    set.CreatedAt.Add(90 * time.Second),
+	}, nil
+}
+
+func (rtss RestTimerStoreStub) ClaimDue(now time.Time, lease time.Duration) ([]RestTimer, error) {
+	return nil, nil
+}
+
+func (rtss RestTimerStoreStub) Remove(timer RestTimer) error {
+	return nil
+}
@@ -0,0 +1,150 @@
+package stores
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ScheduleSlot struct that is entity for training_schedules table, weekday is short english name (mon, tue, ...)
+// and at is time of day in format HH:MM in timezone of schedule, which is timezone of user settings if schedule has
+// none. RemindedAt and MissedAt are occurrences of slot, which user was already reminded and notified about missing
+type ScheduleSlot struct {
+	Id         int64      `db:"id" json:"id"`
+	UserId     int64      `db:"user_id" json:"-"`
+	Weekday    string     `db:"weekday" json:"weekday"`
+	At         string     `db:"at" json:"at"`
+	Timezone   string     `db:"timezone" json:"-"`
+	CreatedAt  time.Time  `db:"created_at" json:"-"`
+	RemindedAt *time.Time `db:"reminded_at" json:"-"`
+	MissedAt   *time.Time `db:"missed_at" json:"-"`
+}
+
+// Schedule - days and times, when user plans to train, all slots are in the same timezone. Schedule without
+// timezone follows timezone of user settings
+type Schedule struct {
+	UserId   int64          `json:"user_id"`
+	Timezone string         `json:"timezone"`
+	Slots    []ScheduleSlot `json:"slots"`
+}
+
+// scheduleColumns - columns of training_schedules table, with time of day formatted as HH:MM and timezone resolved
+// from user settings, must be selected from scheduleTables
+const scheduleColumns = `s.id, s.user_id, s.weekday, to_char(s.at, 'HH24:MI') AS at,
+	COALESCE(s.timezone, us.timezone, '` + DefaultTimezone + `') AS timezone, s.created_at, s.reminded_at, s.missed_at`
+
+// scheduleTables - training_schedules table joined with user settings
+const scheduleTables = `training_schedules s LEFT JOIN user_settings us ON us.user_id=s.user_id`
+
+// ScheduleStore - interface which contains all methods for working with training_schedules table
+type ScheduleStore interface {
+	Set(schedule Schedule) error
+	FindByUser(userId int64) (Schedule, error)
+	DeleteByUser(userId int64) error
+	FindAll() ([]ScheduleSlot, error)
+	MarkReminded(slotId int64, occurrence time.Time) error
+	MarkMissed(slotId int64, occurrence time.Time) error
+}
+
+// SCS - standard realization of ScheduleStore
+type SCS struct {
+	conn *sqlx.DB
+}
+
+// NewSCS - function that creates realization for ScheduleStore interface
+func NewSCS(conn *sqlx.DB) *SCS {
+	return &SCS{
+		conn: conn,
+	}
+}
+
+// Set - replaces schedule of user, occurrences of new slots before now aren't reminded
+func (scs SCS) Set(schedule Schedule) error {
+	tx, err := scs.conn.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(`DELETE FROM training_schedules WHERE user_id=$1`, schedule.UserId)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	q := `INSERT INTO training_schedules(user_id, weekday, at, timezone, created_at) VALUES($1, $2, $3::time, NULLIF($4, ''), $5)`
+	for _, slot := range schedule.Slots {
+		_, err = tx.Exec(q, schedule.UserId, slot.Weekday, slot.At, schedule.Timezone, now)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// FindByUser - returns schedule of user ordered by time in week, sql.ErrNoRows if user has no schedule
+func (scs SCS) FindByUser(userId int64) (Schedule, error) {
+	schedule := Schedule{UserId: userId}
+	q := `SELECT ` + scheduleColumns + ` FROM ` + scheduleTables + ` WHERE s.user_id=$1
+		ORDER BY array_position(ARRAY['mon','tue','wed','thu','fri','sat','sun']::varchar[], s.weekday), s.at`
+	err := scs.conn.Select(&schedule.Slots, q, userId)
+	if err != nil {
+		return schedule, err
+	}
+	if len(schedule.Slots) == 0 {
+		return schedule, sql.ErrNoRows
+	}
+	schedule.Timezone = schedule.Slots[0].Timezone
+	return schedule, nil
+}
+
+// DeleteByUser - removes schedule of user, returns NotDeleted if user has no schedule
+func (scs SCS) DeleteByUser(userId int64) error {
+	res, err := scs.conn.Exec(`DELETE FROM training_schedules WHERE user_id=$1`, userId)
+	if err != nil {
+		return err
+	}
+	r, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if r == 0 {
+		return NotDeleted
+	}
+	return nil
+}
+
+// FindAll - returns slots of all users
+func (scs SCS) FindAll() ([]ScheduleSlot, error) {
+	var slots []ScheduleSlot
+	err := scs.conn.Select(&slots, `SELECT `+scheduleColumns+` FROM `+scheduleTables+` ORDER BY s.id`)
+	return slots, err
+}
+
+// MarkReminded - remembers, that user was reminded about occurrence of slot, returns NotUpdated
+// if it was already done
+func (scs SCS) MarkReminded(slotId int64, occurrence time.Time) error {
+	q := `UPDATE training_schedules SET reminded_at=$1 WHERE id=$2 AND (reminded_at IS NULL OR reminded_at<$1)`
+	return scs.mark(q, slotId, occurrence)
+}
+
+// MarkMissed - remembers, that user was notified about missed occurrence of slot, returns NotUpdated
+// if it was already done
+func (scs SCS) MarkMissed(slotId int64, occurrence time.Time) error {
+	q := `UPDATE training_schedules SET missed_at=$1 WHERE id=$2 AND (missed_at IS NULL OR missed_at<$1)`
+	return scs.mark(q, slotId, occurrence)
+}
+
+func (scs SCS) mark(q string, slotId int64, occurrence time.Time) error {
+	res, err := scs.conn.Exec(q, occurrence, slotId)
+	if err != nil {
+		return err
+	}
+	r, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if r == 0 {
+		return NotUpdated
+	}
+	return nil
+}
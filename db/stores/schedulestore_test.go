@@ -0,0 +1,116 @@
+package stores
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+var defaultSchedule = Schedule{
+	UserId:   1,
+	Timezone: "Europe/Moscow",
+	Slots: []ScheduleSlot{
+		{Weekday: "fri", At: "18:00"},
+		{Weekday: "mon", At: "18:30"},
+		{Weekday: "mon", At: "07:00"},
+	},
+}
+
+func TestSCSSet(t *testing.T) {
+	_, err := scs.FindByUser(defaultSchedule.UserId)
+	if err != sql.ErrNoRows {
+		t.Errorf("error finding missing schedule: %v", err)
+	}
+	err = scs.Set(defaultSchedule)
+	if err != nil {
+		t.Fatalf("error setting schedule: %v", err)
+	}
+	schedule, err := scs.FindByUser(defaultSchedule.UserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.Timezone != defaultSchedule.Timezone || len(schedule.Slots) != 3 ||
+		schedule.Slots[0].At != "07:00" || schedule.Slots[1].At != "18:30" || schedule.Slots[2].Weekday != "fri" {
+		t.Errorf("found wrong schedule: %#v", schedule)
+	}
+	//setting replaces schedule
+	err = scs.Set(Schedule{UserId: 1, Timezone: "UTC", Slots: []ScheduleSlot{{Weekday: "sun", At: "10:00"}}})
+	if err != nil {
+		t.Fatalf("error replacing schedule: %v", err)
+	}
+	schedule, _ = scs.FindByUser(defaultSchedule.UserId)
+	if schedule.Timezone != "UTC" || len(schedule.Slots) != 1 {
+		t.Errorf("schedule wasn't replaced: %#v", schedule)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestSCSTimezoneOfSettings(t *testing.T) {
+	err := scs.Set(Schedule{UserId: 1, Slots: []ScheduleSlot{{Weekday: "sun", At: "10:00"}}})
+	if err != nil {
+		t.Fatalf("error setting schedule: %v", err)
+	}
+	schedule, _ := scs.FindByUser(1)
+	if schedule.Timezone != DefaultTimezone {
+		t.Errorf("schedule of user without settings isn't in default timezone: %#v", schedule)
+	}
+	//schedule without timezone follows settings
+	settings := DefaultSettings(1)
+	settings.Timezone = "Europe/Moscow"
+	NewUSS(conn).Save(settings)
+	slots, err := scs.FindAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slots) != 1 || slots[0].Timezone != "Europe/Moscow" {
+		t.Errorf("slot didn't follow timezone of settings: %#v", slots)
+	}
+	//own timezone of schedule has priority
+	scs.Set(Schedule{UserId: 1, Timezone: "Asia/Tokyo", Slots: []ScheduleSlot{{Weekday: "sun", At: "10:00"}}})
+	slots, _ = scs.FindAll()
+	if len(slots) != 1 || slots[0].Timezone != "Asia/Tokyo" {
+		t.Errorf("slot isn't in timezone of schedule: %#v", slots)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestSCSDeleteByUser(t *testing.T) {
+	err := scs.DeleteByUser(defaultSchedule.UserId)
+	if err != NotDeleted {
+		t.Errorf("error deleting missing schedule: %v", err)
+	}
+	scs.Set(defaultSchedule)
+	err = scs.DeleteByUser(defaultSchedule.UserId)
+	if err != nil {
+		t.Errorf("error deleting schedule: %v", err)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestSCSMark(t *testing.T) {
+	scs.Set(defaultSchedule)
+	slots, err := scs.FindAll()
+	if err != nil || len(slots) != 3 {
+		t.Fatalf("found wrong slots: %#v %v", slots, err)
+	}
+	occurrence := time.Date(2024, 6, 14, 15, 0, 0, 0, time.UTC)
+	err = scs.MarkReminded(slots[0].Id, occurrence)
+	if err != nil {
+		t.Fatalf("error marking slot reminded: %v", err)
+	}
+	//occurrence is reminded only once
+	err = scs.MarkReminded(slots[0].Id, occurrence)
+	if err != NotUpdated {
+		t.Errorf("error marking slot reminded twice: %v", err)
+	}
+	err = scs.MarkMissed(slots[0].Id, occurrence)
+	if err != nil {
+		t.Errorf("error marking slot missed: %v", err)
+	}
+	slots, _ = scs.FindAll()
+	if slots[0].RemindedAt == nil || !slots[0].RemindedAt.Equal(occurrence) ||
+		slots[0].MissedAt == nil || !slots[0].MissedAt.Equal(occurrence) || slots[1].RemindedAt != nil {
+		t.Errorf("slots were marked wrong: %#v", slots)
+	}
+	t.Cleanup(clearTables)
+}
@@ -0,0 +1,46 @@
+package stores
+
+import (
+	"database/sql"
+	"time"
+)
+
+type ScheduleStoreStub struct{}
+
+func (scss ScheduleStoreStub) Set(schedule Schedule) error {
+	return nil
+}
+
+// FindByUser - user 1 has no schedule
+func (scss ScheduleStoreStub) FindByUser(userId int64) (Schedule, error) {
+	if userId == 1 {
+		return Schedule{UserId: userId}, sql.ErrNoRows
+	}
+	return Schedule{
+		UserId:   userId,
+		Timezone: "Europe/Moscow",
+		Slots: []ScheduleSlot{
+			{Id: 1, UserId: userId, Weekday: "mon", At: "18:00", Timezone: "Europe/Moscow"},
+			{Id: 2, UserId: userId, Weekday: "wed", At: "18:00", Timezone: "Europe/Moscow"},
+		},
+	}, nil
+}
+
+func (scss ScheduleStoreStub) DeleteByUser(userId int64) error {
+	if userId == 1 {
+		return NotDeleted
+	}
+	return nil
+}
+
+func (scss ScheduleStoreStub) FindAll() ([]ScheduleSlot, error) {
+	return nil, nil
+}
+
+func (scss ScheduleStoreStub) MarkReminded(slotId int64, occurrence time.Time) error {
+	return nil
+}
+
+func (scss ScheduleStoreStub) MarkMissed(slotId int64, occurrence time.Time) error {
+	return nil
+}
@@ -0,0 +1,78 @@
+package stores
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// defaults of settings for users, who haven't set them
+const (
+	DefaultTimezone     = "UTC"
+	DefaultWeightUnit   = "kg"
+	DefaultDistanceUnit = "km"
+	DefaultWeekStart    = "mon"
+)
+
+// Settings struct that is entity for user_settings table. Timezone is IANA name of location, default rest
+// is used for exercises without rest in seconds, week start is short name of weekday (mon, sun, ...)
+type Settings struct {
+	UserId       int64  `db:"user_id" json:"user_id"`
+	WeightUnit   string `db:"weight_unit" json:"weight_unit"`
+	DistanceUnit string `db:"distance_unit" json:"distance_unit"`
+	Timezone     string `db:"timezone" json:"timezone"`
+	DefaultRest  int64  `db:"default_rest" json:"default_rest"`
+	WeekStart    string `db:"week_start" json:"week_start"`
+}
+
+// DefaultSettings - settings of user, who hasn't saved any
+func DefaultSettings(userId int64) Settings {
+	return Settings{
+		UserId:       userId,
+		WeightUnit:   DefaultWeightUnit,
+		DistanceUnit: DefaultDistanceUnit,
+		Timezone:     DefaultTimezone,
+		WeekStart:    DefaultWeekStart,
+	}
+}
+
+// SettingsStore - interface which contains all methods for working with user_settings table
+type SettingsStore interface {
+	Find(userId int64) (Settings, error)
+	Save(settings Settings) error
+}
+
+// USS - standard realization of SettingsStore
+type USS struct {
+	conn *sqlx.DB
+}
+
+// NewUSS - function that creates realization for SettingsStore interface
+func NewUSS(conn *sqlx.DB) *USS {
+	return &USS{
+		conn: conn,
+	}
+}
+
+// Find - returns settings of user, default ones if user hasn't saved any
+func (uss USS) Find(userId int64) (Settings, error) {
+	var settings Settings
+	q := `SELECT user_id, weight_unit, distance_unit, timezone, EXTRACT(EPOCH FROM default_rest)::bigint AS default_rest,
+		week_start FROM user_settings WHERE user_id=$1`
+	err := uss.conn.Get(&settings, q, userId)
+	if err == sql.ErrNoRows {
+		return DefaultSettings(userId), nil
+	}
+	return settings, err
+}
+
+// Save - creates or replaces settings of user
+func (uss USS) Save(settings Settings) error {
+	q := `INSERT INTO user_settings(user_id, weight_unit, distance_unit, timezone, default_rest, week_start)
+		VALUES($1, $2, $3, $4, make_interval(secs => $5), $6)
+		ON CONFLICT (user_id) DO UPDATE SET weight_unit=EXCLUDED.weight_unit, distance_unit=EXCLUDED.distance_unit,
+			timezone=EXCLUDED.timezone, default_rest=EXCLUDED.default_rest, week_start=EXCLUDED.week_start`
+	_, err := uss.conn.Exec(q, settings.UserId, settings.WeightUnit, settings.DistanceUnit, settings.Timezone,
+		settings.DefaultRest, settings.WeekStart)
+	return err
+}
@@ -0,0 +1,34 @@
+package stores
+
+import (
+	"testing"
+)
+
+func TestUSSSave(t *testing.T) {
+	uss := NewUSS(conn)
+	settings, err := uss.Find(1)
+	if err != nil || settings != DefaultSettings(1) {
+		t.Errorf("found wrong default settings: %#v %v", settings, err)
+	}
+	err = uss.Save(Settings{UserId: 1, WeightUnit: "kg", DistanceUnit: "km", Timezone: "Europe/Berlin", WeekStart: "mon"})
+	if err != nil {
+		t.Fatalf("error saving settings: %v", err)
+	}
+	expected := Settings{
+		UserId:       1,
+		WeightUnit:   "lb",
+		DistanceUnit: "mi",
+		Timezone:     "Europe/Moscow",
+		DefaultRest:  120,
+		WeekStart:    "sun",
+	}
+	err = uss.Save(expected)
+	if err != nil {
+		t.Fatalf("error replacing settings: %v", err)
+	}
+	settings, err = uss.Find(1)
+	if err != nil || settings != expected {
+		t.Errorf("found wrong settings: %#v %v", settings, err)
+	}
+	t.Cleanup(clearTables)
+}
@@ -0,0 +1,119 @@
+package stores
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Summary - statistics of finished trainings of user in date range, durations are in seconds
+type Summary struct {
+	UserId          int64         `db:"user_id" json:"user_id"`
+	From            time.Time     `db:"from" json:"from"`
+	To              time.Time     `db:"to" json:"to"`
+	Sessions        int64         `db:"sessions" json:"sessions"`
+	TotalDuration   int64         `db:"total_duration" json:"total_duration"`
+	AverageDuration int64         `db:"average_duration" json:"average_duration"`
+	TrainingDays    int64         `db:"training_days" json:"training_days"`
+	DaysPerWeek     float64       `db:"days_per_week" json:"days_per_week"`
+	LongestStreak   int64         `db:"longest_streak" json:"longest_streak"`
+	Cardio          CardioSummary `db:"-" json:"cardio"`
+}
+
+// CardioSummary - totals of sets of cardio exercises in the same trainings as Summary. Sessions are trainings with
+// cardio sets, distance is in kilometers, duration in seconds, elevation in meters. Average heart rate is average of
+// sets, max one is the highest of sets. Pace (seconds per kilometer) and speed (kilometers per hour) are counted
+// over sets with both distance and duration
+type CardioSummary struct {
+	Sessions     int64   `db:"sessions" json:"sessions"`
+	Distance     float64 `db:"distance" json:"distance" unit:"distance"`
+	Duration     int64   `db:"duration" json:"duration"`
+	Elevation    float64 `db:"elevation" json:"elevation"`
+	Calories     int64   `db:"calories" json:"calories"`
+	AvgHeartRate int64   `db:"avg_heart_rate" json:"avg_heart_rate"`
+	MaxHeartRate int64   `db:"max_heart_rate" json:"max_heart_rate"`
+	Pace         float64 `db:"pace" json:"pace" unit:"pace"`
+	Speed        float64 `db:"speed" json:"speed" unit:"distance"`
+}
+
+// StatsStore - interface which contains all methods for computing statistics over trainings table
+type StatsStore interface {
+	Summary(userId int64, from time.Time, to time.Time) (Summary, error)
+}
+
+// STS - standard realization of StatsStore
+type STS struct {
+	conn *sqlx.DB
+}
+
+// NewSTS - function that creates realization for StatsStore interface
+func NewSTS(conn *sqlx.DB) *STS {
+	return &STS{
+		conn: conn,
+	}
+}
+
+// Summary - computes statistics of finished trainings, which began in [from, to). Days are counted in location
+// of from, which must be IANA one. Longest streak is the longest run of consecutive days with trainings
+func (sts STS) Summary(userId int64, from time.Time, to time.Time) (Summary, error) {
+	q := `WITH sessions AS (
+			SELECT ` + trainingColumns + ` FROM trainings t
+			WHERE t.user_id=$2 AND t.status=$3 AND t.begins>=$4 AND t.begins<$5 AND t.deleted_at IS NULL
+		), days AS (
+			SELECT DISTINCT (begins AT TIME ZONE $6)::date AS day FROM sessions
+		), streaks AS (
+			SELECT count(*) AS length FROM (
+				SELECT day - (row_number() OVER (ORDER BY day))::integer AS streak FROM days
+			) d GROUP BY streak
+		)
+		SELECT
+			(SELECT count(*) FROM sessions) AS sessions,
+			COALESCE((SELECT sum(active_duration) FROM sessions), 0) AS total_duration,
+			COALESCE((SELECT avg(active_duration) FROM sessions), 0)::bigint AS average_duration,
+			(SELECT count(*) FROM days) AS training_days,
+			(SELECT count(*) FROM days)::float8 /
+				GREATEST(EXTRACT(EPOCH FROM $5::timestamptz - $4::timestamptz) / 604800, 1) AS days_per_week,
+			COALESCE((SELECT max(length) FROM streaks), 0) AS longest_streak`
+	summary := Summary{
+		UserId: userId,
+		From:   from,
+		To:     to,
+	}
+	err := sts.conn.Get(&summary, q, time.Now(), userId, Finished, from, to, from.Location().String())
+	if err != nil {
+		return summary, err
+	}
+	summary.Cardio, err = sts.cardioSummary(userId, from, to)
+	return summary, err
+}
+
+// cardioSummary - totals of cardio sets of finished trainings, which began in [from, to)
+func (sts STS) cardioSummary(userId int64, from time.Time, to time.Time) (CardioSummary, error) {
+	q := `WITH sets AS (
+			SELECT s.training_id, s.distance, EXTRACT(EPOCH FROM s.duration)::float8 AS duration, s.elevation,
+				s.calories, s.avg_heart_rate, s.max_heart_rate
+			FROM exercise_sets s
+			JOIN trainings t ON t.id=s.training_id
+			JOIN exercises e ON e.id=s.exercise_id
+			JOIN exercise_types et ON et.id=e.exercise_type_id
+			WHERE t.user_id=$1 AND t.status=$2 AND t.begins>=$3 AND t.begins<$4 AND et.name=$5
+				AND s.deleted_at IS NULL
+		), paced AS (
+			SELECT sum(duration) AS duration, sum(distance) AS distance FROM sets
+			WHERE distance IS NOT NULL AND duration IS NOT NULL
+		)
+		SELECT
+			count(DISTINCT training_id) AS sessions,
+			COALESCE(sum(distance), 0) AS distance,
+			COALESCE(sum(duration), 0)::bigint AS duration,
+			COALESCE(sum(elevation), 0)::float8 AS elevation,
+			COALESCE(sum(calories), 0) AS calories,
+			COALESCE(round(avg(avg_heart_rate)), 0)::bigint AS avg_heart_rate,
+			COALESCE(max(max_heart_rate), 0) AS max_heart_rate,
+			COALESCE((SELECT duration / distance FROM paced), 0) AS pace,
+			COALESCE((SELECT distance * 3600 / NULLIF(duration, 0) FROM paced), 0) AS speed
+		FROM sets`
+	var cardio CardioSummary
+	err := sts.conn.Get(&cardio, q, userId, Finished, from, to, Cardio)
+	return cardio, err
+}
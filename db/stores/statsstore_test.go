@@ -0,0 +1,115 @@
+package stores
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSTSSummary(t *testing.T) {
+	sts := NewSTS(conn)
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+	//three consecutive days, then a gap and one more day, the last day has two trainings
+	for _, day := range []int{0, 1, 2, 5, 5} {
+		begins := from.AddDate(0, 0, day).Add(10 * time.Hour)
+		conn.Exec("INSERT INTO trainings(user_id, begins, finish, status) VALUES(1, $1, $2, 'finished')",
+			begins, begins.Add(time.Hour))
+	}
+	//abandoned trainings, trainings of other users and out of range aren't counted
+	conn.Exec("INSERT INTO trainings(user_id, begins, finish, status) VALUES(1, $1, $1, 'abandoned')",
+		from.AddDate(0, 0, 3))
+	conn.Exec("INSERT INTO trainings(user_id, begins, finish, status) VALUES(2, $1, $2, 'finished')",
+		from, from.Add(time.Hour))
+	conn.Exec("INSERT INTO trainings(user_id, begins, finish, status) VALUES(1, $1, $2, 'finished')",
+		to, to.Add(time.Hour))
+	summary, err := sts.Summary(1, from, to)
+	if err != nil {
+		t.Fatalf("error getting summary: %v", err)
+	}
+	if summary.Sessions != 5 || summary.TotalDuration != 5*3600 || summary.AverageDuration != 3600 {
+		t.Errorf("got wrong sessions and durations: %#v", summary)
+	}
+	if summary.TrainingDays != 4 || summary.LongestStreak != 3 || summary.DaysPerWeek != 2 {
+		t.Errorf("got wrong days and streak: %#v", summary)
+	}
+	//empty range
+	summary, err = sts.Summary(3, from, to)
+	if err != nil {
+		t.Fatalf("error getting empty summary: %v", err)
+	}
+	if summary.Sessions != 0 || summary.LongestStreak != 0 || summary.DaysPerWeek != 0 {
+		t.Errorf("got non-empty summary: %#v", summary)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestSTSSummaryInLocation(t *testing.T) {
+	sts := NewSTS(conn)
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	//clocks moved forward at 2024-03-31 02:00, so days of trainings differ from days in UTC
+	from := time.Date(2024, 3, 30, 0, 0, 0, 0, berlin)
+	to := time.Date(2024, 4, 2, 0, 0, 0, 0, berlin)
+	for _, begins := range []time.Time{
+		time.Date(2024, 3, 29, 23, 30, 0, 0, time.UTC), //00:30 of 30th in Berlin
+		time.Date(2024, 3, 30, 23, 30, 0, 0, time.UTC), //00:30 of 31st in Berlin
+		time.Date(2024, 3, 31, 22, 30, 0, 0, time.UTC), //00:30 of 1st in Berlin after transition
+		time.Date(2024, 3, 29, 22, 30, 0, 0, time.UTC), //23:30 of 29th in Berlin, out of range
+	} {
+		conn.Exec("INSERT INTO trainings(user_id, begins, finish, status) VALUES(1, $1, $2, 'finished')",
+			begins, begins.Add(time.Hour))
+	}
+	summary, err := sts.Summary(1, from, to)
+	if err != nil {
+		t.Fatalf("error getting summary: %v", err)
+	}
+	if summary.Sessions != 3 || summary.TrainingDays != 3 || summary.LongestStreak != 3 {
+		t.Errorf("got wrong summary in location: %#v", summary)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestSTSSummaryCardio(t *testing.T) {
+	sts := NewSTS(conn)
+	workout, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	run := workout
+	run.Name = "Run"
+	run.ExerciseTypeId = 1
+	run.Id, _ = ex.Save(run)
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	var trainingId int64
+	conn.Get(&trainingId, "INSERT INTO trainings(user_id, begins, finish, status) VALUES($1, $2, $3, 'finished') RETURNING id",
+		run.UserId, from.Add(10*time.Hour), from.Add(11*time.Hour))
+	insert := `INSERT INTO exercise_sets(user_id, exercise_id, training_id, reps, duration, distance, avg_heart_rate,
+		max_heart_rate, calories) VALUES($1, $2, $3, NULLIF($4::integer, 0), make_interval(secs => $5),
+		NULLIF($6::float8, 0), NULLIF($7::integer, 0), NULLIF($8::integer, 0), NULLIF($9::integer, 0))`
+	//5 km in 25 minutes and 1 km in 5 minutes, treadmill warm-up without distance isn't counted in pace
+	conn.Exec(insert, run.UserId, run.Id, trainingId, 0, 1500, 5.0, 150, 172, 400)
+	conn.Exec(insert, run.UserId, run.Id, trainingId, 0, 300, 1.0, 160, 180, 100)
+	conn.Exec(insert, run.UserId, run.Id, trainingId, 0, 600, 0.0, 0, 0, 0)
+	//sets of other types aren't counted
+	conn.Exec(insert, run.UserId, workout.Id, trainingId, 20, 0, 0.0, 140, 190, 0)
+	summary, err := sts.Summary(run.UserId, from, to)
+	if err != nil {
+		t.Fatalf("error getting summary: %v", err)
+	}
+	expected := CardioSummary{
+		Sessions:     1,
+		Distance:     6,
+		Duration:     2400,
+		Calories:     500,
+		AvgHeartRate: 155,
+		MaxHeartRate: 180,
+		Pace:         300,
+		Speed:        12,
+	}
+	if diff := cmp.Diff(expected, summary.Cardio); diff != "" {
+		t.Errorf("got wrong cardio summary: %s", diff)
+	}
+	t.Cleanup(clearTables)
+}
@@ -0,0 +1,36 @@
+package stores
+
+import (
+	"time"
+)
+
+type StatsStoreStub struct{}
+
+func (stss StatsStoreStub) Summary(userId int64, from time.Time, to time.Time) (Summary, error) {
+	summary := Summary{
+		UserId: userId,
+		From:   from,
+		To:     to,
+	}
+	if userId == 1 {
+		return summary, nil
+	}
+	summary.Sessions = 12
+	summary.TotalDuration = 43200
+	summary.AverageDuration = 3600
+	summary.TrainingDays = 12
+	summary.DaysPerWeek = 2.8
+	summary.LongestStreak = 3
+	summary.Cardio = CardioSummary{
+		Sessions:     4,
+		Distance:     20,
+		Duration:     6000,
+		Elevation:    120,
+		Calories:     1400,
+		AvgHeartRate: 148,
+		MaxHeartRate: 176,
+		Pace:         300,
+		Speed:        12,
+	}
+	return summary, nil
+}
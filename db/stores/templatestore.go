@@ -0,0 +1,202 @@
+package stores
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/fridrock/trainingservice/errkind"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	TemplateInProgram = errkind.New(errkind.Conflict, "template is used by program")
+)
+
+// TemplateExercise struct that is entity for template_exercises table, planned exercise of workout template.
+// Exercises are ordered by position, rest is stored in seconds
+type TemplateExercise struct {
+	Id         int64   `db:"id" json:"id"`
+	TemplateId int64   `db:"template_id" json:"template_id"`
+	Position   int64   `db:"position" json:"position"`
+	ExerciseId int64   `db:"exercise_id" json:"exercise_id"`
+	Sets       int64   `db:"sets" json:"sets"`
+	Reps       int64   `db:"reps" json:"reps"`
+	Weight     float64 `db:"weight" json:"weight" unit:"weight"`
+	Rest       int64   `db:"rest" json:"rest"`
+}
+
+// Template struct that is entity for workout_templates table
+type Template struct {
+	Id        int64              `db:"id" json:"id"`
+	UserId    int64              `db:"user_id" json:"user_id"`
+	Name      string             `db:"name" json:"name"`
+	Exercises []TemplateExercise `db:"-" json:"exercises"`
+}
+
+// TemplateStore - interface which contains all methods for working with workout_templates table
+type TemplateStore interface {
+	Save(Template) (int64, error)
+	FindById(userId int64, templateId int64) (Template, error)
+	FindByUser(userId int64) ([]Template, error)
+	Update(Template) error
+	DeleteById(userId int64, templateId int64) error
+}
+
+// templateExerciseColumns - columns of template_exercises table, with rest converted to seconds
+const templateExerciseColumns = `te.id, te.template_id, te.position, te.exercise_id, te.sets, te.reps, te.weight,
+	EXTRACT(EPOCH FROM te.rest)::bigint AS rest`
+
+// templateExerciseTables - template_exercises table joined with exercises, which aren't deleted. Deleted exercises
+// stay in template, so they are back after restoring, but template is used without them
+const templateExerciseTables = `template_exercises te JOIN exercises e ON e.id=te.exercise_id AND e.deleted_at IS NULL`
+
+// TPS - standard realization of TemplateStore
+type TPS struct {
+	conn *sqlx.DB
+}
+
+// NewTPS - function that creates realization for TemplateStore interface
+func NewTPS(conn *sqlx.DB) *TPS {
+	return &TPS{
+		conn: conn,
+	}
+}
+
+// Save - saves template with its exercises, positions of exercises are their order in template
+func (tps TPS) Save(template Template) (int64, error) {
+	tx, err := tps.conn.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var templateId int64
+	q := `INSERT INTO workout_templates(user_id, name) VALUES($1, $2) RETURNING id`
+	err = tx.Get(&templateId, q, template.UserId, template.Name)
+	if err != nil {
+		return 0, err
+	}
+	err = saveTemplateExercises(tx, templateId, template)
+	if err != nil {
+		return 0, err
+	}
+	return templateId, tx.Commit()
+}
+
+// saveTemplateExercises - inserts exercises of template, returns wrapped sql.ErrNoRows if exercise doesn't belong to user
+func saveTemplateExercises(tx *sqlx.Tx, templateId int64, template Template) error {
+	q := `INSERT INTO template_exercises(template_id, position, exercise_id, sets, reps, weight, rest)
+		SELECT $1, $2, e.id, $3, $4, $5, make_interval(secs => $6) FROM exercises e
+		WHERE e.id=$7 AND e.user_id=$8 AND e.deleted_at IS NULL`
+	for i, exercise := range template.Exercises {
+		res, err := tx.Exec(q, templateId, i+1, exercise.Sets, exercise.Reps, exercise.Weight, exercise.Rest,
+			exercise.ExerciseId, template.UserId)
+		if err != nil {
+			return err
+		}
+		r, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if r == 0 {
+			return fmt.Errorf("exercise %d: %w", exercise.ExerciseId, sql.ErrNoRows)
+		}
+	}
+	return nil
+}
+
+func (tps TPS) FindById(userId int64, templateId int64) (Template, error) {
+	var template Template
+	q := `SELECT id, user_id, name FROM workout_templates WHERE id=$1 AND user_id=$2`
+	err := tps.conn.Get(&template, q, templateId, userId)
+	if err != nil {
+		return template, err
+	}
+	q = `SELECT ` + templateExerciseColumns + ` FROM ` + templateExerciseTables + `
+		WHERE te.template_id=$1 ORDER BY te.position`
+	err = tps.conn.Select(&template.Exercises, q, templateId)
+	return template, err
+}
+
+func (tps TPS) FindByUser(userId int64) ([]Template, error) {
+	var templates []Template
+	q := `SELECT id, user_id, name FROM workout_templates WHERE user_id=$1 ORDER BY id`
+	err := tps.conn.Select(&templates, q, userId)
+	if err != nil {
+		return templates, err
+	}
+	var exercises []TemplateExercise
+	q = `SELECT ` + templateExerciseColumns + ` FROM ` + templateExerciseTables + `
+		JOIN workout_templates w ON w.id=te.template_id
+		WHERE w.user_id=$1 ORDER BY te.template_id, te.position`
+	err = tps.conn.Select(&exercises, q, userId)
+	if err != nil {
+		return templates, err
+	}
+	indexes := make(map[int64]int, len(templates))
+	for i, template := range templates {
+		indexes[template.Id] = i
+	}
+	for _, exercise := range exercises {
+		i := indexes[exercise.TemplateId]
+		templates[i].Exercises = append(templates[i].Exercises, exercise)
+	}
+	return templates, nil
+}
+
+// Update - renames template and replaces its exercises
+func (tps TPS) Update(updated Template) error {
+	tx, err := tps.conn.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := `UPDATE workout_templates SET name=$1 WHERE id=$2 AND user_id=$3`
+	res, err := tx.Exec(q, updated.Name, updated.Id, updated.UserId)
+	if err != nil {
+		return err
+	}
+	r, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if r == 0 {
+		return NotUpdated
+	}
+	_, err = tx.Exec(`DELETE FROM template_exercises WHERE template_id=$1`, updated.Id)
+	if err != nil {
+		return err
+	}
+	err = saveTemplateExercises(tx, updated.Id, updated)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteById - deletes template with its exercises, returns NotDeleted if user has no such template and
+// TemplateInProgram if template is day of some program
+func (tps TPS) DeleteById(userId int64, templateId int64) error {
+	tx, err := tps.conn.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var used bool
+	q := `SELECT EXISTS (SELECT 1 FROM program_days d WHERE d.template_id=w.id) FROM workout_templates w
+		WHERE w.id=$1 AND w.user_id=$2 FOR UPDATE`
+	err = tx.Get(&used, q, templateId, userId)
+	if err == sql.ErrNoRows {
+		return NotDeleted
+	}
+	if err != nil {
+		return err
+	}
+	if used {
+		return TemplateInProgram
+	}
+	_, err = tx.Exec(`DELETE FROM workout_templates WHERE id=$1`, templateId)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,246 @@
+package stores
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func createDefaultTemplate() (Template, error) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		return Template{}, err
+	}
+	template := Template{
+		UserId: exercise.UserId,
+		Name:   "Push day",
+		Exercises: []TemplateExercise{
+			{ExerciseId: exercise.Id, Sets: 3, Reps: 10, Weight: 60, Rest: 90},
+			{ExerciseId: exercise.Id, Sets: 2, Reps: 15, Rest: 60},
+		},
+	}
+	template.Id, err = tps.Save(template)
+	return template, err
+}
+
+func TestTPSSaveFindById(t *testing.T) {
+	template, err := createDefaultTemplate()
+	if err != nil {
+		t.Fatalf("error saving template: %v", err)
+	}
+	found, err := tps.FindById(template.UserId, template.Id)
+	if err != nil {
+		t.Fatalf("error finding template: %v", err)
+	}
+	for i := range template.Exercises {
+		template.Exercises[i].Id = found.Exercises[i].Id
+		template.Exercises[i].TemplateId = template.Id
+		template.Exercises[i].Position = int64(i + 1)
+	}
+	if diff := cmp.Diff(template, found); diff != "" {
+		t.Errorf("got wrong template: %s", diff)
+	}
+	//templates of other users aren't found
+	_, err = tps.FindById(template.UserId+1, template.Id)
+	if err != sql.ErrNoRows {
+		t.Errorf("found template of other user: %v", err)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestTPSSaveForeignExercise(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	_, err = tps.Save(Template{
+		UserId:    exercise.UserId + 1,
+		Name:      "Push day",
+		Exercises: []TemplateExercise{{ExerciseId: exercise.Id, Sets: 3}},
+	})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("saved template with exercise of other user: %v", err)
+	}
+	templates, _ := tps.FindByUser(exercise.UserId + 1)
+	if len(templates) != 0 {
+		t.Errorf("template was saved partially: %#v", templates)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestTPSUpdateDelete(t *testing.T) {
+	template, err := createDefaultTemplate()
+	if err != nil {
+		t.Fatalf("error saving template: %v", err)
+	}
+	template.Name = "Pull day"
+	template.Exercises = template.Exercises[1:]
+	err = tps.Update(template)
+	if err != nil {
+		t.Fatalf("error updating template: %v", err)
+	}
+	templates, err := tps.FindByUser(template.UserId)
+	if err != nil {
+		t.Fatalf("error finding templates: %v", err)
+	}
+	if len(templates) != 1 || templates[0].Name != "Pull day" || len(templates[0].Exercises) != 1 ||
+		templates[0].Exercises[0].Reps != 15 || templates[0].Exercises[0].Position != 1 {
+		t.Errorf("got wrong updated template: %#v", templates)
+	}
+	//negative cases
+	err = tps.Update(Template{Id: template.Id + 1, UserId: template.UserId, Name: "Leg day"})
+	if err != NotUpdated {
+		t.Errorf("updated unexisting template: %v", err)
+	}
+	err = tps.DeleteById(template.UserId+1, template.Id)
+	if err != NotDeleted {
+		t.Errorf("deleted template of other user: %v", err)
+	}
+	//positive case
+	err = tps.DeleteById(template.UserId, template.Id)
+	if err != nil {
+		t.Errorf("error deleting template: %v", err)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestTPSDeleteTemplateOfProgram(t *testing.T) {
+	program, err := createDefaultProgram()
+	if err != nil {
+		t.Fatalf("error saving program: %v", err)
+	}
+	templateId := program.Days[0].TemplateId
+	err = tps.DeleteById(program.UserId, templateId)
+	if err != TemplateInProgram {
+		t.Errorf("deleted template of program: %v", err)
+	}
+	_, err = tps.FindById(program.UserId, templateId)
+	if err != nil {
+		t.Errorf("error finding template after failed deletion: %v", err)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestTPSDeletedExercise(t *testing.T) {
+	template, err := createDefaultTemplate()
+	if err != nil {
+		t.Fatalf("error saving template: %v", err)
+	}
+	exerciseId := template.Exercises[0].ExerciseId
+	ex.DeleteById(exerciseId)
+	found, err := tps.FindById(template.UserId, template.Id)
+	if err != nil || len(found.Exercises) != 0 {
+		t.Errorf("found deleted exercises of template: %#v, %v", found, err)
+	}
+	templates, _ := tps.FindByUser(template.UserId)
+	if len(templates) != 1 || len(templates[0].Exercises) != 0 {
+		t.Errorf("found deleted exercises of templates: %#v", templates)
+	}
+	ts.StartFromTemplate(template.UserId, template.Id)
+	planned, _ := ess.FindPlanned(template.UserId)
+	if len(planned) != 0 {
+		t.Errorf("planned sets of deleted exercise: %#v", planned)
+	}
+	//restored exercise is back in template
+	trs.Restore(template.UserId, TrashExercise, exerciseId)
+	found, _ = tps.FindById(template.UserId, template.Id)
+	if len(found.Exercises) != 2 {
+		t.Errorf("restored exercise isn't back in template: %#v", found)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestTSStartFromTemplate(t *testing.T) {
+	template, err := createDefaultTemplate()
+	if err != nil {
+		t.Fatalf("error saving template: %v", err)
+	}
+	_, err = ts.StartFromTemplate(template.UserId, template.Id+1)
+	if err != sql.ErrNoRows {
+		t.Errorf("started training from unexisting template: %v", err)
+	}
+	trainingId, err := ts.StartFromTemplate(template.UserId, template.Id)
+	if err != nil {
+		t.Fatalf("error starting training from template: %v", err)
+	}
+	_, err = ts.StartFromTemplate(template.UserId, template.Id)
+	if err != TrainingInProgress {
+		t.Errorf("started second training from template: %v", err)
+	}
+	planned, err := ess.FindPlanned(template.UserId)
+	if err != nil {
+		t.Fatalf("error finding planned sets: %v", err)
+	}
+	if len(planned) != 5 || planned[0].TrainingId != trainingId || planned[0].Weight != 60 ||
+		planned[2].SetNumber != 3 || planned[3].Position != 2 || planned[3].Rest != 60 {
+		t.Errorf("got wrong planned sets: %#v", planned)
+	}
+	found, err := ess.FindPlannedById(template.UserId, planned[3].Id)
+	if err != nil || found != planned[3] {
+		t.Errorf("found wrong planned set: %#v, error: %v", found, err)
+	}
+	_, err = ess.FindPlannedById(template.UserId+1, planned[3].Id)
+	if err != sql.ErrNoRows {
+		t.Errorf("found planned set of other user: %v", err)
+	}
+	//confirming planned set with edited reps
+	set, err := ess.ConfirmSet(planned[0].Id, ExerciseSet{UserId: template.UserId, Reps: 8})
+	if err != nil {
+		t.Fatalf("error confirming set: %v", err)
+	}
+	if set.TrainingId != trainingId || set.Weight != 60 || set.Reps != 8 {
+		t.Errorf("got wrong confirmed set: %#v", set)
+	}
+	_, err = ess.ConfirmSet(planned[0].Id, ExerciseSet{UserId: template.UserId})
+	if err != NotUpdated {
+		t.Errorf("confirmed set twice: %v", err)
+	}
+	planned, _ = ess.FindPlanned(template.UserId)
+	if planned[0].SetId != set.Id {
+		t.Errorf("planned set isn't confirmed: %#v", planned[0])
+	}
+	//undoing confirmed set makes planned one unconfirmed again
+	ess.UndoSet(template.UserId)
+	planned, _ = ess.FindPlanned(template.UserId)
+	if planned[0].SetId != 0 {
+		t.Errorf("planned set stays confirmed after undo: %#v", planned[0])
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestESSConfirmSetConcurrently(t *testing.T) {
+	template, err := createDefaultTemplate()
+	if err != nil {
+		t.Fatalf("error saving template: %v", err)
+	}
+	ts.StartFromTemplate(template.UserId, template.Id)
+	planned, _ := ess.FindPlanned(template.UserId)
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := ess.ConfirmSet(planned[0].Id, ExerciseSet{UserId: template.UserId})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	confirmed := 0
+	for err := range errs {
+		if err == nil {
+			confirmed++
+		} else if err != NotUpdated {
+			t.Errorf("error confirming set: %v", err)
+		}
+	}
+	sets, _ := ess.FindCurrent(template.UserId)
+	if confirmed != 1 || len(sets) != 1 {
+		t.Errorf("planned set confirmed %d times, added sets: %#v", confirmed, sets)
+	}
+	t.Cleanup(clearTables)
+}
@@ -0,0 +1,64 @@
+package stores
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+type TemplateStoreStub struct{}
+
+func (tpss TemplateStoreStub) Save(template Template) (int64, error) {
+	if template.Name == "Internal" {
+		return 0, driver.ErrBadConn
+	}
+	return 1, nil
+}
+
+func (tpss TemplateStoreStub) FindById(userId int64, templateId int64) (Template, error) {
+	if templateId != 1 {
+		return Template{}, sql.ErrNoRows
+	}
+	return Template{
+		Id:     1,
+		UserId: userId,
+		Name:   "Push day",
+		Exercises: []TemplateExercise{
+			{
+				Id:         1,
+				TemplateId: 1,
+				Position:   1,
+				ExerciseId: 1,
+				Sets:       3,
+				Reps:       10,
+				Weight:     60,
+				Rest:       90,
+			},
+		},
+	}, nil
+}
+
+func (tpss TemplateStoreStub) FindByUser(userId int64) ([]Template, error) {
+	if userId == 1 {
+		return nil, sql.ErrNoRows
+	}
+	template, err := tpss.FindById(userId, 1)
+	return []Template{template}, err
+}
+
+func (tpss TemplateStoreStub) Update(template Template) error {
+	if template.Id != 1 {
+		return NotUpdated
+	}
+	return nil
+}
+
+// DeleteById - template 3 is used by program
+func (tpss TemplateStoreStub) DeleteById(userId int64, templateId int64) error {
+	if templateId == 3 {
+		return TemplateInProgram
+	}
+	if templateId != 1 {
+		return NotDeleted
+	}
+	return nil
+}
@@ -1,29 +1,68 @@
 package stores
 
 import (
+	"database/sql"
 	"errors"
 	"time"
 
+	"github.com/fridrock/trainingservice/errkind"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
+// TrainingStatus - state of training, open and paused trainings are in progress
+type TrainingStatus string
+
+const (
+	Open      TrainingStatus = "open"
+	Paused    TrainingStatus = "paused"
+	Finished  TrainingStatus = "finished"
+	Abandoned TrainingStatus = "abandoned"
+)
+
+// inProgress - condition on trainings table, that matches the only in progress training of user
+const inProgress = `status IN ('open', 'paused')`
+
+// uniqueViolation - postgres error code of unique constraint violation
+const uniqueViolation = "23505"
+
+// Training struct that is entity for trainings table, finish is nil until training is finished,
+// active duration is time in seconds without pauses
 type Training struct {
-	Id     int64     `db:"id" json:"id"`
-	UserId int64     `db:"user_id" json:"user_id"`
-	Begins time.Time `db:"begins" json:"begins"`
-	Finish time.Time `db:"finish" json:"finish"`
+	Id             int64          `db:"id" json:"id"`
+	UserId         int64          `db:"user_id" json:"user_id"`
+	Begins         time.Time      `db:"begins" json:"begins"`
+	Finish         *time.Time     `db:"finish" json:"finish"`
+	Status         TrainingStatus `db:"status" json:"status"`
+	ActiveDuration int64          `db:"active_duration" json:"active_duration"`
 }
 
+// trainingColumns - columns of trainings table with active duration, $1 must be current time. Only trainings
+// in progress last until now, closed trainings without finish last zero time
+const trainingColumns = `t.id, t.user_id, t.begins, t.finish, t.status,
+	EXTRACT(EPOCH FROM COALESCE(t.finish, CASE WHEN t.` + inProgress + ` THEN $1 ELSE t.begins END) - t.begins - COALESCE(
+		(SELECT SUM(COALESCE(p.finish, t.finish, CASE WHEN t.` + inProgress + ` THEN $1 ELSE p.begins END) - p.begins)
+			FROM training_pauses p WHERE p.training_id=t.id),
+		interval '0'))::bigint AS active_duration`
+
 type TrainingStore interface {
 	StartTraining(userId int64) (int64, error)
+	StartFromTemplate(userId int64, templateId int64) (int64, error)
 	FinishTraining(userId int64) error
+	PauseTraining(userId int64) error
+	ResumeTraining(userId int64) error
 	FindById(trainingId int64) (Training, error)
 	GetLastTraining(userId int64) (Training, error)
 	GetTrainings(userId int64) ([]Training, error)
+	CloseStale(maxDuration time.Duration) ([]Training, error)
+	DeleteTraining(userId int64, trainingId int64) error
 }
 
 var (
-	AllTrainingsFinished = errors.New("Empty non-finished trainings list")
+	AllTrainingsFinished = errkind.New(errkind.Conflict, "Empty non-finished trainings list")
+	TrainingInProgress   = errkind.New(errkind.Conflict, "training is already in progress")
+	TrainingNotOpen      = errkind.New(errkind.Conflict, "no open training to pause")
+	TrainingNotPaused    = errkind.New(errkind.Conflict, "no paused training to resume")
 )
 
 type TS struct {
@@ -36,19 +75,83 @@ func NewTs(conn *sqlx.DB) *TS {
 	}
 }
 
-func (ts TS) StartTraining(userId int64) (id int64, err error) {
+// StartTraining - opens new training, returns TrainingInProgress if user already has one
+func (ts TS) StartTraining(userId int64) (int64, error) {
+	return startTraining(ts.conn, userId)
+}
+
+// startTraining - opens new training with q, which is either connection or transaction
+func startTraining(q sqlx.Queryer, userId int64) (id int64, err error) {
 	training := Training{
 		UserId: userId,
 		Begins: time.Now(),
+		Status: Open,
+	}
+	query := `INSERT INTO trainings(user_id, begins, status) SELECT $1, $2, $3
+		WHERE NOT EXISTS (SELECT 1 FROM trainings WHERE user_id=$1 AND ` + inProgress + `) RETURNING id`
+	err = sqlx.Get(q, &id, query, training.UserId, training.Begins, training.Status)
+	var pqErr *pq.Error
+	if err == sql.ErrNoRows || (errors.As(err, &pqErr) && pqErr.Code == uniqueViolation) {
+		return 0, TrainingInProgress
 	}
-	q := "INSERT INTO trainings(user_id, begins, finish) VALUES ($1, $2, $2) RETURNING id"
-	err = ts.conn.Get(&id, q, training.UserId, training.Begins)
 	return id, err
 }
 
+// StartFromTemplate - opens new training with planned sets of template, deleted exercises of template aren't
+// planned. Returns sql.ErrNoRows if user has no such template and TrainingInProgress if user already has training
+// in progress
+func (ts TS) StartFromTemplate(userId int64, templateId int64) (int64, error) {
+	tx, err := ts.conn.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	var exists bool
+	err = tx.Get(&exists, `SELECT true FROM workout_templates WHERE id=$1 AND user_id=$2`, templateId, userId)
+	if err != nil {
+		return 0, err
+	}
+	trainingId, err := startTraining(tx, userId)
+	if err != nil {
+		return 0, err
+	}
+	q := `INSERT INTO planned_sets(training_id, position, exercise_id, set_number, reps, weight, rest)
+		SELECT $1, te.position, te.exercise_id, n, te.reps, te.weight, te.rest
+		FROM ` + templateExerciseTables + `, generate_series(1, te.sets) n
+		WHERE te.template_id=$2 ORDER BY te.position, n`
+	_, err = tx.Exec(q, trainingId, templateId)
+	if err != nil {
+		return 0, err
+	}
+	return trainingId, tx.Commit()
+}
+
+// FinishTraining - finishes training in progress and its pause, returns AllTrainingsFinished if user has no such
 func (ts TS) FinishTraining(userId int64) error {
-	q := "UPDATE trainings SET finish=$1 WHERE finish=begins AND user_id=$2"
-	res, err := ts.conn.Exec(q, time.Now(), userId)
+	q := `WITH finished AS (
+			UPDATE trainings SET finish=$1, status=$2 WHERE user_id=$3 AND ` + inProgress + ` RETURNING id
+		), closed AS (
+			UPDATE training_pauses SET finish=$1 WHERE finish IS NULL AND training_id IN (SELECT id FROM finished)
+		)
+		SELECT count(*) FROM finished`
+	var r int64
+	err := ts.conn.Get(&r, q, time.Now(), Finished, userId)
+	if err != nil {
+		return err
+	}
+	if r == 0 {
+		return AllTrainingsFinished
+	}
+	return nil
+}
+
+// PauseTraining - pauses open training, returns TrainingNotOpen if user has no such
+func (ts TS) PauseTraining(userId int64) error {
+	q := `WITH paused AS (
+			UPDATE trainings SET status=$2 WHERE user_id=$3 AND status=$4 RETURNING id
+		)
+		INSERT INTO training_pauses(training_id, begins) SELECT id, $1 FROM paused`
+	res, err := ts.conn.Exec(q, time.Now(), Paused, userId, Open)
 	if err != nil {
 		return err
 	}
@@ -57,28 +160,116 @@ func (ts TS) FinishTraining(userId int64) error {
 		return err
 	}
 	if r == 0 {
-		return AllTrainingsFinished
+		return TrainingNotOpen
+	}
+	return nil
+}
+
+// ResumeTraining - resumes paused training, returns TrainingNotPaused if user has no such
+func (ts TS) ResumeTraining(userId int64) error {
+	q := `WITH resumed AS (
+			UPDATE trainings SET status=$2 WHERE user_id=$3 AND status=$4 RETURNING id
+		)
+		UPDATE training_pauses SET finish=$1 WHERE finish IS NULL AND training_id IN (SELECT id FROM resumed)`
+	res, err := ts.conn.Exec(q, time.Now(), Open, userId, Paused)
+	if err != nil {
+		return err
+	}
+	r, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if r == 0 {
+		return TrainingNotPaused
 	}
 	return nil
 }
 
 func (ts TS) FindById(trainingId int64) (Training, error) {
-	q := "SELECT * FROM trainings WHERE id=$1"
+	q := "SELECT " + trainingColumns + " FROM trainings t WHERE t.id=$2 AND t.deleted_at IS NULL"
 	var training Training
-	err := ts.conn.Get(&training, q, trainingId)
+	err := ts.conn.Get(&training, q, time.Now(), trainingId)
 	return training, err
 }
 
 func (ts TS) GetLastTraining(userId int64) (Training, error) {
-	q := "SELECT * FROM trainings WHERE user_id=$1 ORDER BY begins DESC LIMIT 1;"
+	q := "SELECT " + trainingColumns + ` FROM trainings t WHERE t.user_id=$2 AND t.deleted_at IS NULL
+		ORDER BY t.begins DESC LIMIT 1;`
 	var training Training
-	err := ts.conn.Get(&training, q, userId)
+	err := ts.conn.Get(&training, q, time.Now(), userId)
 	return training, err
 }
 
 func (ts TS) GetTrainings(userId int64) ([]Training, error) {
 	var trainings []Training
-	q := "SELECT * FROM trainings WHERE user_id=$1"
-	err := ts.conn.Select(&trainings, q, userId)
+	q := "SELECT " + trainingColumns + " FROM trainings t WHERE t.user_id=$2 AND t.deleted_at IS NULL"
+	err := ts.conn.Select(&trainings, q, time.Now(), userId)
+	return trainings, err
+}
+
+// CloseStale - closes trainings in progress without activity for longer than maxDuration. Start, logged sets,
+// pauses and resumes are activity, so time of finished pauses doesn't make training stale. Trainings with logged sets
+// are finished at the time of the last set, others are abandoned with zero duration. Returns closed trainings
+func (ts TS) CloseStale(maxDuration time.Duration) ([]Training, error) {
+	now := time.Now()
+	q := `WITH activity AS (
+			SELECT t.id, t.begins, (SELECT max(s.created_at) FROM exercise_sets s
+				WHERE s.training_id=t.id AND s.deleted_at IS NULL) AS last_set,
+				(SELECT max(GREATEST(p.begins, p.finish)) FROM training_pauses p
+				WHERE p.training_id=t.id) AS last_pause
+			FROM trainings t WHERE t.` + inProgress + `
+		), stale AS (
+			SELECT id, last_set FROM activity
+			WHERE GREATEST(begins, last_set, last_pause)<$1
+		), closed AS (
+			UPDATE trainings t SET
+				status=CASE WHEN stale.last_set IS NULL THEN $2 ELSE $3 END,
+				finish=COALESCE(stale.last_set, t.begins)
+			FROM stale WHERE t.id=stale.id
+			RETURNING t.id, t.finish
+		), pauses AS (
+			UPDATE training_pauses p SET finish=GREATEST(p.begins, closed.finish)
+			FROM closed WHERE p.training_id=closed.id AND p.finish IS NULL
+		)
+		SELECT id FROM closed`
+	var ids []int64
+	err := ts.conn.Select(&ids, q, now.Add(-maxDuration), Abandoned, Finished)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	var trainings []Training
+	q = "SELECT " + trainingColumns + " FROM trainings t WHERE t.id=ANY($2)"
+	err = ts.conn.Select(&trainings, q, now, pq.Array(ids))
 	return trainings, err
 }
+
+// DeleteTraining - soft-deletes training of user together with its sets. Returns NotDeleted if user has no such
+// training and TrainingInProgress if training isn't finished yet
+func (ts TS) DeleteTraining(userId int64, trainingId int64) error {
+	tx, err := ts.conn.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var status TrainingStatus
+	q := `SELECT status FROM trainings WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL FOR UPDATE`
+	err = tx.Get(&status, q, trainingId, userId)
+	if err == sql.ErrNoRows {
+		return NotDeleted
+	}
+	if err != nil {
+		return err
+	}
+	if status == Open || status == Paused {
+		return TrainingInProgress
+	}
+	_, err = tx.Exec(`UPDATE trainings SET deleted_at=now() WHERE id=$1`, trainingId)
+	if err != nil {
+		return err
+	}
+	_, err = deleteSets(tx, `s.training_id=$1`, trainingId)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
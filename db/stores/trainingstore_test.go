@@ -1,7 +1,9 @@
 package stores
 
 import (
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -19,8 +21,22 @@ func TestTSStartTrainingFindById(t *testing.T) {
 	if diff := cmp.Diff(empty, training); diff == "" {
 		t.Errorf("got wrong training value:%v", training)
 	}
-	if training.Begins != training.Finish {
-		t.Errorf("finish value isn't equal to begins value")
+	if training.Finish != nil || training.Status != Open {
+		t.Errorf("started training isn't open: %#v", training)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestTSStartTrainingInProgress(t *testing.T) {
+	ts.StartTraining(1)
+	_, err := ts.StartTraining(1)
+	if err != TrainingInProgress {
+		t.Errorf("error starting second training: %v", err)
+	}
+	//other users aren't affected
+	_, err = ts.StartTraining(2)
+	if err != nil {
+		t.Errorf("error starting training of other user: %v", err)
 	}
 	t.Cleanup(clearTables)
 }
@@ -38,15 +54,54 @@ func TestTSFinishTraining(t *testing.T) {
 		t.Fatalf("error finishing training: %v", err)
 	}
 	training, _ := ts.FindById(id)
-	if training.Begins == training.Finish {
-		t.Errorf("finish value didn't change")
+	if training.Finish == nil || training.Status != Finished {
+		t.Errorf("training wasn't finished: %#v", training)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestTSDeleteTraining(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	id, _ := ts.StartTraining(exercise.UserId)
+	ess.AddSet(ExerciseSet{UserId: exercise.UserId, ExerciseId: exercise.Id, Reps: 10})
+	//negative cases
+	err = ts.DeleteTraining(exercise.UserId, id)
+	if err != TrainingInProgress {
+		t.Errorf("deleted training in progress: %v", err)
+	}
+	ts.FinishTraining(exercise.UserId)
+	err = ts.DeleteTraining(exercise.UserId+1, id)
+	if err != NotDeleted {
+		t.Errorf("deleted training of other user: %v", err)
+	}
+	//positive case
+	err = ts.DeleteTraining(exercise.UserId, id)
+	if err != nil {
+		t.Fatalf("error deleting training: %v", err)
+	}
+	_, err = ts.FindById(id)
+	if err != sql.ErrNoRows {
+		t.Errorf("found training after deletion: %v", err)
+	}
+	sets, _ := ess.FindByTraining(exercise.UserId, id)
+	if len(sets) != 0 {
+		t.Errorf("found sets of deleted training: %#v", sets)
+	}
+	err = ts.DeleteTraining(exercise.UserId, id)
+	if err != NotDeleted {
+		t.Errorf("deleted training twice: %v", err)
 	}
 	t.Cleanup(clearTables)
 }
 
 func TestTSGetLastTraining(t *testing.T) {
 	ts.StartTraining(1)
+	ts.FinishTraining(1)
 	ts.StartTraining(1)
+	ts.FinishTraining(1)
 	lastId, _ := ts.StartTraining(1)
 	lastTraining, err := ts.GetLastTraining(1)
 	if err != nil {
@@ -71,3 +126,151 @@ func TestTSGetTrainings(t *testing.T) {
 		t.Errorf("Getting wrong amount of trainings")
 	}
 }
+
+func TestTSPauseResumeTraining(t *testing.T) {
+	//negative cases
+	err := ts.PauseTraining(1)
+	if err != TrainingNotOpen {
+		t.Errorf("error pausing unexisting training: %v", err)
+	}
+	id, _ := ts.StartTraining(1)
+	err = ts.ResumeTraining(1)
+	if err != TrainingNotPaused {
+		t.Errorf("error resuming open training: %v", err)
+	}
+	//positive case
+	err = ts.PauseTraining(1)
+	if err != nil {
+		t.Fatalf("error pausing training: %v", err)
+	}
+	training, _ := ts.FindById(id)
+	if training.Status != Paused {
+		t.Errorf("training wasn't paused: %#v", training)
+	}
+	err = ts.PauseTraining(1)
+	if err != TrainingNotOpen {
+		t.Errorf("error pausing paused training: %v", err)
+	}
+	err = ts.ResumeTraining(1)
+	if err != nil {
+		t.Fatalf("error resuming training: %v", err)
+	}
+	training, _ = ts.FindById(id)
+	if training.Status != Open {
+		t.Errorf("training wasn't resumed: %#v", training)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestTSActiveDuration(t *testing.T) {
+	//training lasted an hour with 20 minutes pause
+	begins := time.Now().Add(-time.Hour)
+	var id int64
+	conn.Get(&id, "INSERT INTO trainings(user_id, begins, finish, status) VALUES(1, $1, $2, 'finished') RETURNING id",
+		begins, begins.Add(time.Hour))
+	conn.Exec("INSERT INTO training_pauses(training_id, begins, finish) VALUES($1, $2, $3)",
+		id, begins.Add(10*time.Minute), begins.Add(30*time.Minute))
+	training, err := ts.FindById(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if training.ActiveDuration != 40*60 {
+		t.Errorf("got wrong active duration: %d", training.ActiveDuration)
+	}
+	//finishing paused training closes pause
+	ts.StartTraining(2)
+	ts.PauseTraining(2)
+	err = ts.FinishTraining(2)
+	if err != nil {
+		t.Fatalf("error finishing paused training: %v", err)
+	}
+	var openPauses int
+	conn.Get(&openPauses, "SELECT count(*) FROM training_pauses WHERE finish IS NULL")
+	if openPauses != 0 {
+		t.Errorf("pause wasn't closed with training")
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestTSLegacyAbandonedDuration(t *testing.T) {
+	//training abandoned by migration of statuses has no finish
+	var id int64
+	conn.Get(&id, "INSERT INTO trainings(user_id, begins, status) VALUES(1, $1, 'abandoned') RETURNING id",
+		time.Now().Add(-10*time.Hour))
+	conn.Exec("INSERT INTO training_pauses(training_id, begins) VALUES($1, $2)", id, time.Now().Add(-9*time.Hour))
+	training, err := ts.FindById(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if training.ActiveDuration != 0 {
+		t.Errorf("abandoned training without finish has duration: %d", training.ActiveDuration)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestTSCloseStale(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	//training without sets is abandoned
+	var abandonedId int64
+	conn.Get(&abandonedId, "INSERT INTO trainings(user_id, begins) VALUES(1, $1) RETURNING id",
+		time.Now().Add(-10*time.Hour))
+	//training with sets is finished at the time of the last set
+	var finishedId int64
+	lastSet := time.Now().Add(-7 * time.Hour).Truncate(time.Second)
+	conn.Get(&finishedId, "INSERT INTO trainings(user_id, begins) VALUES(2, $1) RETURNING id",
+		time.Now().Add(-8*time.Hour))
+	conn.Exec("INSERT INTO exercise_sets(user_id, exercise_id, training_id, reps, created_at) VALUES(2, $1, $2, 10, $3)",
+		exercise.Id, finishedId, lastSet)
+	//fresh training stays open
+	freshId, _ := ts.StartTraining(3)
+	//training resumed after long pause stays open
+	var resumedId int64
+	conn.Get(&resumedId, "INSERT INTO trainings(user_id, begins) VALUES(4, $1) RETURNING id",
+		time.Now().Add(-9*time.Hour))
+	conn.Exec("INSERT INTO training_pauses(training_id, begins, finish) VALUES($1, $2, $3)",
+		resumedId, time.Now().Add(-8*time.Hour), time.Now().Add(-time.Hour))
+	closed, err := ts.CloseStale(6 * time.Hour)
+	if err != nil {
+		t.Fatalf("error closing stale trainings: %v", err)
+	}
+	if len(closed) != 2 {
+		t.Fatalf("closed wrong amount of trainings: %#v", closed)
+	}
+	abandoned, _ := ts.FindById(abandonedId)
+	if abandoned.Status != Abandoned || abandoned.ActiveDuration != 0 {
+		t.Errorf("training without sets wasn't abandoned: %#v", abandoned)
+	}
+	finished, _ := ts.FindById(finishedId)
+	if finished.Status != Finished || finished.Finish == nil ||
+		!finished.Finish.Equal(lastSet) {
+		t.Errorf("training with sets wasn't finished at last set: %#v", finished)
+	}
+	fresh, _ := ts.FindById(freshId)
+	if fresh.Status != Open {
+		t.Errorf("fresh training was closed: %#v", fresh)
+	}
+	resumed, _ := ts.FindById(resumedId)
+	if resumed.Status != Open {
+		t.Errorf("training resumed after pause was closed: %#v", resumed)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestTSTimestampsInLocation(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	//wall clock of Berlin right after transition to summer time
+	begins := time.Date(2024, 3, 31, 3, 30, 0, 0, berlin)
+	var trainingId int64
+	conn.Get(&trainingId, "INSERT INTO trainings(user_id, begins) VALUES(1, $1) RETURNING id", begins)
+	training, err := ts.FindById(trainingId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !training.Begins.Equal(begins) {
+		t.Errorf("begins wasn't kept as the same moment: %s, expected %s", training.Begins, begins)
+	}
+	t.Cleanup(clearTables)
+}
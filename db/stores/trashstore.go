@@ -0,0 +1,224 @@
+package stores
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/fridrock/trainingservice/errkind"
+	"github.com/jmoiron/sqlx"
+)
+
+// TrashKind - kind of soft-deleted entity
+type TrashKind string
+
+const (
+	TrashGroup    TrashKind = "group"
+	TrashExercise TrashKind = "exercise"
+	TrashTraining TrashKind = "training"
+	TrashSet      TrashKind = "set"
+)
+
+var ParentDeleted = errkind.New(errkind.Conflict, "parent of deleted item is deleted, restore it first")
+
+// TrashItem - soft-deleted entity of user. Name is name of group or exercise, for sets it is name of their exercise
+// and it is empty for trainings
+type TrashItem struct {
+	Kind      TrashKind `db:"kind" json:"kind"`
+	Id        int64     `db:"id" json:"id"`
+	Name      string    `db:"name" json:"name,omitempty"`
+	DeletedAt time.Time `db:"deleted_at" json:"deleted_at"`
+}
+
+// TrashStore - interface which contains all methods for working with soft-deleted groups, exercises, trainings
+// and sets
+type TrashStore interface {
+	FindByUser(userId int64) ([]TrashItem, error)
+	Restore(userId int64, kind TrashKind, id int64) error
+	Purge(before time.Time) (int64, error)
+}
+
+// TRS - standard realization of TrashStore
+type TRS struct {
+	conn *sqlx.DB
+}
+
+// NewTRS - function that creates realization for TrashStore interface
+func NewTRS(conn *sqlx.DB) *TRS {
+	return &TRS{
+		conn: conn,
+	}
+}
+
+// FindByUser - deleted items of user, the most recently deleted first. Items deleted together with their parent
+// aren't listed, they are restored with it
+func (trs TRS) FindByUser(userId int64) ([]TrashItem, error) {
+	var items []TrashItem
+	q := `SELECT 'group' AS kind, g.id, g.name, g.deleted_at FROM exercise_groups g
+			WHERE g.user_id=$1 AND g.deleted_at IS NOT NULL
+		UNION ALL
+		SELECT 'exercise', e.id, e.name, e.deleted_at FROM exercises e
+			JOIN exercise_groups g ON g.id=e.exercise_group_id
+			WHERE e.user_id=$1 AND e.deleted_at IS NOT NULL AND g.deleted_at IS DISTINCT FROM e.deleted_at
+		UNION ALL
+		SELECT 'training', t.id, '', t.deleted_at FROM trainings t
+			WHERE t.user_id=$1 AND t.deleted_at IS NOT NULL
+		UNION ALL
+		SELECT 'set', s.id, e.name, s.deleted_at FROM exercise_sets s
+			JOIN exercises e ON e.id=s.exercise_id
+			LEFT JOIN trainings t ON t.id=s.training_id
+			WHERE s.user_id=$1 AND s.deleted_at IS NOT NULL AND e.deleted_at IS DISTINCT FROM s.deleted_at
+				AND t.deleted_at IS DISTINCT FROM s.deleted_at
+		ORDER BY deleted_at DESC, kind, id`
+	err := trs.conn.Select(&items, q, userId)
+	return items, err
+}
+
+// Restore - restores deleted item of user together with items deleted with it. Returns NotUpdated if user has no
+// such deleted item, ParentDeleted if group of exercise or training or exercise of set is deleted, NameTaken
+// if user has other group with the same name, ExerciseNameTaken if group has other exercise with the same name and
+// AlreadyLinked if group has other copy of restored catalogue exercise
+func (trs TRS) Restore(userId int64, kind TrashKind, id int64) error {
+	tx, err := trs.conn.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	switch kind {
+	case TrashGroup:
+		err = restoreGroupById(tx, userId, id)
+	case TrashExercise:
+		err = restoreExercise(tx, userId, id)
+	case TrashTraining:
+		err = restoreTraining(tx, userId, id)
+	case TrashSet:
+		err = restoreSet(tx, userId, id)
+	default:
+		err = NotUpdated
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// deletedItem - deletion time of item and whether its parent is deleted
+type deletedItem struct {
+	DeletedAt     time.Time `db:"deleted_at"`
+	ParentDeleted bool      `db:"parent_deleted"`
+}
+
+// getDeleted - locks deleted item selected by q, returns NotUpdated if there is no such and ParentDeleted if its
+// parent is deleted
+func getDeleted(tx *sqlx.Tx, q string, args ...any) (time.Time, error) {
+	var item deletedItem
+	err := tx.Get(&item, q, args...)
+	if err == sql.ErrNoRows {
+		return item.DeletedAt, NotUpdated
+	}
+	if err != nil {
+		return item.DeletedAt, err
+	}
+	if item.ParentDeleted {
+		return item.DeletedAt, ParentDeleted
+	}
+	return item.DeletedAt, nil
+}
+
+func restoreGroupById(tx *sqlx.Tx, userId int64, id int64) error {
+	var deleted deletedGroup
+	q := `SELECT id, user_id, name, deleted_at FROM exercise_groups
+		WHERE id=$1 AND user_id=$2 AND deleted_at IS NOT NULL FOR UPDATE`
+	err := tx.Get(&deleted, q, id, userId)
+	if err == sql.ErrNoRows {
+		return NotUpdated
+	}
+	if err != nil {
+		return err
+	}
+	return restoreGroup(tx, deleted)
+}
+
+func restoreExercise(tx *sqlx.Tx, userId int64, id int64) error {
+	q := `SELECT e.deleted_at, g.deleted_at IS NOT NULL AS parent_deleted FROM exercises e
+		JOIN exercise_groups g ON g.id=e.exercise_group_id
+		WHERE e.id=$1 AND e.user_id=$2 AND e.deleted_at IS NOT NULL FOR UPDATE OF e`
+	deletedAt, err := getDeleted(tx, q, id, userId)
+	if err != nil {
+		return err
+	}
+	err = restoreSets(tx, `s.exercise_id=$1 AND s.deleted_at=$2`, id, deletedAt)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`UPDATE exercises SET deleted_at=NULL WHERE id=$1`, id)
+	return checkExercise(err)
+}
+
+func restoreTraining(tx *sqlx.Tx, userId int64, id int64) error {
+	q := `SELECT deleted_at, false AS parent_deleted FROM trainings
+		WHERE id=$1 AND user_id=$2 AND deleted_at IS NOT NULL FOR UPDATE`
+	deletedAt, err := getDeleted(tx, q, id, userId)
+	if err != nil {
+		return err
+	}
+	err = restoreSets(tx, `s.training_id=$1 AND s.deleted_at=$2`, id, deletedAt)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`UPDATE trainings SET deleted_at=NULL WHERE id=$1`, id)
+	return err
+}
+
+func restoreSet(tx *sqlx.Tx, userId int64, id int64) error {
+	q := `SELECT s.deleted_at, e.deleted_at IS NOT NULL OR t.deleted_at IS NOT NULL AS parent_deleted
+		FROM exercise_sets s
+		JOIN exercises e ON e.id=s.exercise_id
+		LEFT JOIN trainings t ON t.id=s.training_id
+		WHERE s.id=$1 AND s.user_id=$2 AND s.deleted_at IS NOT NULL FOR UPDATE OF s`
+	_, err := getDeleted(tx, q, id, userId)
+	if err != nil {
+		return err
+	}
+	return restoreSets(tx, `s.id=$1`, id)
+}
+
+// Purge - permanently deletes items deleted before the time, together with their children. Returns number of
+// deleted groups, exercises, trainings and sets. Purged exercises are removed from templates and progressions of
+// programs by cascade, templates and programs keep their other exercises
+func (trs TRS) Purge(before time.Time) (int64, error) {
+	tx, err := trs.conn.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	//pauses are part of training, so they aren't counted
+	q := `DELETE FROM training_pauses WHERE training_id IN (SELECT id FROM trainings WHERE deleted_at<$1)`
+	_, err = tx.Exec(q, before)
+	if err != nil {
+		return 0, err
+	}
+	purgedExercises := `SELECT e.id FROM exercises e JOIN exercise_groups g ON g.id=e.exercise_group_id
+		WHERE e.deleted_at<$1 OR g.deleted_at<$1`
+	queries := []string{
+		`DELETE FROM exercise_sets s WHERE s.deleted_at<$1
+			OR s.training_id IN (SELECT id FROM trainings WHERE deleted_at<$1)
+			OR s.exercise_id IN (` + purgedExercises + `)`,
+		`DELETE FROM trainings WHERE deleted_at<$1`,
+		`DELETE FROM exercises WHERE id IN (` + purgedExercises + `)`,
+		`DELETE FROM exercise_groups g WHERE g.deleted_at<$1
+			AND NOT EXISTS (SELECT 1 FROM exercises e WHERE e.exercise_group_id=g.id)`,
+	}
+	var purged int64
+	for _, q := range queries {
+		res, err := tx.Exec(q, before)
+		if err != nil {
+			return 0, err
+		}
+		r, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		purged += r
+	}
+	return purged, tx.Commit()
+}
@@ -0,0 +1,128 @@
+package stores
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTRSFindRestore(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	trainingId, _ := ts.StartTraining(exercise.UserId)
+	set, _ := ess.AddSet(ExerciseSet{UserId: exercise.UserId, ExerciseId: exercise.Id, Weight: 60, Reps: 10})
+	prs.Detect(set)
+	ts.FinishTraining(exercise.UserId)
+	ts.DeleteTraining(exercise.UserId, trainingId)
+	//set deleted with training is restored only with it
+	items, err := trs.FindByUser(exercise.UserId)
+	if err != nil {
+		t.Fatalf("error finding trash: %v", err)
+	}
+	if len(items) != 1 || items[0].Kind != TrashTraining || items[0].Id != trainingId {
+		t.Errorf("got wrong trash: %#v", items)
+	}
+	err = trs.Restore(exercise.UserId, TrashSet, set.Id)
+	if err != ParentDeleted {
+		t.Errorf("restored set of deleted training: %v", err)
+	}
+	err = trs.Restore(exercise.UserId, TrashTraining, trainingId)
+	if err != nil {
+		t.Fatalf("error restoring training: %v", err)
+	}
+	sets, _ := ess.FindByTraining(exercise.UserId, trainingId)
+	if len(sets) != 1 || sets[0].Id != set.Id {
+		t.Errorf("set wasn't restored with training: %#v", sets)
+	}
+	records, _ := prs.FindByExercise(exercise.UserId, exercise.Id)
+	if len(records) == 0 {
+		t.Errorf("records of restored set weren't rebuilt")
+	}
+	err = trs.Restore(exercise.UserId, TrashTraining, trainingId)
+	if err != NotUpdated {
+		t.Errorf("restored training twice: %v", err)
+	}
+	//exercise deleted with group is restored only with it
+	egs.SoftDeleteByName(defaultExGroup.UserId, defaultExGroup.Name)
+	items, _ = trs.FindByUser(exercise.UserId)
+	if len(items) != 1 || items[0].Kind != TrashGroup || items[0].Id != exercise.ExerciseGroupId {
+		t.Errorf("got wrong trash: %#v", items)
+	}
+	err = trs.Restore(exercise.UserId, TrashExercise, exercise.Id)
+	if err != ParentDeleted {
+		t.Errorf("restored exercise of deleted group: %v", err)
+	}
+	err = trs.Restore(exercise.UserId, TrashGroup, exercise.ExerciseGroupId)
+	if err != nil {
+		t.Fatalf("error restoring group: %v", err)
+	}
+	sets, _ = ess.FindByTraining(exercise.UserId, trainingId)
+	if len(sets) != 1 {
+		t.Errorf("set wasn't restored with group: %#v", sets)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestTRSPurge(t *testing.T) {
+	exercise, err := createDefaultExercise()
+	if err != nil {
+		t.Fatalf("error saving exercise: %v", err)
+	}
+	trainingId, _ := ts.StartTraining(exercise.UserId)
+	ess.AddSet(ExerciseSet{UserId: exercise.UserId, ExerciseId: exercise.Id, Reps: 10})
+	ts.PauseTraining(exercise.UserId)
+	ts.FinishTraining(exercise.UserId)
+	ts.DeleteTraining(exercise.UserId, trainingId)
+	egs.SoftDeleteByName(defaultExGroup.UserId, defaultExGroup.Name)
+	//items deleted after the time aren't purged
+	purged, err := trs.Purge(time.Now().Add(-time.Hour))
+	if err != nil || purged != 0 {
+		t.Errorf("purged recently deleted items: %d, %v", purged, err)
+	}
+	purged, err = trs.Purge(time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("error purging trash: %v", err)
+	}
+	//group, exercise, training and set
+	if purged != 4 {
+		t.Errorf("purged wrong number of items: %d", purged)
+	}
+	items, _ := trs.FindByUser(exercise.UserId)
+	if len(items) != 0 {
+		t.Errorf("found purged items: %#v", items)
+	}
+	t.Cleanup(clearTables)
+}
+
+func TestTRSPurgeUsedExercise(t *testing.T) {
+	program, err := createDefaultProgram()
+	if err != nil {
+		t.Fatalf("error saving program: %v", err)
+	}
+	templateId := program.Days[0].TemplateId
+	egs.SoftDeleteByName(defaultExGroup.UserId, defaultExGroup.Name)
+	//exercise is purged with its group and removed from template and progressions of program
+	purged, err := trs.Purge(time.Now().Add(time.Hour))
+	if err != nil || purged != 2 {
+		t.Errorf("purged wrong number of items: %d, %v", purged, err)
+	}
+	template, err := tps.FindById(program.UserId, templateId)
+	if err != nil || len(template.Exercises) != 0 {
+		t.Errorf("got wrong template after purging its exercise: %#v, %v", template, err)
+	}
+	var exercises int
+	conn.Get(&exercises, `SELECT count(*) FROM template_exercises WHERE template_id=$1`, templateId)
+	if exercises != 0 {
+		t.Errorf("purged exercise is kept in template")
+	}
+	found, err := pgs.FindById(program.UserId, program.Id)
+	if err != nil || len(found.Progressions) != 0 || len(found.Days) != 2 {
+		t.Errorf("got wrong program after purging exercise: %#v, %v", found, err)
+	}
+	items, _ := trs.FindByUser(program.UserId)
+	if len(items) != 0 {
+		t.Errorf("found purged items: %#v", items)
+	}
+	t.Cleanup(clearTables)
+}
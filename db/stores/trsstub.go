@@ -0,0 +1,52 @@
+package stores
+
+import (
+	"database/sql"
+	"time"
+)
+
+type TrashStoreStub struct{}
+
+func (trss TrashStoreStub) FindByUser(userId int64) ([]TrashItem, error) {
+	if userId == 1 {
+		return nil, sql.ErrNoRows
+	}
+	deletedAt := time.Date(2024, 9, 10, 12, 0, 0, 0, time.UTC)
+	return []TrashItem{
+		{
+			Kind:      TrashSet,
+			Id:        3,
+			Name:      "Bench press",
+			DeletedAt: deletedAt,
+		},
+		{
+			Kind:      TrashTraining,
+			Id:        2,
+			DeletedAt: deletedAt.Add(-time.Hour),
+		},
+		{
+			Kind:      TrashGroup,
+			Id:        1,
+			Name:      "Back",
+			DeletedAt: deletedAt.Add(-2 * time.Hour),
+		},
+	}, nil
+}
+
+// Restore - item 404 doesn't exist, exercise 2 is in deleted group, group 3 has name of existing one
+func (trss TrashStoreStub) Restore(userId int64, kind TrashKind, id int64) error {
+	if id == 404 {
+		return NotUpdated
+	}
+	if kind == TrashExercise && id == 2 {
+		return ParentDeleted
+	}
+	if kind == TrashGroup && id == 3 {
+		return NameTaken
+	}
+	return nil
+}
+
+func (trss TrashStoreStub) Purge(before time.Time) (int64, error) {
+	return 0, nil
+}
@@ -10,39 +10,86 @@ type TrainingStoreStub struct {
 }
 
 func (tss TrainingStoreStub) StartTraining(userId int64) (int64, error) {
+	if userId == 3 {
+		return 0, TrainingInProgress
+	}
 	return 12, nil
 }
 
+// StartFromTemplate - template 404 doesn't exist
+func (tss TrainingStoreStub) StartFromTemplate(userId int64, templateId int64) (int64, error) {
+	if templateId == 404 {
+		return 0, sql.ErrNoRows
+	}
+	return tss.StartTraining(userId)
+}
+
 func (tss TrainingStoreStub) FinishTraining(userId int64) error {
 	if userId == 1 {
 		return AllTrainingsFinished
 	}
 	return nil
 }
+func (tss TrainingStoreStub) PauseTraining(userId int64) error {
+	if userId == 1 {
+		return TrainingNotOpen
+	}
+	return nil
+}
+
+func (tss TrainingStoreStub) ResumeTraining(userId int64) error {
+	if userId == 1 {
+		return TrainingNotPaused
+	}
+	return nil
+}
+
 func (tss TrainingStoreStub) GetTrainings(userId int64) ([]Training, error) {
 	var trainings []Training
 	if userId == 1 {
 		return trainings, sql.ErrNoRows
 	}
+	finish := time.Now()
 	trainings = []Training{
 		{
-			Id:     1,
-			UserId: userId,
-			Begins: time.Now(),
-			Finish: time.Now(),
+			Id:             1,
+			UserId:         userId,
+			Begins:         time.Now(),
+			Finish:         &finish,
+			Status:         Finished,
+			ActiveDuration: 3600,
 		},
 		{
-			Id:     2,
-			UserId: userId,
-			Begins: time.Now(),
-			Finish: time.Now(),
+			Id:             2,
+			UserId:         userId,
+			Begins:         time.Now(),
+			Finish:         &finish,
+			Status:         Finished,
+			ActiveDuration: 3600,
 		},
 		{
-			Id:     3,
-			UserId: userId,
-			Begins: time.Now(),
-			Finish: time.Now(),
+			Id:             3,
+			UserId:         userId,
+			Begins:         time.Now(),
+			Finish:         &finish,
+			Status:         Finished,
+			ActiveDuration: 3600,
 		},
 	}
 	return trainings, nil
 }
+
+func (tss TrainingStoreStub) CloseStale(maxDuration time.Duration) ([]Training, error) {
+	return nil, nil
+}
+
+// DeleteTraining - training 404 doesn't exist, user 3 has training in progress
+func (tss TrainingStoreStub) DeleteTraining(userId int64, trainingId int64) error {
+	if trainingId == 404 {
+		return NotDeleted
+	}
+	if userId == 3 {
+		return TrainingInProgress
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package stores
+
+type SettingsStoreStub struct{}
+
+// Find - user 3 lives in Europe/Moscow, user 5 uses pounds and miles, others have default settings
+func (usss SettingsStoreStub) Find(userId int64) (Settings, error) {
+	settings := DefaultSettings(userId)
+	switch userId {
+	case 3:
+		settings.Timezone = "Europe/Moscow"
+	case 5:
+		settings.WeightUnit = "lb"
+		settings.DistanceUnit = "mi"
+		settings.WeekStart = "sun"
+	}
+	return settings, nil
+}
+
+func (usss SettingsStoreStub) Save(settings Settings) error {
+	return nil
+}
@@ -1,12 +1,207 @@
 CREATE TABLE IF NOT EXISTS exercise_groups(
     id SERIAL PRIMARY KEY,
     user_id INTEGER NOT NULL,
-    name varchar(100) NOT NULL
+    name varchar(100) NOT NULL,
+    muscles text[] NOT NULL DEFAULT '{}',
+    deleted_at timestamptz
 );
 
+CREATE UNIQUE INDEX IF NOT EXISTS exercise_groups_one_name_per_user_idx
+    ON exercise_groups(user_id, lower(name)) WHERE deleted_at IS NULL;
+
 CREATE TABLE IF NOT EXISTS trainings(
     id SERIAL PRIMARY KEY,
     user_id INTEGER NOT NULL,
-    begins timestamp NOT NULL,
-    finish timestamp 
-);
\ No newline at end of file
+    begins timestamptz NOT NULL,
+    finish timestamptz,
+    status varchar(20) NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'paused', 'finished', 'abandoned')),
+    deleted_at timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS trainings_one_in_progress_per_user_idx
+    ON trainings(user_id) WHERE status IN ('open', 'paused');
+
+CREATE TABLE IF NOT EXISTS training_pauses(
+    id SERIAL PRIMARY KEY,
+    training_id INTEGER NOT NULL REFERENCES trainings(id),
+    begins timestamptz NOT NULL,
+    finish timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS training_pauses_one_open_per_training_idx
+    ON training_pauses(training_id) WHERE finish IS NULL;
+
+CREATE TABLE IF NOT EXISTS exercise_types(
+    id SERIAL PRIMARY KEY,
+    name varchar(100) NOT NULL
+);
+
+INSERT INTO exercise_types(name) VALUES ('CARDIO'),  ('WORKOUT'), ('GYM');
+
+CREATE TABLE IF NOT EXISTS catalogue_exercises(
+    id INTEGER PRIMARY KEY,
+    name varchar(100) NOT NULL UNIQUE,
+    aliases text[] NOT NULL DEFAULT '{}',
+    primary_muscles text[] NOT NULL DEFAULT '{}',
+    secondary_muscles text[] NOT NULL DEFAULT '{}',
+    equipment varchar(50) NOT NULL,
+    exercise_type_id INTEGER NOT NULL REFERENCES exercise_types(id)
+);
+
+CREATE TABLE IF NOT EXISTS exercises(
+    id SERIAL PRIMARY KEY,
+    name varchar(100) NOT NULL,
+    description varchar(100),
+    rest interval NOT NULL,
+    exercise_type_id INTEGER REFERENCES exercise_types(id) NOT NULL,
+    user_id INTEGER NOT NULL,
+    exercise_group_id INTEGER REFERENCES exercise_groups(id) NOT NULL,
+    catalogue_id INTEGER REFERENCES catalogue_exercises(id),
+    muscles text[] NOT NULL DEFAULT '{}',
+    deleted_at timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS exercises_one_copy_per_group_idx
+    ON exercises(user_id, exercise_group_id, catalogue_id) WHERE catalogue_id IS NOT NULL AND deleted_at IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS exercises_one_name_per_group_idx
+    ON exercises(user_id, exercise_group_id, lower(name)) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS exercise_sets(
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id),
+    weight REAL,
+    reps INTEGER,
+    duration interval,
+    training_id INTEGER REFERENCES trainings(id),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    rpe REAL CHECK (rpe BETWEEN 1 AND 10),
+    distance double precision CHECK (distance > 0),
+    elevation REAL CHECK (elevation >= 0),
+    avg_heart_rate INTEGER CHECK (avg_heart_rate > 0),
+    max_heart_rate INTEGER CHECK (max_heart_rate > 0),
+    calories INTEGER CHECK (calories > 0),
+    deleted_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS personal_records(
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    kind varchar(20) NOT NULL CHECK (kind IN ('max_weight', 'max_reps', 'best_1rm', 'max_duration')),
+    weight REAL NOT NULL DEFAULT 0,
+    value double precision NOT NULL,
+    set_id INTEGER NOT NULL REFERENCES exercise_sets(id) ON DELETE CASCADE,
+    achieved_at timestamptz NOT NULL,
+    UNIQUE (user_id, exercise_id, kind, weight)
+);
+
+CREATE TABLE IF NOT EXISTS workout_templates(
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    name varchar(100) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS template_exercises(
+    id SERIAL PRIMARY KEY,
+    template_id INTEGER NOT NULL REFERENCES workout_templates(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    sets INTEGER NOT NULL CHECK (sets > 0),
+    reps INTEGER NOT NULL DEFAULT 0,
+    weight REAL NOT NULL DEFAULT 0,
+    rest interval NOT NULL DEFAULT interval '0',
+    UNIQUE (template_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS planned_sets(
+    id SERIAL PRIMARY KEY,
+    training_id INTEGER NOT NULL REFERENCES trainings(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    set_number INTEGER NOT NULL,
+    reps INTEGER NOT NULL DEFAULT 0,
+    weight REAL NOT NULL DEFAULT 0,
+    rest interval NOT NULL DEFAULT interval '0',
+    set_id INTEGER REFERENCES exercise_sets(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS programs(
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    name varchar(100) NOT NULL,
+    weeks INTEGER NOT NULL CHECK (weeks > 0),
+    deload_every INTEGER NOT NULL DEFAULT 0,
+    deload_factor REAL NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS program_days(
+    id SERIAL PRIMARY KEY,
+    program_id INTEGER NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    template_id INTEGER NOT NULL REFERENCES workout_templates(id),
+    UNIQUE (program_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS program_progressions(
+    program_id INTEGER NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    increment REAL NOT NULL,
+    PRIMARY KEY (program_id, exercise_id)
+);
+
+CREATE TABLE IF NOT EXISTS program_enrollments(
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    program_id INTEGER NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    status varchar(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'cancelled')),
+    day_index INTEGER NOT NULL DEFAULT 0,
+    skipped INTEGER NOT NULL DEFAULT 0,
+    started_at timestamptz NOT NULL,
+    advanced_at timestamptz NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS program_enrollments_one_active_per_user_idx
+    ON program_enrollments(user_id) WHERE status = 'active';
+
+CREATE TABLE IF NOT EXISTS rest_timers(
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL UNIQUE,
+    training_id INTEGER NOT NULL REFERENCES trainings(id) ON DELETE CASCADE,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    set_id INTEGER NOT NULL REFERENCES exercise_sets(id) ON DELETE CASCADE,
+    rest interval NOT NULL,
+    fires_at timestamptz NOT NULL,
+    claimed_until timestamptz
+);
+CREATE INDEX IF NOT EXISTS rest_timers_fires_at_idx ON rest_timers(fires_at);
+
+CREATE TABLE IF NOT EXISTS training_schedules(
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    weekday varchar(3) NOT NULL CHECK (weekday IN ('mon', 'tue', 'wed', 'thu', 'fri', 'sat', 'sun')),
+    at time NOT NULL,
+    timezone varchar(64),
+    created_at timestamptz NOT NULL,
+    reminded_at timestamptz,
+    missed_at timestamptz,
+    UNIQUE (user_id, weekday, at)
+);
+
+CREATE TABLE IF NOT EXISTS user_settings(
+    user_id INTEGER PRIMARY KEY,
+    timezone varchar(64) NOT NULL DEFAULT 'UTC',
+    weight_unit varchar(2) NOT NULL DEFAULT 'kg' CHECK (weight_unit IN ('kg', 'lb')),
+    distance_unit varchar(2) NOT NULL DEFAULT 'km' CHECK (distance_unit IN ('km', 'mi')),
+    default_rest interval NOT NULL DEFAULT interval '0',
+    week_start varchar(3) NOT NULL DEFAULT 'mon'
+        CHECK (week_start IN ('mon', 'tue', 'wed', 'thu', 'fri', 'sat', 'sun'))
+);
+
+CREATE TABLE IF NOT EXISTS processed_messages(
+    key varchar(255) PRIMARY KEY,
+    response text,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
@@ -0,0 +1,39 @@
+// Package errkind - kinds of expected errors. Stores and helpers declare their errors with kind, so responses
+// can map errors to codes without depending on packages, which declare them
+package errkind
+
+import "errors"
+
+// Kind - what caller did wrong, when error isn't failure of service
+type Kind int
+
+const (
+	// NotFound - requested entity doesn't exist
+	NotFound Kind = iota + 1
+	// Conflict - request can't be done in current state of entity
+	Conflict
+)
+
+// Error - error of kind, errors are compared by identity like errors.New ones
+type Error struct {
+	Kind    Kind
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// New - creates error of kind with message
+func New(kind Kind, message string) error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// Of - returns kind of the first error of kind in chain of err, zero if there is no such error
+func Of(err error) Kind {
+	var kindErr *Error
+	if errors.As(err, &kindErr) {
+		return kindErr.Kind
+	}
+	return 0
+}
@@ -0,0 +1,31 @@
+package errkind
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestOf(t *testing.T) {
+	notFound := New(NotFound, "no rows updated")
+	data := []struct {
+		testName     string
+		err          error
+		expectedKind Kind
+	}{
+		{"no error", nil, 0},
+		{"error without kind", errors.New("connection refused"), 0},
+		{"error of kind", notFound, NotFound},
+		{"wrapped error of kind", fmt.Errorf("error updating: %w", New(Conflict, "name taken")), Conflict},
+	}
+	for _, d := range data {
+		t.Run(d.testName, func(t *testing.T) {
+			if kind := Of(d.err); kind != d.expectedKind {
+				t.Errorf("got kind %d", kind)
+			}
+		})
+	}
+	if errors.Is(notFound, New(NotFound, "no rows updated")) {
+		t.Error("errors with the same message are equal")
+	}
+}
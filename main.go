@@ -4,7 +4,9 @@ import (
 	"log"
 
 	rs "github.com/fridrock/rabbitsimplier"
+	"github.com/fridrock/trainingservice/api/producers"
 	"github.com/fridrock/trainingservice/api/routers"
+	"github.com/fridrock/trainingservice/db/core"
 )
 
 // defining answer event
@@ -30,13 +32,76 @@ func main() {
 	//setting up configurer
 	brc := setupConfigurer()
 	defer brc.Stop()
+	//setting up retry and dead letter exchanges
+	err := routers.SetupRetryTopology(brc)
+	if err != nil {
+		log.Fatalf("error creating retry topology: %v", err)
+	}
+	//setting up connection to database, which is shared by all routers and background jobs
+	conn := core.CreateConnection()
+	defer conn.Close()
 	//setting up routers
-	exgroupRouter := routers.NewExGroupRouter(brc)
+	exgroupRouter := routers.NewExGroupRouter(brc, conn)
 	exgroupRouter.Setup()
 	defer exgroupRouter.Stop()
-	trainingsRouter := routers.NewTrainingRouter(brc)
+	trainingsRouter := routers.NewTrainingRouter(brc, conn)
 	trainingsRouter.Setup()
 	defer trainingsRouter.Stop()
+	exerciseRouter := routers.NewExerciseRouter(brc, conn)
+	exerciseRouter.Setup()
+	defer exerciseRouter.Stop()
+	exerciseTypeRouter := routers.NewExerciseTypeRouter(brc, conn)
+	exerciseTypeRouter.Setup()
+	defer exerciseTypeRouter.Stop()
+	catalogueRouter := routers.NewCatalogueRouter(brc, conn)
+	catalogueRouter.Setup()
+	defer catalogueRouter.Stop()
+	exerciseSetRouter := routers.NewExerciseSetRouter(brc, conn)
+	exerciseSetRouter.Setup()
+	defer exerciseSetRouter.Stop()
+	statsRouter := routers.NewStatsRouter(brc, conn)
+	statsRouter.Setup()
+	defer statsRouter.Stop()
+	recordRouter := routers.NewRecordRouter(brc, conn)
+	recordRouter.Setup()
+	defer recordRouter.Stop()
+	analyticsRouter := routers.NewAnalyticsRouter(brc, conn)
+	analyticsRouter.Setup()
+	defer analyticsRouter.Stop()
+	templateRouter := routers.NewTemplateRouter(brc, conn)
+	templateRouter.Setup()
+	defer templateRouter.Stop()
+	programRouter := routers.NewProgramRouter(brc, conn)
+	programRouter.Setup()
+	defer programRouter.Stop()
+	suggestRouter := routers.NewSuggestRouter(brc, conn)
+	suggestRouter.Setup()
+	defer suggestRouter.Stop()
+	scheduleRouter := routers.NewScheduleRouter(brc, conn)
+	scheduleRouter.Setup()
+	defer scheduleRouter.Stop()
+	settingsRouter := routers.NewSettingsRouter(brc, conn)
+	settingsRouter.Setup()
+	defer settingsRouter.Stop()
+	trashRouter := routers.NewTrashRouter(brc, conn)
+	trashRouter.Setup()
+	defer trashRouter.Stop()
+	//setting up background jobs
+	trainingSweeper := producers.NewTrainingSweeper(brc, conn)
+	trainingSweeper.Setup()
+	defer trainingSweeper.Stop()
+	restScheduler := producers.NewRestScheduler(brc, conn)
+	restScheduler.Setup()
+	defer restScheduler.Stop()
+	trainingReminder := producers.NewTrainingReminder(brc, conn)
+	trainingReminder.Setup()
+	defer trainingReminder.Stop()
+	trashPurger := producers.NewTrashPurger(conn)
+	trashPurger.Setup()
+	defer trashPurger.Stop()
+	messagePurger := producers.NewMessagePurger(conn)
+	messagePurger.Setup()
+	defer messagePurger.Stop()
 	//infinite work of service
 	var forever chan struct{}
 	log.Printf(" [*] Waiting for messages. To exit press CTRL+C")